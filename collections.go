@@ -0,0 +1,149 @@
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/boltdb/bolt"
+	"github.com/gorilla/mux"
+)
+
+// collection is a folder of the index page.
+type collection struct {
+	Name  string
+	Count int
+}
+
+// collections returns the collections of the books, in alphabetical order.
+func collections(books []Book) []collection {
+	var list []collection
+	index := make(map[string]int)
+	for _, b := range books {
+		if b.Collection == "" {
+			continue
+		}
+		i, ok := index[b.Collection]
+		if !ok {
+			i = len(list)
+			index[b.Collection] = i
+			list = append(list, collection{Name: b.Collection})
+		}
+		list[i].Count++
+	}
+	sort.Slice(list, func(i, j int) bool { return strings.ToLower(list[i].Name) < strings.ToLower(list[j].Name) })
+	return list
+}
+
+// sortOrder is an order of the books on the index page.
+type sortOrder struct {
+	Name  string
+	Title string
+}
+
+var bookSortOrders = []sortOrder{
+	{"activity", "Last activity"},
+	{"title", "Title"},
+	{"created", "Creation date"},
+	{"progress", "Progress"},
+	{"size", "Size"},
+}
+
+// sortBooks sorts the books, which are expected to be sorted by activity,
+// in the given order. The order of the books which compare equal is kept.
+func sortBooks(books []Book, by string) {
+	var less func(a, b Book) bool
+	switch by {
+	case "title":
+		less = func(a, b Book) bool { return strings.ToLower(a.Title) < strings.ToLower(b.Title) }
+	case "created":
+		less = func(a, b Book) bool { return b.Created.Before(a.Created) }
+	case "progress":
+		less = func(a, b Book) bool {
+			// Compare a.Translated/a.Total with b.Translated/b.Total.
+			return a.FragmentsTranslated*b.FragmentsTotal > b.FragmentsTranslated*a.FragmentsTotal
+		}
+	case "size":
+		less = func(a, b Book) bool { return a.FragmentsTotal > b.FragmentsTotal }
+	default:
+		return
+	}
+	sort.SliceStable(books, func(i, j int) bool { return less(books[i], books[j]) })
+}
+
+// updateBookInfo applies fn to the book in the index.
+func (db *DB) updateBookInfo(bid uint64, fn func(*bookInfo)) error {
+	return db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte("index"))
+		var book bookInfo
+		if found, err := unmarshal(b, bid, &book); err != nil {
+			return err
+		} else if !found {
+			return ErrNotFound
+		}
+		fn(&book)
+		return marshal(b, bid, book)
+	})
+}
+
+func (db *DB) SetBookCollection(bid uint64, name string) error {
+	return db.updateBookInfo(bid, func(book *bookInfo) { book.Collection = name })
+}
+
+func (db *DB) ArchiveBook(bid uint64, archived bool) error {
+	return db.updateBookInfo(bid, func(book *bookInfo) { book.Archived = archived })
+}
+
+func (a *App) BookCollection(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	bid, err := u64(vars["book_id"])
+	if err != nil {
+		http.Error(w, "Invalid book ID", http.StatusBadRequest)
+		return
+	}
+
+	name := strings.Join(strings.Fields(r.FormValue("collection")), " ")
+	if err := a.db.SetBookCollection(bid, name); err != nil {
+		if err == ErrNotFound {
+			http.Error(w, "Book not found", 404)
+			return
+		}
+		internalError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (a *App) ArchiveBook(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	bid, err := u64(vars["book_id"])
+	if err != nil {
+		http.Error(w, "Invalid book ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := a.db.ArchiveBook(bid, r.Method == "POST"); err != nil {
+		if err == ErrNotFound {
+			http.Error(w, "Book not found", 404)
+			return
+		}
+		internalError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
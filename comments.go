@@ -0,0 +1,418 @@
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/boltdb/bolt"
+	"github.com/gorilla/mux"
+)
+
+// Comment is an entry of a comment thread.
+type Comment struct {
+	ID      uint64    `json:"id"`
+	Author  string    `json:"author,omitempty"`
+	Created time.Time `json:"created"`
+	Text    string    `json:"text"`
+}
+
+// Thread is a discussion of a fragment: the first comment and the replies
+// to it.
+type Thread struct {
+	ID       uint64    `json:"id"`
+	Resolved bool      `json:"resolved"`
+	Comments []Comment `json:"comments"`
+}
+
+// HasUnresolvedThreads reports whether any of the threads of the fragment
+// is still open.
+func (f Fragment) HasUnresolvedThreads() bool {
+	for _, t := range f.Threads {
+		if !t.Resolved {
+			return true
+		}
+	}
+	return false
+}
+
+func (f *Fragment) thread(tid uint64) *Thread {
+	for i := range f.Threads {
+		if f.Threads[i].ID == tid {
+			return &f.Threads[i]
+		}
+	}
+	return nil
+}
+
+// nextCommentID returns a new ID for a thread or a comment of the fragment.
+// The fragments saved before CommentSeq was kept start from the largest ID
+// in use.
+func (f *Fragment) nextCommentID() uint64 {
+	for _, t := range f.Threads {
+		if t.ID > f.CommentSeq {
+			f.CommentSeq = t.ID
+		}
+		for _, c := range t.Comments {
+			if c.ID > f.CommentSeq {
+				f.CommentSeq = c.ID
+			}
+		}
+	}
+	f.CommentSeq++
+	return f.CommentSeq
+}
+
+// removeComment removes the comment from the thread, and the thread if it
+// has no comments left.
+func (f *Fragment) removeComment(tid, cid uint64) error {
+	for i, t := range f.Threads {
+		if t.ID != tid {
+			continue
+		}
+		for j, c := range t.Comments {
+			if c.ID != cid {
+				continue
+			}
+			if len(t.Comments) == 1 {
+				f.Threads = append(f.Threads[:i], f.Threads[i+1:]...)
+			} else {
+				f.Threads[i].Comments = append(t.Comments[:j], t.Comments[j+1:]...)
+			}
+			return nil
+		}
+	}
+	return ErrNotFound
+}
+
+// upgradeComment turns the single comment the fragments used to have into
+// the first comment of a thread of its own. The time of the comment wasn't
+// kept; the time the fragment was last updated stands for it.
+func (f *Fragment) upgradeComment() {
+	if f.Comment == "" {
+		return
+	}
+	created := f.Updated
+	if created.IsZero() {
+		created = f.Created
+	}
+	t := Thread{ID: f.nextCommentID()}
+	t.Comments = []Comment{{ID: f.nextCommentID(), Created: created, Text: f.Comment}}
+	f.Threads = append([]Thread{t}, f.Threads...)
+	f.Comment = ""
+}
+
+func commentFromRequest(r *http.Request) Comment {
+	return Comment{
+		Author: strings.TrimSpace(r.FormValue("author")),
+		Text:   strings.TrimSpace(r.FormValue("text")),
+	}
+}
+
+// updateFragment applies fn to the fragment of a book which is not in the
+// trash and saves the result.
+func updateFragment(tx *bolt.Tx, bid, fid uint64, fn func(f *Fragment) error) error {
+	if _, err := indexedBook(tx, bid); err != nil {
+		return err
+	}
+	if !hasFragment(tx, bid, fid) {
+		return ErrNotFound
+	}
+
+	fb := tx.Bucket([]byte("fragments")).Bucket(encode(bid))
+	var f Fragment
+	if found, err := unmarshal(fb, fid, &f); err != nil {
+		return err
+	} else if !found {
+		return ErrNotFound
+	}
+
+	if err := fn(&f); err != nil {
+		return err
+	}
+	if err := marshal(fb, fid, f); err != nil {
+		return err
+	}
+
+	return reindexFragment(tx, bid, fid)
+}
+
+func (db *DB) Threads(bid, fid uint64) ([]Thread, error) {
+	var threads []Thread
+	err := db.View(func(tx *bolt.Tx) error {
+		if _, err := indexedBook(tx, bid); err != nil {
+			return err
+		}
+		fb := tx.Bucket([]byte("fragments")).Bucket(encode(bid))
+		var f Fragment
+		if found, err := unmarshal(fb, fid, &f); err != nil {
+			return err
+		} else if !found {
+			return ErrNotFound
+		}
+		threads = f.Threads
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if threads == nil {
+		threads = []Thread{}
+	}
+	return threads, nil
+}
+
+func (db *DB) AddThread(bid, fid uint64, c Comment) (Thread, error) {
+	var t Thread
+	err := db.Update(func(tx *bolt.Tx) error {
+		return updateFragment(tx, bid, fid, func(f *Fragment) error {
+			t.ID = f.nextCommentID()
+			c.ID = f.nextCommentID()
+			c.Created = time.Now()
+			t.Comments = []Comment{c}
+			f.Threads = append(f.Threads, t)
+			return nil
+		})
+	})
+	if err != nil {
+		return Thread{}, err
+	}
+	return t, nil
+}
+
+func (db *DB) ReplyToThread(bid, fid, tid uint64, c Comment) (Comment, error) {
+	err := db.Update(func(tx *bolt.Tx) error {
+		return updateFragment(tx, bid, fid, func(f *Fragment) error {
+			t := f.thread(tid)
+			if t == nil {
+				return ErrNotFound
+			}
+			c.ID = f.nextCommentID()
+			c.Created = time.Now()
+			t.Comments = append(t.Comments, c)
+			return nil
+		})
+	})
+	if err != nil {
+		return Comment{}, err
+	}
+	return c, nil
+}
+
+func (db *DB) ResolveThread(bid, fid, tid uint64, resolved bool) error {
+	return db.Update(func(tx *bolt.Tx) error {
+		return updateFragment(tx, bid, fid, func(f *Fragment) error {
+			t := f.thread(tid)
+			if t == nil {
+				return ErrNotFound
+			}
+			t.Resolved = resolved
+			return nil
+		})
+	})
+}
+
+// RemoveComment removes a comment from the thread. Removing the only
+// comment of the thread removes the thread.
+func (db *DB) RemoveComment(bid, fid, tid, cid uint64) error {
+	return db.Update(func(tx *bolt.Tx) error {
+		return updateFragment(tx, bid, fid, func(f *Fragment) error {
+			return f.removeComment(tid, cid)
+		})
+	})
+}
+
+// upgradeComments moves the single comments of the fragments of all the
+// books into threads.
+func upgradeComments(tx *bolt.Tx) error {
+	var bids []uint64
+	if err := tx.Bucket([]byte("fragments")).ForEach(func(k, _ []byte) error {
+		bids = append(bids, decode(k))
+		return nil
+	}); err != nil {
+		return err
+	}
+	for _, bid := range bids {
+		fb := tx.Bucket([]byte("fragments")).Bucket(encode(bid))
+		if fb == nil {
+			continue
+		}
+		var fragments []Fragment
+		if err := fb.ForEach(func(_, v []byte) error {
+			var f Fragment
+			if err := json.Unmarshal(v, &f); err != nil {
+				return err
+			}
+			if f.Comment != "" {
+				fragments = append(fragments, f)
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+		for _, f := range fragments {
+			f.upgradeComment()
+			if err := marshal(fb, f.ID, f); err != nil {
+				return err
+			}
+			if err := reindexFragment(tx, bid, f.ID); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (a *App) Comments(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	bid, err := u64(vars["book_id"])
+	if err != nil {
+		http.Error(w, "Invalid book ID", http.StatusBadRequest)
+		return
+	}
+	fid, err := u64(vars["fragment_id"])
+	if err != nil {
+		http.Error(w, "Invalid fragment ID", http.StatusBadRequest)
+		return
+	}
+
+	var v interface{}
+	switch r.Method {
+	case "GET":
+		v, err = a.db.Threads(bid, fid)
+	case "POST":
+		c := commentFromRequest(r)
+		if c.Text == "" {
+			http.Error(w, "Comment must not be empty!", http.StatusBadRequest)
+			return
+		}
+		v, err = a.db.AddThread(bid, fid, c)
+	}
+	if err != nil {
+		if err == ErrNotFound {
+			http.Error(w, "Fragment not found", 404)
+			return
+		}
+		internalError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
+
+func (a *App) Thread(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	bid, err := u64(vars["book_id"])
+	if err != nil {
+		http.Error(w, "Invalid book ID", http.StatusBadRequest)
+		return
+	}
+	fid, err := u64(vars["fragment_id"])
+	if err != nil {
+		http.Error(w, "Invalid fragment ID", http.StatusBadRequest)
+		return
+	}
+	tid, err := u64(vars["thread_id"])
+	if err != nil {
+		http.Error(w, "Invalid thread ID", http.StatusBadRequest)
+		return
+	}
+
+	c := commentFromRequest(r)
+	if c.Text == "" {
+		http.Error(w, "Comment must not be empty!", http.StatusBadRequest)
+		return
+	}
+	c, err = a.db.ReplyToThread(bid, fid, tid, c)
+	if err != nil {
+		if err == ErrNotFound {
+			http.Error(w, "Thread not found", 404)
+			return
+		}
+		internalError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(c)
+}
+
+func (a *App) ResolveThread(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	bid, err := u64(vars["book_id"])
+	if err != nil {
+		http.Error(w, "Invalid book ID", http.StatusBadRequest)
+		return
+	}
+	fid, err := u64(vars["fragment_id"])
+	if err != nil {
+		http.Error(w, "Invalid fragment ID", http.StatusBadRequest)
+		return
+	}
+	tid, err := u64(vars["thread_id"])
+	if err != nil {
+		http.Error(w, "Invalid thread ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := a.db.ResolveThread(bid, fid, tid, r.Method == "POST"); err != nil {
+		if err == ErrNotFound {
+			http.Error(w, "Thread not found", 404)
+			return
+		}
+		internalError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (a *App) RemoveComment(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	bid, err := u64(vars["book_id"])
+	if err != nil {
+		http.Error(w, "Invalid book ID", http.StatusBadRequest)
+		return
+	}
+	fid, err := u64(vars["fragment_id"])
+	if err != nil {
+		http.Error(w, "Invalid fragment ID", http.StatusBadRequest)
+		return
+	}
+	tid, err := u64(vars["thread_id"])
+	if err != nil {
+		http.Error(w, "Invalid thread ID", http.StatusBadRequest)
+		return
+	}
+	cid, err := u64(vars["comment_id"])
+	if err != nil {
+		http.Error(w, "Invalid comment ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := a.db.RemoveComment(bid, fid, tid, cid); err != nil {
+		if err == ErrNotFound {
+			http.Error(w, "Comment not found", 404)
+			return
+		}
+		internalError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
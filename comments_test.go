@@ -0,0 +1,41 @@
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+// TestUpgradeCommentTime checks that a migrated comment is dated by the
+// fragment.
+func TestUpgradeCommentTime(t *testing.T) {
+	created := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	updated := created.Add(time.Hour)
+	for _, tc := range []struct {
+		f    Fragment
+		want time.Time
+	}{
+		{Fragment{Created: created, Updated: updated, Comment: "Check"}, updated},
+		{Fragment{Created: created, Comment: "Check"}, created},
+	} {
+		tc.f.upgradeComment()
+		if len(tc.f.Threads) != 1 || len(tc.f.Threads[0].Comments) != 1 {
+			t.Fatalf("got threads %+v", tc.f.Threads)
+		}
+		if got := tc.f.Threads[0].Comments[0].Created; !got.Equal(tc.want) {
+			t.Errorf("got %v, want %v", got, tc.want)
+		}
+	}
+}
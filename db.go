@@ -18,23 +18,22 @@ import (
 	"encoding/binary"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
 	"os"
 	"regexp"
 	"sort"
-	"strconv"
 	"strings"
 	"time"
-	"unicode/utf8"
 
 	"github.com/boltdb/bolt"
-	"github.com/opennota/substring"
 )
 
 var (
 	ErrNotFound      = errors.New("not found")
 	ErrInvalidOffset = errors.New("invalid offset")
 
-	rWord   = regexp.MustCompile(`\w+`)
+	rWord   = regexp.MustCompile(`[\pL\pN_]+`)
 	rLetter = regexp.MustCompile(`\pL`)
 )
 
@@ -43,16 +42,45 @@ type DB struct {
 }
 
 type Book struct {
-	ID                  uint64    `json:"id"`
-	Title               string    `json:"title"`
-	Created             time.Time `json:"created"`
+	bookInfo
+	bookStats
+	FragmentsIDs []uint64 `json:"fragments_ids"`
+
+	Fragments []Fragment `json:"-"`
+	// MoreFragments is set by BookWithTranslations when FragmentsIDs
+	// stops at the end of the page and more fragments match the filters.
+	MoreFragments bool `json:"-"`
+}
+
+// bookInfo is the part of a book which is kept in the index (or in the
+// trash).
+type bookInfo struct {
+	ID      uint64    `json:"id"`
+	Title   string    `json:"title"`
+	Created time.Time `json:"created"`
+	Deleted time.Time `json:"deleted"`
+	BookMeta
+
+	Collection string     `json:"collection,omitempty"`
+	Archived   bool       `json:"archived,omitempty"`
+	Goal       *DailyGoal `json:"goal,omitempty"`
+}
+
+// bookStats is kept in a record of its own, so that the frequent updates
+// don't rewrite the rest of the book.
+type bookStats struct {
 	FragmentsTotal      int       `json:"fragments_total"`
 	FragmentsTranslated int       `json:"fragments_translated"`
-	FragmentsIDs        []uint64  `json:"fragments_ids"`
 	LastActivity        time.Time `json:"last_activity"`
 	LastVisitedPage     int       `json:"last_visited_page"`
-
-	Fragments []Fragment `json:"-"`
+	// Days counts the fragments translated for the first time by day, for
+	// the daily goals.
+	Days map[string]dayCount `json:"days,omitempty"`
+
+	// FragmentsInReview and FragmentsApproved count the translated
+	// fragments with these statuses. They are not stored.
+	FragmentsInReview int `json:"-"`
+	FragmentsApproved int `json:"-"`
 }
 
 type Fragment struct {
@@ -60,12 +88,33 @@ type Fragment struct {
 	Created     time.Time `json:"created"`
 	Updated     time.Time `json:"updated"`
 	Text        string    `json:"text"`
-	Comment     string    `json:"comment"`
+	Threads     []Thread  `json:"threads,omitempty"`
 	Starred     bool      `json:"starred"`
+	Status      string    `json:"status,omitempty"`
 	VersionsIDs []uint64  `json:"versions_ids"`
 
+	// CommentSeq is the last ID given to a thread or a comment of the
+	// fragment. It never goes down, so that the IDs are not reused.
+	CommentSeq uint64 `json:"comment_seq,omitempty"`
+
+	// Comment is the single comment the fragments had before the comment
+	// threads. It is only read, from old databases and exports, and moved
+	// into a thread.
+	Comment string `json:"comment,omitempty"`
+
+	// PreferredVersion is the ID of the version chosen as the translation,
+	// 0 if none is.
+	PreferredVersion uint64 `json:"preferred_version,omitempty"`
+
+	// SourceUpdated is the time the text of the original was last edited.
+	SourceUpdated time.Time `json:"source_updated"`
+
 	Versions []TranslationVersion `json:"-"`
 	SeqNum   int                  `json:"-"`
+	// Terms are the glossary terms found in the original.
+	Terms []Term `json:"-"`
+	// Issues are the problems found by the QA checks.
+	Issues []QAIssue `json:"-"`
 }
 
 type TranslationVersion struct {
@@ -73,6 +122,9 @@ type TranslationVersion struct {
 	Created time.Time `json:"created"`
 	Updated time.Time `json:"updated"`
 	Text    string    `json:"text"`
+
+	// Stale is set if the original was edited after the version.
+	Stale bool `json:"-"`
 }
 
 type Scratchpad struct {
@@ -107,27 +159,53 @@ func unmarshal(b *bolt.Bucket, key uint64, val interface{}) (bool, error) {
 	return true, nil
 }
 
+// getBook reads the book with its stats from the index or the trash,
+// depending on name. The fragment IDs are not read.
+func getBook(tx *bolt.Tx, name string, bid uint64) (Book, bool, error) {
+	var book Book
+	if found, err := unmarshal(tx.Bucket([]byte(name)), bid, &book.bookInfo); err != nil || !found {
+		return Book{}, found, err
+	}
+	if _, err := unmarshal(tx.Bucket([]byte("stats")), bid, &book.bookStats); err != nil {
+		return Book{}, false, err
+	}
+	countStatuses(tx, bid, &book.bookStats)
+	return book, true, nil
+}
+
+// indexedBook is getBook from the index which returns ErrNotFound if the
+// book isn't there.
+func indexedBook(tx *bolt.Tx, bid uint64) (Book, error) {
+	book, found, err := getBook(tx, "index", bid)
+	if err != nil {
+		return Book{}, err
+	} else if !found {
+		return Book{}, ErrNotFound
+	}
+	return book, nil
+}
+
+func putStats(tx *bolt.Tx, bid uint64, st bookStats) error {
+	return marshal(tx.Bucket([]byte("stats")), bid, st)
+}
+
+// updateStats applies fn to the stats of the book.
+func updateStats(tx *bolt.Tx, bid uint64, fn func(st *bookStats)) (bookStats, error) {
+	var st bookStats
+	if _, err := unmarshal(tx.Bucket([]byte("stats")), bid, &st); err != nil {
+		return bookStats{}, err
+	}
+	fn(&st)
+	return st, putStats(tx, bid, st)
+}
+
 func OpenDatabase(path string, mode os.FileMode, options *bolt.Options) (DB, error) {
 	db, err := bolt.Open(path, mode, options)
 	if err != nil {
 		return DB{}, err
 	}
-	if err := db.Update(func(tx *bolt.Tx) error {
-		_, err := tx.CreateBucketIfNotExists([]byte("index"))
-		if err != nil {
-			return err
-		}
-		_, err = tx.CreateBucketIfNotExists([]byte("fragments"))
-		if err != nil {
-			return err
-		}
-		_, err = tx.CreateBucketIfNotExists([]byte("versions"))
-		if err != nil {
-			return err
-		}
-		_, err = tx.CreateBucketIfNotExists([]byte("scratchpad"))
-		return err
-	}); err != nil {
+	if err := migrate(db, path, mode); err != nil {
+		db.Close()
 		return DB{}, err
 	}
 	return DB{db}, nil
@@ -136,17 +214,9 @@ func OpenDatabase(path string, mode os.FileMode, options *bolt.Options) (DB, err
 func (db *DB) Books() ([]Book, error) {
 	var books []Book
 	err := db.View(func(tx *bolt.Tx) error {
-		books = books[:0]
-		b := tx.Bucket([]byte("index"))
-		c := b.Cursor()
-		for k, v := c.First(); k != nil; k, v = c.Next() {
-			var book Book
-			if err := json.Unmarshal(v, &book); err != nil {
-				return err
-			}
-			books = append(books, book)
-		}
-		return nil
+		var err error
+		books, err = listBooks(tx, "index")
+		return err
 	})
 	if err != nil {
 		return nil, err
@@ -154,18 +224,45 @@ func (db *DB) Books() ([]Book, error) {
 	return books, nil
 }
 
+// listBooks returns the books, with their stats, from the index or the
+// trash.
+func listBooks(tx *bolt.Tx, name string) ([]Book, error) {
+	var books []Book
+	sb := tx.Bucket([]byte("stats"))
+	c := tx.Bucket([]byte(name)).Cursor()
+	for k, v := c.First(); k != nil; k, v = c.Next() {
+		var book Book
+		if err := json.Unmarshal(v, &book.bookInfo); err != nil {
+			return nil, err
+		}
+		if data := sb.Get(k); data != nil {
+			if err := json.Unmarshal(data, &book.bookStats); err != nil {
+				return nil, err
+			}
+		}
+		countStatuses(tx, book.ID, &book.bookStats)
+		books = append(books, book)
+	}
+	return books, nil
+}
+
 func (db *DB) BooksByActivity() ([]Book, error) {
 	books, err := db.Books()
 	if err != nil {
 		return nil, err
 	}
+	sortBooksByActivity(books)
+	return books, nil
+}
+
+// sortBooksByActivity puts the most recently active books first.
+func sortBooksByActivity(books []Book) {
 	sort.Slice(books, func(i, j int) bool {
 		if a, b := books[i].LastActivity, books[j].LastActivity; !a.Equal(b) {
 			return b.Before(a)
 		}
 		return books[i].ID > books[j].ID
 	})
-	return books, nil
 }
 
 func min(a, b int) int {
@@ -175,196 +272,173 @@ func min(a, b int) int {
 	return b
 }
 
-type filterKind int
-
-const (
-	fNone filterKind = iota
-	fUntranslated
-	fCommented
-	fStarred
-	fWithTwoOrMoreVersions
-	fOriginalContains
-	fTranslationContains
-	fOriginalLength
-)
-
-func wordCount(s string) int {
-	return len(rWord.FindAllStringIndex(s, -1))
-}
-
-func (db *DB) BookWithTranslations(bid uint64, from, size int, filter filterKind, filterArg ...string) (Book, error) {
+func (db *DB) BookWithTranslations(bid uint64, from, size int, filters ...bookFilter) (Book, error) {
+	m, err := newFragmentMatcher(filters)
+	if err != nil {
+		return Book{}, err
+	}
 	var book Book
 	if err := db.View(func(tx *bolt.Tx) error {
-		b := tx.Bucket([]byte("index"))
-		if found, err := unmarshal(b, bid, &book); err != nil {
+		var err error
+		book, err = indexedBook(tx, bid)
+		if err != nil {
 			return err
-		} else if !found {
-			return ErrNotFound
 		}
-
-		if from >= len(book.FragmentsIDs) && from > 0 {
+		if from >= book.FragmentsTotal && from > 0 {
 			return ErrInvalidOffset
 		}
 
-		if size == -1 {
-			size = len(book.FragmentsIDs)
-		}
-
-		var m *substring.Matcher
-		if filter == fOriginalContains || filter == fTranslationContains {
-			m = substring.NewMatcher(filterArg[0])
-		}
-
 		fb := tx.Bucket([]byte("fragments")).Bucket(encode(bid))
 		vb := tx.Bucket([]byte("versions")).Bucket(encode(bid))
-		origFragmentIDs := book.FragmentsIDs
 
-		if filter != fNone {
-			filtered := make([]uint64, 0, len(book.FragmentsIDs))
-
-			switch filter {
-			case fUntranslated:
-				needle := []byte(`"versions_ids":[]`)
-				for _, fid := range book.FragmentsIDs {
-					data := fb.Get(encode(fid))
-					if !bytes.Contains(data, needle) {
-						continue
-					}
-					filtered = append(filtered, fid)
-				}
-			case fCommented:
-				needle := []byte(`"comment":""`)
-				for _, fid := range book.FragmentsIDs {
-					data := fb.Get(encode(fid))
-					if bytes.Contains(data, needle) {
-						continue
-					}
-					filtered = append(filtered, fid)
-				}
-			case fStarred:
-				needle := []byte(`"starred":true`)
-				for _, fid := range book.FragmentsIDs {
-					data := fb.Get(encode(fid))
-					if !bytes.Contains(data, needle) {
-						continue
-					}
-					filtered = append(filtered, fid)
+		readVersions := func(f *Fragment) ([]TranslationVersion, error) {
+			var versions []TranslationVersion
+			for _, vid := range f.VersionsIDs {
+				var v TranslationVersion
+				if found, err := unmarshal(vb, vid, &v); err != nil {
+					return nil, err
+				} else if found {
+					versions = append(versions, v)
 				}
-			case fWithTwoOrMoreVersions:
-				for _, fid := range book.FragmentsIDs {
-					data := fb.Get(encode(fid))
-					if data == nil {
-						continue
-					}
-					var tmp struct {
-						IDs []uint64 `json:"versions_ids"`
-					}
-					if err := json.Unmarshal(data, &tmp); err != nil {
-						return err
+			}
+			return versions, nil
+		}
+
+		var pageKeys []uint64
+		if len(filters) == 0 {
+			book.FragmentsIDs = fragmentIDs(tx, bid)
+			if size == -1 {
+				size = len(book.FragmentsIDs)
+			}
+		} else {
+			// The fragments in all the sets are walked in order until
+			// the page is full; the rest of the book is left alone.
+			book.FragmentsIDs = []uint64{}
+			pb := tx.Bucket([]byte("filter_index")).Bucket(encode(bid))
+			ob := tx.Bucket([]byte("order")).Bucket(encode(bid))
+			if ob == nil {
+				return nil
+			}
+			var iters []keyIter
+			var excluded []keyIter
+			for _, f := range filters {
+				switch f.Kind {
+				case fUntranslated:
+					iters = append(iters, setIter(pb, setUntranslated))
+				case fCommented:
+					iters = append(iters, setIter(pb, setCommented))
+				case fUnresolved:
+					iters = append(iters, setIter(pb, setUnresolved))
+				case fStarred:
+					iters = append(iters, setIter(pb, setStarred))
+				case fWithTwoOrMoreVersions:
+					iters = append(iters, setIter(pb, setMultiple))
+				case fStale:
+					iters = append(iters, setIter(pb, setStale))
+				case fStatus:
+					iters = append(iters, setIter(pb, statusSet(f.Args[0])))
+				case fNoPreferredVersion:
+					iters = append(iters, setIter(pb, setMultiple))
+					excluded = append(excluded, setIter(pb, setPreferred))
+				case fOriginalContains:
+					if tt, ok := trigramIters(pb, "orig_trigrams", f.Args[0]); ok {
+						iters = append(iters, tt...)
 					}
-					if len(tmp.IDs) < 2 {
-						continue
+				case fTranslationContains:
+					if tt, ok := trigramIters(pb, "trans_trigrams", f.Args[0]); ok {
+						iters = append(iters, tt...)
 					}
-					filtered = append(filtered, fid)
 				}
-			case fOriginalContains:
-				for _, fid := range book.FragmentsIDs {
-					data := fb.Get(encode(fid))
-					if data == nil {
-						continue
-					}
-					var tmp struct{ Text string }
-					if err := json.Unmarshal(data, &tmp); err != nil {
-						return err
-					}
-					if !m.Match(tmp.Text) {
-						continue
+			}
+			if len(iters) == 0 {
+				iters = append(iters, bucketIter{ob.Cursor()})
+			}
+
+		next:
+			for k := intersect(iters, orderKey(0)); k != nil; k = intersect(iters, nextKey(k)) {
+				for _, it := range excluded {
+					if bytes.Equal(it.seek(k), k) {
+						continue next
 					}
-					filtered = append(filtered, fid)
 				}
-			case fTranslationContains:
-				for _, fid := range book.FragmentsIDs {
+				fid := decode(ob.Get(k))
+				if !m.empty() {
 					var f Fragment
-					if _, err := unmarshal(fb, fid, &f); err != nil {
+					if found, err := unmarshal(fb, fid, &f); err != nil {
 						return err
+					} else if !found {
+						continue
 					}
-					for _, vid := range f.VersionsIDs {
-						var v TranslationVersion
-						if found, err := unmarshal(vb, vid, &v); err != nil {
+					var versions []TranslationVersion
+					if m.needsVersions() {
+						if versions, err = readVersions(&f); err != nil {
 							return err
-						} else if !found {
-							continue
-						}
-						if m.Match(v.Text) {
-							filtered = append(filtered, fid)
-							break
 						}
 					}
-				}
-			case fOriginalLength:
-				compare := func(a, b int) bool { return a < b }
-				if filterArg[0] == "more" {
-					compare = func(a, b int) bool { return a > b }
-				}
-				n, _ := strconv.Atoi(filterArg[1])
-				count := utf8.RuneCountInString
-				if filterArg[2] == "words" {
-					count = wordCount
-				}
-				for _, fid := range book.FragmentsIDs {
-					data := fb.Get(encode(fid))
-					if data == nil {
+					if !m.match(&f, versions) {
 						continue
 					}
-					var tmp struct{ Text string }
-					if err := json.Unmarshal(data, &tmp); err != nil {
-						return err
-					}
-					if !compare(count(tmp.Text), n) {
-						continue
-					}
-					filtered = append(filtered, fid)
 				}
+				if size != -1 && len(book.FragmentsIDs) == from+size {
+					book.MoreFragments = true
+					break
+				}
+				if len(book.FragmentsIDs) >= from {
+					pageKeys = append(pageKeys, binary.BigEndian.Uint64(k))
+				}
+				book.FragmentsIDs = append(book.FragmentsIDs, fid)
+			}
+			if size == -1 {
+				size = len(book.FragmentsIDs)
 			}
-
-			book.FragmentsIDs = filtered
 		}
 
 		if from >= len(book.FragmentsIDs) {
 			return nil
 		}
 
+		var nums []int
+		if pageKeys != nil {
+			nums = seqNums(tx, bid, pageKeys)
+		}
 		to := min(len(book.FragmentsIDs), from+size)
-		for _, fid := range book.FragmentsIDs[from:to] {
+		for i, fid := range book.FragmentsIDs[from:to] {
 			var f Fragment
 			if _, err := unmarshal(fb, fid, &f); err != nil {
 				return err
 			}
 
-			if filter != fUntranslated {
-				for _, vid := range f.VersionsIDs {
-					var v TranslationVersion
-					if found, err := unmarshal(vb, vid, &v); err != nil {
-						return err
-					} else if !found {
-						continue
-					}
-					if filter == fTranslationContains && !m.Match(v.Text) {
-						continue
-					}
-					f.Versions = append(f.Versions, v)
+			versions, err := readVersions(&f)
+			if err != nil {
+				return err
+			}
+			for _, v := range versions {
+				if !m.versionMatches(v.Text) {
+					continue
 				}
+				v.Stale = v.Updated.Before(f.SourceUpdated)
+				f.Versions = append(f.Versions, v)
 			}
 
-			if filter == fTranslationContains && len(f.Versions) == 0 {
-				continue
+			if m.withQA {
+				f.Issues = qaIssues(f.Text, f.Versions, m.qa)
 			}
 
-			f.SeqNum = 1 + idx(origFragmentIDs, f.ID)
+			if nums != nil {
+				f.SeqNum = nums[i]
+			} else {
+				f.SeqNum = from + i + 1
+			}
 
 			book.Fragments = append(book.Fragments, f)
 		}
 
+		terms, err := glossaryTerms(tx, bid)
+		if err != nil {
+			return err
+		}
+		attachTerms(book.Fragments, terms)
+
 		return nil
 	}); err != nil {
 		if err == ErrInvalidOffset {
@@ -378,12 +452,12 @@ func (db *DB) BookWithTranslations(bid uint64, from, size int, filter filterKind
 func (db *DB) BookByID(bid uint64) (Book, error) {
 	var book Book
 	err := db.View(func(tx *bolt.Tx) error {
-		b := tx.Bucket([]byte("index"))
-		if found, err := unmarshal(b, bid, &book); err != nil {
+		var err error
+		book, err = indexedBook(tx, bid)
+		if err != nil {
 			return err
-		} else if !found {
-			return ErrNotFound
 		}
+		book.FragmentsIDs = fragmentIDs(tx, bid)
 		return nil
 	})
 	if err != nil {
@@ -409,7 +483,7 @@ func (db *DB) AddBook(title string, fragments []string, autotranslate bool) (uin
 		}
 
 		ids := make([]uint64, len(fragments))
-		fragmentsTranslated := 0
+		st := bookStats{FragmentsTotal: len(fragments)}
 		for i := range fragments {
 			versionsIDs := []uint64{}
 			if autotranslate && !rLetter.MatchString(fragments[i]) {
@@ -422,7 +496,8 @@ func (db *DB) AddBook(title string, fragments []string, autotranslate bool) (uin
 				}); err != nil {
 					return err
 				}
-				fragmentsTranslated++
+				st.FragmentsTranslated++
+				st.countTranslated(now, wordCount(fragments[i]), 1)
 				versionsIDs = append(versionsIDs, vid)
 			}
 
@@ -439,13 +514,20 @@ func (db *DB) AddBook(title string, fragments []string, autotranslate bool) (uin
 			}
 		}
 
-		return marshal(b, bid, Book{
-			ID:                  bid,
-			Title:               title,
-			Created:             now,
-			FragmentsTotal:      len(fragments),
-			FragmentsTranslated: fragmentsTranslated,
-			FragmentsIDs:        ids,
+		if err := appendFragments(tx, bid, ids...); err != nil {
+			return err
+		}
+		if err := reindexFragment(tx, bid, ids...); err != nil {
+			return err
+		}
+		if err := putStats(tx, bid, st); err != nil {
+			return err
+		}
+
+		return marshal(b, bid, bookInfo{
+			ID:      bid,
+			Title:   title,
+			Created: now,
 		})
 	})
 	if err != nil {
@@ -470,10 +552,11 @@ func (db *DB) AddTranslatedBook(title string, fragments [][]string) (uint64, err
 			return err
 		}
 
-		fragmentsTranslated := 0
+		st := bookStats{FragmentsTotal: len(fragments)}
 		ids := make([]uint64, len(fragments))
 		for i := range fragments {
 			var versionIDs []uint64
+			text := strings.TrimSpace(fragments[i][0])
 			translationText := strings.TrimSpace(fragments[i][1])
 			if translationText != "" {
 				vid, _ := vb.NextSequence()
@@ -487,7 +570,8 @@ func (db *DB) AddTranslatedBook(title string, fragments [][]string) (uint64, err
 					return err
 				}
 				versionIDs = []uint64{vid}
-				fragmentsTranslated++
+				st.FragmentsTranslated++
+				st.countTranslated(now, wordCount(text), 1)
 			}
 			fid, _ := fb.NextSequence()
 			ids[i] = fid
@@ -495,20 +579,27 @@ func (db *DB) AddTranslatedBook(title string, fragments [][]string) (uint64, err
 				ID:          fid,
 				Created:     now,
 				Updated:     now,
-				Text:        strings.TrimSpace(fragments[i][0]),
+				Text:        text,
 				VersionsIDs: versionIDs,
 			}); err != nil {
 				return err
 			}
 		}
 
-		return marshal(b, bid, Book{
-			ID:                  bid,
-			Title:               title,
-			Created:             now,
-			FragmentsTotal:      len(fragments),
-			FragmentsTranslated: fragmentsTranslated,
-			FragmentsIDs:        ids,
+		if err := appendFragments(tx, bid, ids...); err != nil {
+			return err
+		}
+		if err := reindexFragment(tx, bid, ids...); err != nil {
+			return err
+		}
+		if err := putStats(tx, bid, st); err != nil {
+			return err
+		}
+
+		return marshal(b, bid, bookInfo{
+			ID:      bid,
+			Title:   title,
+			Created: now,
 		})
 	})
 	if err != nil {
@@ -518,28 +609,159 @@ func (db *DB) AddTranslatedBook(title string, fragments [][]string) (uint64, err
 }
 
 func (db *DB) UpdateBookTitle(bid uint64, title string) error {
+	return db.updateBookInfo(bid, func(book *bookInfo) { book.Title = title })
+}
+
+// RemoveBook moves the book to the trash.
+func (db *DB) RemoveBook(bid uint64) error {
+	now := time.Now()
 	return db.Update(func(tx *bolt.Tx) error {
 		b := tx.Bucket([]byte("index"))
-		var book Book
+		var book bookInfo
 		if found, err := unmarshal(b, bid, &book); err != nil {
 			return err
 		} else if !found {
 			return ErrNotFound
 		}
-		book.Title = title
-		return marshal(b, bid, book)
+		book.Deleted = now
+		if err := marshal(tx.Bucket([]byte("trash")), bid, book); err != nil {
+			return err
+		}
+		return b.Delete(encode(bid))
 	})
 }
 
-func (db *DB) RemoveBook(bid uint64) error {
-	return db.Update(func(tx *bolt.Tx) error {
+// bookBuckets are the buckets which hold a nested bucket for each book.
+var bookBuckets = []string{"fragments", "versions", "history", "source_history", "filter_index", "order", "positions", "order_counts", "glossary", "filters"}
+
+func removeBookData(tx *bolt.Tx, bid uint64) error {
+	key := encode(bid)
+	for _, name := range bookBuckets {
+		err := tx.Bucket([]byte(name)).DeleteBucket(key)
+		if err != nil && err != bolt.ErrBucketNotFound {
+			return err
+		}
+	}
+	if err := tx.Bucket([]byte("stats")).Delete(key); err != nil {
+		return err
+	}
+	return tx.Bucket([]byte("scratchpad")).Delete(key)
+}
+
+// bucketSize returns the number of values in the bucket and its nested
+// buckets and the total size of their keys and values.
+func bucketSize(b *bolt.Bucket) (int, int, error) {
+	var n, size int
+	err := b.ForEach(func(k, v []byte) error {
+		size += len(k)
+		if v != nil {
+			n++
+			size += len(v)
+			return nil
+		}
+		nn, ss, err := bucketSize(b.Bucket(k))
+		n += nn
+		size += ss
+		return err
+	})
+	return n, size, err
+}
+
+type GCStats struct {
+	Books       int
+	Fragments   int
+	Versions    int
+	Revisions   int
+	Scratchpads int
+	Bytes       int
+}
+
+// CollectGarbage removes fragments, versions and scratchpads left behind
+// by books which are neither in the index nor in the trash.
+func (db *DB) CollectGarbage() (GCStats, error) {
+	var stats GCStats
+	err := db.Update(func(tx *bolt.Tx) error {
+		stats = GCStats{}
 		b := tx.Bucket([]byte("index"))
-		key := encode(bid)
-		if b.Get(key) == nil {
-			return ErrNotFound
+		tb := tx.Bucket([]byte("trash"))
+		exists := func(k []byte) bool {
+			return b.Get(k) != nil || tb.Get(k) != nil
+		}
+		orphans := make(map[string]bool)
+
+		for _, name := range bookBuckets {
+			parent := tx.Bucket([]byte(name))
+			var keys [][]byte
+			c := parent.Cursor()
+			for k, v := c.First(); k != nil; k, v = c.Next() {
+				if v != nil || exists(k) {
+					continue
+				}
+				keys = append(keys, k)
+			}
+			for _, k := range keys {
+				n, size, err := bucketSize(parent.Bucket(k))
+				if err != nil {
+					return err
+				}
+				stats.Bytes += size
+				switch name {
+				case "fragments":
+					stats.Fragments += n
+				case "versions":
+					stats.Versions += n
+				case "history", "source_history":
+					stats.Revisions += n
+				}
+				orphans[string(k)] = true
+				if err := parent.DeleteBucket(k); err != nil {
+					return err
+				}
+			}
+		}
+
+		spb := tx.Bucket([]byte("scratchpad"))
+		var keys [][]byte
+		c := spb.Cursor()
+		for k, v := c.First(); k != nil; k, v = c.Next() {
+			if exists(k) {
+				continue
+			}
+			keys = append(keys, k)
+			stats.Bytes += len(k) + len(v)
+		}
+		for _, k := range keys {
+			orphans[string(k)] = true
+			if err := spb.Delete(k); err != nil {
+				return err
+			}
+		}
+		stats.Scratchpads = len(keys)
+
+		sb := tx.Bucket([]byte("stats"))
+		keys = keys[:0]
+		c = sb.Cursor()
+		for k, v := c.First(); k != nil; k, v = c.Next() {
+			if exists(k) {
+				continue
+			}
+			keys = append(keys, k)
+			stats.Bytes += len(k) + len(v)
+		}
+		for _, k := range keys {
+			orphans[string(k)] = true
+			if err := sb.Delete(k); err != nil {
+				return err
+			}
 		}
-		return b.Delete(key)
+		stats.Books = len(orphans)
+
+		return nil
 	})
+	if err != nil {
+		return GCStats{}, err
+	}
+	return stats, nil
 }
 
 func idx(a []uint64, v uint64) int {
@@ -558,12 +780,8 @@ func (db *DB) AddFragment(bid, fidAfter uint64, text string) (Fragment, error) {
 	now := time.Now()
 	var f Fragment
 	if err := db.Update(func(tx *bolt.Tx) error {
-		b := tx.Bucket([]byte("index"))
-		var book Book
-		if found, err := unmarshal(b, bid, &book); err != nil {
+		if _, err := indexedBook(tx, bid); err != nil {
 			return err
-		} else if !found {
-			return ErrNotFound
 		}
 
 		fb := tx.Bucket([]byte("fragments")).Bucket(encode(bid))
@@ -575,30 +793,23 @@ func (db *DB) AddFragment(bid, fidAfter uint64, text string) (Fragment, error) {
 			Text:        text,
 			VersionsIDs: []uint64{},
 		}
-		if fidAfter == 0 {
-			book.FragmentsIDs = append([]uint64{fid}, book.FragmentsIDs...)
-			f.SeqNum = 1
-		} else {
-			findex := idx(book.FragmentsIDs, fidAfter)
-			if findex == -1 {
-				return ErrNotFound
-			}
-			fids := make([]uint64, 0, len(book.FragmentsIDs)+1)
-			fids = append(fids, book.FragmentsIDs[:findex+1]...)
-			fids = append(fids, fid)
-			fids = append(fids, book.FragmentsIDs[findex+1:]...)
-			book.FragmentsIDs = fids
-			f.SeqNum = findex + 2
+		if err := insertFragment(tx, bid, fidAfter, fid); err != nil {
+			return err
 		}
-		book.FragmentsTotal++
-
+		key := tx.Bucket([]byte("positions")).Bucket(encode(bid)).Get(encode(fid))
+		f.SeqNum = seqNums(tx, bid, []uint64{binary.BigEndian.Uint64(key)})[0]
 		if err := marshal(fb, fid, f); err != nil {
 			return err
 		}
+		if err := reindexFragment(tx, bid, fid); err != nil {
+			return err
+		}
 
-		book.LastActivity = now
-
-		return marshal(b, bid, book)
+		_, err := updateStats(tx, bid, func(st *bookStats) {
+			st.FragmentsTotal++
+			st.LastActivity = now
+		})
+		return err
 	}); err != nil {
 		return Fragment{}, err
 	}
@@ -608,14 +819,10 @@ func (db *DB) AddFragment(bid, fidAfter uint64, text string) (Fragment, error) {
 func (db *DB) UpdateFragment(bid, fid uint64, text string) error {
 	now := time.Now()
 	return db.Update(func(tx *bolt.Tx) error {
-		b := tx.Bucket([]byte("index"))
-		var book Book
-		if found, err := unmarshal(b, bid, &book); err != nil {
+		if _, err := indexedBook(tx, bid); err != nil {
 			return err
-		} else if !found {
-			return ErrNotFound
 		}
-		if !has(book.FragmentsIDs, fid) {
+		if !hasFragment(tx, bid, fid) {
 			return ErrNotFound
 		}
 
@@ -626,14 +833,42 @@ func (db *DB) UpdateFragment(bid, fid uint64, text string) error {
 		} else if !found {
 			return ErrNotFound
 		}
+		if f.Text == text {
+			return nil
+		}
+
+		prev := &Revision{Created: f.Created, Text: f.Text}
+		if !f.SourceUpdated.IsZero() {
+			prev.Created = f.SourceUpdated
+		}
+		if err := appendRevision(tx, "source_history", bid, fid, prev, now, text); err != nil {
+			return err
+		}
+		vb := tx.Bucket([]byte("versions")).Bucket(encode(bid))
+		first, err := firstVersionTime(vb, f.VersionsIDs)
+		if err != nil {
+			return err
+		}
+		words := wordCount(f.Text)
+
 		f.Text = text
+		f.Updated = now
+		f.SourceUpdated = now
 		if err := marshal(fb, fid, f); err != nil {
 			return err
 		}
+		if err := reindexFragment(tx, bid, fid); err != nil {
+			return err
+		}
 
-		book.LastActivity = now
-
-		return marshal(b, bid, book)
+		_, err = updateStats(tx, bid, func(st *bookStats) {
+			st.LastActivity = now
+			if !first.IsZero() {
+				st.countTranslated(first, words, -1)
+				st.countTranslated(first, wordCount(text), 1)
+			}
+		})
+		return err
 	})
 }
 
@@ -641,18 +876,12 @@ func (db *DB) RemoveFragment(bid, fid uint64) (int, error) {
 	now := time.Now()
 	var fragmentsTranslated int
 	if err := db.Update(func(tx *bolt.Tx) error {
-		b := tx.Bucket([]byte("index"))
-		var book Book
-		if found, err := unmarshal(b, bid, &book); err != nil {
+		if _, err := indexedBook(tx, bid); err != nil {
 			return err
-		} else if !found {
-			return ErrNotFound
 		}
-		findex := idx(book.FragmentsIDs, fid)
-		if findex == -1 {
+		if !hasFragment(tx, bid, fid) {
 			return ErrNotFound
 		}
-		book.FragmentsIDs = append(book.FragmentsIDs[:findex], book.FragmentsIDs[findex+1:]...)
 
 		fb := tx.Bucket([]byte("fragments")).Bucket(encode(bid))
 		var f Fragment
@@ -661,34 +890,68 @@ func (db *DB) RemoveFragment(bid, fid uint64) (int, error) {
 		} else if !found {
 			return ErrNotFound
 		}
+		first, err := firstVersionTime(tx.Bucket([]byte("versions")).Bucket(encode(bid)), f.VersionsIDs)
+		if err != nil {
+			return err
+		}
+		if err := removeFragmentOrder(tx, bid, fid); err != nil {
+			return err
+		}
 		if err := fb.Delete(encode(fid)); err != nil {
 			return err
 		}
-
-		book.LastActivity = now
-		book.FragmentsTotal--
-		if len(f.VersionsIDs) > 0 {
-			book.FragmentsTranslated--
+		if err := removeVersions(tx, bid, f.VersionsIDs...); err != nil {
+			return err
+		}
+		if hb := tx.Bucket([]byte("source_history")).Bucket(encode(bid)); hb != nil {
+			if err := hb.DeleteBucket(encode(fid)); err != nil && err != bolt.ErrBucketNotFound {
+				return err
+			}
+		}
+		if err := reindexFragment(tx, bid, fid); err != nil {
+			return err
 		}
-		fragmentsTranslated = book.FragmentsTranslated
 
-		return marshal(b, bid, book)
+		st, err := updateStats(tx, bid, func(st *bookStats) {
+			st.LastActivity = now
+			st.FragmentsTotal--
+			if len(f.VersionsIDs) > 0 {
+				st.FragmentsTranslated--
+				st.countTranslated(first, wordCount(f.Text), -1)
+			}
+		})
+		fragmentsTranslated = st.FragmentsTranslated
+		return err
 	}); err != nil {
 		return 0, err
 	}
 	return fragmentsTranslated, nil
 }
 
+// removeVersions deletes the version records and their edit history.
+func removeVersions(tx *bolt.Tx, bid uint64, vids ...uint64) error {
+	vb := tx.Bucket([]byte("versions")).Bucket(encode(bid))
+	hb := tx.Bucket([]byte("history")).Bucket(encode(bid))
+	for _, vid := range vids {
+		if err := vb.Delete(encode(vid)); err != nil {
+			return err
+		}
+		if hb == nil {
+			continue
+		}
+		if err := hb.DeleteBucket(encode(vid)); err != nil && err != bolt.ErrBucketNotFound {
+			return err
+		}
+	}
+	return nil
+}
+
 func (db *DB) StarFragment(bid, fid uint64) error {
 	return db.Update(func(tx *bolt.Tx) error {
-		b := tx.Bucket([]byte("index"))
-		var book Book
-		if found, err := unmarshal(b, bid, &book); err != nil {
+		if _, err := indexedBook(tx, bid); err != nil {
 			return err
-		} else if !found {
-			return ErrNotFound
 		}
-		if !has(book.FragmentsIDs, fid) {
+		if !hasFragment(tx, bid, fid) {
 			return ErrNotFound
 		}
 
@@ -703,8 +966,11 @@ func (db *DB) StarFragment(bid, fid uint64) error {
 		}
 
 		f.Starred = true
+		if err := marshal(fb, fid, f); err != nil {
+			return err
+		}
 
-		return marshal(fb, fid, f)
+		return reindexFragment(tx, bid, fid)
 	})
 }
 
@@ -721,35 +987,11 @@ func (db *DB) UnstarFragment(bid, fid uint64) error {
 		}
 
 		f.Starred = false
-
-		return marshal(fb, fid, f)
-	})
-}
-
-func (db *DB) CommentFragment(bid, fid uint64, text string) error {
-	return db.Update(func(tx *bolt.Tx) error {
-		b := tx.Bucket([]byte("index"))
-		var book Book
-		if found, err := unmarshal(b, bid, &book); err != nil {
-			return err
-		} else if !found {
-			return ErrNotFound
-		}
-		if !has(book.FragmentsIDs, fid) {
-			return ErrNotFound
-		}
-
-		fb := tx.Bucket([]byte("fragments")).Bucket(encode(bid))
-		var f Fragment
-		if found, err := unmarshal(fb, fid, &f); err != nil {
+		if err := marshal(fb, fid, f); err != nil {
 			return err
-		} else if !found {
-			return ErrNotFound
 		}
 
-		f.Comment = text
-
-		return marshal(fb, fid, f)
+		return reindexFragment(tx, bid, fid)
 	})
 }
 
@@ -758,14 +1000,10 @@ func (db *DB) Translate(bid, fid, vidOrZero uint64, text string) (TranslationVer
 	now := time.Now()
 	var fragmentsTranslated int
 	err := db.Update(func(tx *bolt.Tx) error {
-		b := tx.Bucket([]byte("index"))
-		var book Book
-		if found, err := unmarshal(b, bid, &book); err != nil {
+		if _, err := indexedBook(tx, bid); err != nil {
 			return err
-		} else if !found {
-			return ErrNotFound
 		}
-		if !has(book.FragmentsIDs, fid) {
+		if !hasFragment(tx, bid, fid) {
 			return ErrNotFound
 		}
 
@@ -780,17 +1018,20 @@ func (db *DB) Translate(bid, fid, vidOrZero uint64, text string) (TranslationVer
 			return ErrNotFound
 		}
 
-		book.LastActivity = now
-		if len(f.VersionsIDs) == 0 {
-			book.FragmentsTranslated++
-		}
-		fragmentsTranslated = book.FragmentsTranslated
-
-		if err := marshal(b, bid, book); err != nil {
+		st, err := updateStats(tx, bid, func(st *bookStats) {
+			st.LastActivity = now
+			if len(f.VersionsIDs) == 0 {
+				st.FragmentsTranslated++
+				st.countTranslated(now, wordCount(f.Text), 1)
+			}
+		})
+		if err != nil {
 			return err
 		}
+		fragmentsTranslated = st.FragmentsTranslated
 
 		vb := tx.Bucket([]byte("versions")).Bucket(encode(bid))
+		var prev *Revision
 		if vid := vidOrZero; vid == 0 {
 			vid, _ = vb.NextSequence()
 			f.VersionsIDs = append(f.VersionsIDs, vid)
@@ -805,12 +1046,20 @@ func (db *DB) Translate(bid, fid, vidOrZero uint64, text string) (TranslationVer
 			} else if !found {
 				return ErrNotFound
 			}
+			prev = &Revision{Created: vers.Updated, Text: vers.Text}
+		}
+
+		if err := appendRevision(tx, "history", bid, vers.ID, prev, now, text); err != nil {
+			return err
 		}
 
 		vers.Updated = now
 		vers.Text = text
+		if err := marshal(vb, vers.ID, vers); err != nil {
+			return err
+		}
 
-		return marshal(vb, vers.ID, vers)
+		return reindexFragment(tx, bid, fid)
 	})
 	if err != nil {
 		return TranslationVersion{}, 0, err
@@ -818,18 +1067,81 @@ func (db *DB) Translate(bid, fid, vidOrZero uint64, text string) (TranslationVer
 	return vers, fragmentsTranslated, nil
 }
 
+// RewriteVersions replaces the text of every translation version of the
+// book with the result of the rewrite function, in one transaction, and
+// returns the versions changed. A rewrite is mechanical: it is recorded in
+// the history of the versions but leaves their Updated time alone, so that
+// a stale version stays stale and the statistics do not count it as work.
+func (db *DB) RewriteVersions(bid uint64, rewrite func(string) string) ([]VersionChange, error) {
+	changes := []VersionChange{}
+	now := time.Now()
+	err := db.Update(func(tx *bolt.Tx) error {
+		if _, err := indexedBook(tx, bid); err != nil {
+			return err
+		}
+		fb := tx.Bucket([]byte("fragments")).Bucket(encode(bid))
+		vb := tx.Bucket([]byte("versions")).Bucket(encode(bid))
+		if fb == nil || vb == nil {
+			return nil
+		}
+		for i, fid := range fragmentIDs(tx, bid) {
+			var f Fragment
+			if found, err := unmarshal(fb, fid, &f); err != nil {
+				return err
+			} else if !found {
+				continue
+			}
+			changed := false
+			for _, vid := range f.VersionsIDs {
+				var v TranslationVersion
+				if found, err := unmarshal(vb, vid, &v); err != nil {
+					return err
+				} else if !found {
+					continue
+				}
+				text := rewrite(v.Text)
+				if text == v.Text {
+					continue
+				}
+				prev := &Revision{Created: v.Updated, Text: v.Text}
+				if err := appendRevision(tx, "history", bid, vid, prev, now, text); err != nil {
+					return err
+				}
+				changes = append(changes, VersionChange{fid, vid, i + 1, v.Text, text})
+				v.Text = text
+				if err := marshal(vb, vid, v); err != nil {
+					return err
+				}
+				changed = true
+			}
+			if changed {
+				if err := reindexFragment(tx, bid, fid); err != nil {
+					return err
+				}
+			}
+		}
+		if len(changes) == 0 {
+			return nil
+		}
+		_, err := updateStats(tx, bid, func(st *bookStats) {
+			st.LastActivity = now
+		})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
+
 func (db *DB) RemoveVersion(bid, fid, vid uint64) (int, error) {
 	now := time.Now()
 	var fragmentsTranslated int
 	if err := db.Update(func(tx *bolt.Tx) error {
-		b := tx.Bucket([]byte("index"))
-		var book Book
-		if found, err := unmarshal(b, bid, &book); err != nil {
+		if _, err := indexedBook(tx, bid); err != nil {
 			return err
-		} else if !found {
-			return ErrNotFound
 		}
-		if !has(book.FragmentsIDs, fid) {
+		if !hasFragment(tx, bid, fid) {
 			return ErrNotFound
 		}
 
@@ -844,19 +1156,46 @@ func (db *DB) RemoveVersion(bid, fid, vid uint64) (int, error) {
 		if vindex == -1 {
 			return ErrNotFound
 		}
+		vb := tx.Bucket([]byte("versions")).Bucket(encode(bid))
+		first, err := firstVersionTime(vb, f.VersionsIDs)
+		if err != nil {
+			return err
+		}
 		f.VersionsIDs = append(f.VersionsIDs[:vindex], f.VersionsIDs[vindex+1:]...)
+		newFirst, err := firstVersionTime(vb, f.VersionsIDs)
+		if err != nil {
+			return err
+		}
+		if f.PreferredVersion == vid {
+			f.PreferredVersion = 0
+		}
 
 		if err := marshal(fb, f.ID, f); err != nil {
 			return err
 		}
-
-		book.LastActivity = now
-		if len(f.VersionsIDs) == 0 {
-			book.FragmentsTranslated--
+		if err := removeVersions(tx, bid, vid); err != nil {
+			return err
+		}
+		if err := reindexFragment(tx, bid, fid); err != nil {
+			return err
 		}
-		fragmentsTranslated = book.FragmentsTranslated
 
-		return marshal(b, bid, book)
+		st, err := updateStats(tx, bid, func(st *bookStats) {
+			st.LastActivity = now
+			if len(f.VersionsIDs) == 0 {
+				st.FragmentsTranslated--
+			}
+			// The fragment counts on the day of its first version left.
+			if !newFirst.Equal(first) {
+				words := wordCount(f.Text)
+				st.countTranslated(first, words, -1)
+				if !newFirst.IsZero() {
+					st.countTranslated(newFirst, words, 1)
+				}
+			}
+		})
+		fragmentsTranslated = st.FragmentsTranslated
+		return err
 	}); err != nil {
 		return 0, err
 	}
@@ -902,97 +1241,133 @@ func (db *DB) UpdateScratchpad(bid uint64, text string) error {
 
 func (db *DB) UpdateLastVisitedPage(bid uint64, page int) error {
 	return db.Update(func(tx *bolt.Tx) error {
-		b := tx.Bucket([]byte("index"))
-		var book Book
-		if found, err := unmarshal(b, bid, &book); err != nil {
+		book, err := indexedBook(tx, bid)
+		if err != nil {
 			return err
-		} else if !found {
-			return ErrNotFound
 		}
 		if book.LastVisitedPage == page {
 			return nil
 		}
 		book.LastVisitedPage = page
-		return marshal(b, bid, &book)
+		return putStats(tx, bid, book.bookStats)
 	})
 }
 
-func (db *DB) ExportBookToJSON(bid uint64) ([]byte, error) {
-	var data []byte
+// ExportBook returns all the data of the book, including its edit history.
+func (db *DB) ExportBook(bid uint64) (BookData, error) {
+	var data BookData
 	err := db.View(func(tx *bolt.Tx) error {
-		b := tx.Bucket([]byte("index"))
-		var book Book
-		if found, err := unmarshal(b, bid, &book); err != nil {
+		data = BookData{}
+		book := &data.Book
+		var found bool
+		var err error
+		if *book, found, err = getBook(tx, "index", bid); err != nil {
 			return err
 		} else if !found {
-			return ErrNotFound
+			if *book, found, err = getBook(tx, "trash", bid); err != nil {
+				return err
+			} else if !found {
+				return ErrNotFound
+			}
 		}
+		// The day counters are counted again on import.
+		book.Days = nil
+		book.FragmentsIDs = fragmentIDs(tx, bid)
 		fb := tx.Bucket([]byte("fragments")).Bucket(encode(bid))
 		vb := tx.Bucket([]byte("versions")).Bucket(encode(bid))
-		fragments := make([]Fragment, 0, book.FragmentsTotal)
-		versions := make([]TranslationVersion, 0, book.FragmentsTranslated)
+		data.Fragments = make([]Fragment, 0, book.FragmentsTotal)
+		data.Versions = make([]TranslationVersion, 0, book.FragmentsTranslated)
+		data.History = make(map[uint64][]Revision)
+		data.SourceHistory = make(map[uint64][]Revision)
 		for _, fid := range book.FragmentsIDs {
 			var f Fragment
 			if _, err := unmarshal(fb, fid, &f); err != nil {
 				return err
 			}
-			fragments = append(fragments, f)
+			data.Fragments = append(data.Fragments, f)
+			revs, err := revisions(tx, "source_history", bid, fid)
+			if err != nil {
+				return err
+			}
+			if len(revs) > 0 {
+				data.SourceHistory[fid] = revs
+			}
 			for _, vid := range f.VersionsIDs {
 				var v TranslationVersion
 				if _, err := unmarshal(vb, vid, &v); err != nil {
 					return err
 				}
-				versions = append(versions, v)
+				data.Versions = append(data.Versions, v)
+				revs, err := revisions(tx, "history", bid, vid)
+				if err != nil {
+					return err
+				}
+				if len(revs) > 0 {
+					data.History[vid] = revs
+				}
 			}
 		}
 
-		var sp *Scratchpad
-		spb := tx.Bucket([]byte("scratchpad"))
-		if _, err := unmarshal(spb, bid, &sp); err != nil {
+		if data.Glossary, err = glossaryTerms(tx, bid); err != nil {
+			return err
+		}
+		if data.Filters, err = savedFilters(tx, bid); err != nil {
 			return err
 		}
 
-		var err error
-		data, err = json.Marshal(struct {
-			Book        `json:"book"`
-			Fragments   []Fragment           `json:"fragments"`
-			Versions    []TranslationVersion `json:"versions"`
-			*Scratchpad `json:"scratchpad"`
-		}{
-			book,
-			fragments,
-			versions,
-			sp,
-		})
+		spb := tx.Bucket([]byte("scratchpad"))
+		_, err = unmarshal(spb, bid, &data.Scratchpad)
 		return err
 	})
 	if err != nil {
-		return nil, err
+		return BookData{}, err
 	}
 	return data, nil
 }
 
-func (db *DB) ImportBookFromJSON(data []byte) (uint64, error) {
-	var book Book
-	var fragments []Fragment
-	var versions []TranslationVersion
-	var sp *Scratchpad
-	if err := json.Unmarshal(data, &struct {
-		Book       *Book
-		Fragments  *[]Fragment
-		Versions   *[]TranslationVersion
-		Scratchpad **Scratchpad
-	}{
-		&book,
-		&fragments,
-		&versions,
-		&sp,
-	}); err != nil {
-		return 0, err
+func putRevisions(tx *bolt.Tx, name string, bid, id uint64, revs []Revision) error {
+	pb, err := tx.Bucket([]byte(name)).CreateBucketIfNotExists(encode(bid))
+	if err != nil {
+		return err
 	}
+	hb, err := pb.CreateBucket(encode(id))
+	if err != nil {
+		return err
+	}
+	for _, r := range revs {
+		if err := marshal(hb, r.ID, r); err != nil {
+			return err
+		}
+		if r.ID > hb.Sequence() {
+			if err := hb.SetSequence(r.ID); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// ImportBook adds the book to the database. If keepIDs is true, the IDs of
+// the book, its fragments and versions are preserved; otherwise new IDs are
+// assigned. A book which was removed is put in the trash.
+func (db *DB) ImportBook(data BookData, keepIDs bool) (uint64, error) {
+	book := data.Book
 	if err := db.Update(func(tx *bolt.Tx) error {
 		b := tx.Bucket([]byte("index"))
-		bid, _ := b.NextSequence()
+		tb := tx.Bucket([]byte("trash"))
+		bid := book.ID
+		if keepIDs {
+			if b.Get(encode(bid)) != nil || tb.Get(encode(bid)) != nil {
+				return fmt.Errorf("book %d already exists", bid)
+			}
+			if bid > b.Sequence() {
+				if err := b.SetSequence(bid); err != nil {
+					return err
+				}
+			}
+		} else {
+			bid, _ = b.NextSequence()
+		}
 		book.ID = bid
 		fb, err := tx.Bucket([]byte("fragments")).CreateBucket(encode(bid))
 		if err != nil {
@@ -1003,34 +1378,82 @@ func (db *DB) ImportBookFromJSON(data []byte) (uint64, error) {
 			return err
 		}
 
+		nextID := func(b *bolt.Bucket, id uint64) (uint64, error) {
+			if !keepIDs {
+				return b.NextSequence()
+			}
+			if id > b.Sequence() {
+				return id, b.SetSequence(id)
+			}
+			return id, nil
+		}
+
 		vmap := make(map[uint64]uint64)
-		for _, v := range versions {
-			vid, _ := vb.NextSequence()
+		for _, v := range data.Versions {
+			vid, err := nextID(vb, v.ID)
+			if err != nil {
+				return err
+			}
 			vmap[v.ID] = vid
+			if revs := data.History[v.ID]; len(revs) > 0 {
+				if err := putRevisions(tx, "history", bid, vid, revs); err != nil {
+					return err
+				}
+			}
 			v.ID = vid
 			if err := marshal(vb, vid, v); err != nil {
 				return err
 			}
 		}
-		fmap := make(map[uint64]uint64)
-		for i, f := range fragments {
-			fid, _ := fb.NextSequence()
-			fmap[f.ID] = fid
+		book.FragmentsIDs = make([]uint64, len(data.Fragments))
+		book.FragmentsTotal = len(data.Fragments)
+		book.FragmentsTranslated = 0
+		for i, f := range data.Fragments {
+			fid, err := nextID(fb, f.ID)
+			if err != nil {
+				return err
+			}
+			if revs := data.SourceHistory[f.ID]; len(revs) > 0 {
+				if err := putRevisions(tx, "source_history", bid, fid, revs); err != nil {
+					return err
+				}
+			}
 			f.ID = fid
 			book.FragmentsIDs[i] = fid
 			for j, vid := range f.VersionsIDs {
 				f.VersionsIDs[j] = vmap[vid]
 			}
+			f.PreferredVersion = vmap[f.PreferredVersion]
+			f.upgradeComment()
+			if len(f.VersionsIDs) > 0 {
+				book.FragmentsTranslated++
+			}
 			if err := marshal(fb, fid, f); err != nil {
 				return err
 			}
 		}
 
-		if err := marshal(b, bid, book); err != nil {
+		if err := appendFragments(tx, bid, book.FragmentsIDs...); err != nil {
+			return err
+		}
+		if err := reindexFragment(tx, bid, book.FragmentsIDs...); err != nil {
+			return err
+		}
+
+		if book.Days, err = translatedDays(tx, bid); err != nil {
+			return err
+		}
+		if err := putStats(tx, bid, book.bookStats); err != nil {
+			return err
+		}
+		if !book.Deleted.IsZero() {
+			b = tb
+		}
+		if err := marshal(b, bid, book.bookInfo); err != nil {
 			return err
 		}
 
-		if sp != nil {
+		if sp := data.Scratchpad; sp != nil {
 			sp.ID = bid
 			b := tx.Bucket([]byte("scratchpad"))
 			if err := marshal(b, bid, sp); err != nil {
@@ -1038,9 +1461,50 @@ func (db *DB) ImportBookFromJSON(data []byte) (uint64, error) {
 			}
 		}
 
+		if len(data.Glossary) > 0 {
+			gb, err := tx.Bucket([]byte("glossary")).CreateBucket(encode(bid))
+			if err != nil {
+				return err
+			}
+			for _, t := range data.Glossary {
+				if t.ID, err = nextID(gb, t.ID); err != nil {
+					return err
+				}
+				if err := marshal(gb, t.ID, t); err != nil {
+					return err
+				}
+			}
+		}
+
+		if len(data.Filters) > 0 {
+			fb, err := tx.Bucket([]byte("filters")).CreateBucket(encode(bid))
+			if err != nil {
+				return err
+			}
+			for _, f := range data.Filters {
+				data, err := json.Marshal(f)
+				if err != nil {
+					return err
+				}
+				if err := fb.Put([]byte(f.Name), data); err != nil {
+					return err
+				}
+			}
+		}
+
 		return nil
 	}); err != nil {
 		return 0, err
 	}
 	return book.ID, nil
 }
+
+// Backup writes a consistent copy of the database to w. size is called
+// with the size of the copy before anything is written.
+func (db *DB) Backup(w io.Writer, size func(int64)) error {
+	return db.View(func(tx *bolt.Tx) error {
+		size(tx.Size())
+		_, err := tx.WriteTo(w)
+		return err
+	})
+}
@@ -0,0 +1,115 @@
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"net/url"
+	"path/filepath"
+	"testing"
+)
+
+// openTestStorages opens an empty database of every kind.
+func openTestStorages(t *testing.T) []Storage {
+	db, err := OpenDatabase(filepath.Join(t.TempDir(), "tl.db"), 0600, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	sdb, err := OpenSQLite(filepath.Join(t.TempDir(), "tl.sqlite"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { sdb.Close() })
+	return []Storage{&db, sdb}
+}
+
+// addStaleVersion adds a book with a translated fragment, then edits its
+// original, so that the version is stale.
+func addStaleVersion(t *testing.T, db Storage, translation string) (uint64, TranslationVersion) {
+	bid, err := db.AddBook("Book", []string{"One", "Two"}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	book, err := db.BookByID(bid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fid := book.FragmentsIDs[0]
+	v, _, err := db.Translate(bid, fid, 0, translation)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.UpdateFragment(bid, fid, "One!"); err != nil {
+		t.Fatal(err)
+	}
+	return bid, v
+}
+
+// checkStillStale checks that the version added by addStaleVersion was
+// rewritten and is still stale.
+func checkStillStale(t *testing.T, db Storage, bid uint64, v TranslationVersion) {
+	t.Helper()
+	book, err := db.BookWithTranslations(bid, 0, -1, filtersFromRequest(url.Values{"f": {"stale"}})...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(book.Fragments) != 1 || len(book.Fragments[0].Versions) != 1 {
+		t.Fatalf("%T: the rewritten version is no longer stale", db)
+	}
+	got := book.Fragments[0].Versions[0]
+	if !got.Stale || !got.Updated.Equal(v.Updated) || got.Text == v.Text {
+		t.Errorf("%T: got %+v, want the rewritten text with the time %v", db, got, v.Updated)
+	}
+}
+
+func TestRewriteVersionsKeepsStale(t *testing.T) {
+	for _, db := range openTestStorages(t) {
+		bid, v := addStaleVersion(t, db, `"Один"`)
+		changes, err := db.RewriteVersions(bid, typograph)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(changes) != 1 {
+			t.Fatalf("%T: %d versions changed, want 1", db, len(changes))
+		}
+		checkStillStale(t, db, bid, v)
+	}
+}
+
+// TestAddFragmentSeqNum checks the sequence number AddFragment returns for
+// the new fragment.
+func TestAddFragmentSeqNum(t *testing.T) {
+	for _, db := range openTestStorages(t) {
+		bid, err := db.AddBook("Book", []string{"One", "Two", "Three"}, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, tc := range []struct {
+			after uint64
+			want  int
+		}{
+			{2, 3},
+			{0, 1},
+			{3, 6},
+		} {
+			f, err := db.AddFragment(bid, tc.after, "New")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if f.SeqNum != tc.want {
+				t.Errorf("%T: after %d: got #%d, want #%d", db, tc.after, f.SeqNum, tc.want)
+			}
+		}
+	}
+}
@@ -0,0 +1,105 @@
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"bytes"
+	"html"
+	"html/template"
+	"regexp"
+)
+
+var rToken = regexp.MustCompile(`\w+|\s+|[^\w\s]`)
+
+type diffOp int
+
+const (
+	diffEqual diffOp = iota
+	diffDelete
+	diffInsert
+)
+
+type diffChunk struct {
+	op   diffOp
+	text string
+}
+
+// diff computes a word-level difference between a and b.
+func diff(a, b string) []diffChunk {
+	x := rToken.FindAllString(a, -1)
+	y := rToken.FindAllString(b, -1)
+
+	// lcs[i][j] is the length of the longest common subsequence
+	// of x[i:] and y[j:].
+	lcs := make([][]int, len(x)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(y)+1)
+	}
+	for i := len(x) - 1; i >= 0; i-- {
+		for j := len(y) - 1; j >= 0; j-- {
+			if x[i] == y[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var chunks []diffChunk
+	add := func(op diffOp, text string) {
+		if n := len(chunks); n > 0 && chunks[n-1].op == op {
+			chunks[n-1].text += text
+			return
+		}
+		chunks = append(chunks, diffChunk{op, text})
+	}
+	i, j := 0, 0
+	for i < len(x) && j < len(y) {
+		switch {
+		case x[i] == y[j]:
+			add(diffEqual, x[i])
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			add(diffDelete, x[i])
+			i++
+		default:
+			add(diffInsert, y[j])
+			j++
+		}
+	}
+	for ; i < len(x); i++ {
+		add(diffDelete, x[i])
+	}
+	for ; j < len(y); j++ {
+		add(diffInsert, y[j])
+	}
+	return chunks
+}
+
+func renderDiff(a, b string) template.HTML {
+	var buf bytes.Buffer
+	for _, c := range diff(a, b) {
+		s := html.EscapeString(c.text)
+		switch c.op {
+		case diffEqual:
+			buf.WriteString(s)
+		case diffDelete:
+			buf.WriteString("<del>" + s + "</del>")
+		case diffInsert:
+			buf.WriteString("<ins>" + s + "</ins>")
+		}
+	}
+	return template.HTML(nl2br.Replace(buf.String()))
+}
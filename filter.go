@@ -0,0 +1,398 @@
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/boltdb/bolt"
+	"github.com/gorilla/mux"
+	"github.com/opennota/substring"
+)
+
+type filterKind int
+
+const (
+	fUntranslated filterKind = iota
+	fCommented
+	fStarred
+	fWithTwoOrMoreVersions
+	fOriginalContains
+	fTranslationContains
+	fOriginalLength
+	fStale
+	fQA
+	fOriginalMatches
+	fTranslationMatches
+	fUpdated
+	fStatus
+	fNoPreferredVersion
+	fUnresolved
+)
+
+// bookFilter restricts the fragments returned by BookWithTranslations. A
+// fragment has to match all the filters given.
+type bookFilter struct {
+	Kind filterKind
+	Args []string
+}
+
+// dateLayout is the format of the dates of the fUpdated filter.
+const dateLayout = "2006-01-02"
+
+// filtersFromRequest returns the filters of the book page. Every value of
+// the f parameter is a filter; its arguments are in the other parameters.
+// Filters whose arguments are missing are ignored.
+func filtersFromRequest(form url.Values) []bookFilter {
+	var filters []bookFilter
+	add := func(kind filterKind, args ...string) {
+		for _, arg := range args {
+			if arg == "" {
+				return
+			}
+		}
+		filters = append(filters, bookFilter{kind, args})
+	}
+	for _, f := range form["f"] {
+		switch f {
+		case "u":
+			add(fUntranslated)
+		case "c":
+			add(fCommented)
+		case "ur":
+			add(fUnresolved)
+		case "s":
+			add(fStarred)
+		case "2":
+			add(fWithTwoOrMoreVersions)
+		case "stale":
+			add(fStale)
+		case "np":
+			add(fNoPreferredVersion)
+		case "st":
+			add(fStatus, form.Get("st"))
+		case "o":
+			add(fOriginalContains, form.Get("to"))
+		case "t":
+			add(fTranslationContains, form.Get("tt"))
+		case "ro":
+			add(fOriginalMatches, form.Get("ro"))
+		case "rt":
+			add(fTranslationMatches, form.Get("rt"))
+		case "l":
+			add(fOriginalLength, form.Get("comp"), form.Get("n"), form.Get("unit"))
+		case "d":
+			if from, to := form.Get("df"), form.Get("dt"); from != "" || to != "" {
+				filters = append(filters, bookFilter{fUpdated, []string{from, to}})
+			}
+		case "qa":
+			filters = append(filters, bookFilter{fQA, form["qa"]})
+		}
+	}
+	return filters
+}
+
+func hasFilter(filters []bookFilter, kind filterKind) bool {
+	for _, f := range filters {
+		if f.Kind == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// wordCount counts the words in any script.
+func wordCount(s string) int {
+	return len(rWord.FindAllStringIndex(s, -1))
+}
+
+// lengthMatcher returns a predicate for the fOriginalLength filter; the
+// filter arguments are "less" or "more", the length, and "chars" or "words".
+func lengthMatcher(filterArg []string) func(string) bool {
+	compare := func(a, b int) bool { return a < b }
+	if filterArg[0] == "more" {
+		compare = func(a, b int) bool { return a > b }
+	}
+	n, _ := strconv.Atoi(filterArg[1])
+	count := utf8.RuneCountInString
+	if filterArg[2] == "words" {
+		count = wordCount
+	}
+	return func(s string) bool { return compare(count(s), n) }
+}
+
+// updatedMatcher returns a predicate for the fUpdated filter; the filter
+// arguments are the first and the last day of the range, either of which
+// may be empty.
+func updatedMatcher(filterArg []string) (func(time.Time) bool, error) {
+	var from, to time.Time
+	var err error
+	if filterArg[0] != "" {
+		if from, err = time.ParseInLocation(dateLayout, filterArg[0], time.Local); err != nil {
+			return nil, fmt.Errorf("invalid date: %q", filterArg[0])
+		}
+	}
+	if filterArg[1] != "" {
+		if to, err = time.ParseInLocation(dateLayout, filterArg[1], time.Local); err != nil {
+			return nil, fmt.Errorf("invalid date: %q", filterArg[1])
+		}
+		to = to.AddDate(0, 0, 1)
+	}
+	return func(t time.Time) bool {
+		return !t.Before(from) && (to.IsZero() || t.Before(to))
+	}, nil
+}
+
+// fragmentMatcher checks the filters which can't be answered from the
+// filter indexes against the texts of a fragment.
+type fragmentMatcher struct {
+	orig    []func(string) bool
+	trans   []func(string) bool
+	updated []func(time.Time) bool
+	qa      []qaCheck
+	withQA  bool
+}
+
+func newFragmentMatcher(filters []bookFilter) (*fragmentMatcher, error) {
+	m := &fragmentMatcher{}
+	for _, f := range filters {
+		switch f.Kind {
+		case fOriginalContains:
+			m.orig = append(m.orig, substring.NewMatcher(f.Args[0]).Match)
+		case fOriginalMatches:
+			re, err := regexp.Compile(f.Args[0])
+			if err != nil {
+				return nil, err
+			}
+			m.orig = append(m.orig, re.MatchString)
+		case fOriginalLength:
+			m.orig = append(m.orig, lengthMatcher(f.Args))
+		case fTranslationContains:
+			m.trans = append(m.trans, substring.NewMatcher(f.Args[0]).Match)
+		case fTranslationMatches:
+			re, err := regexp.Compile(f.Args[0])
+			if err != nil {
+				return nil, err
+			}
+			m.trans = append(m.trans, re.MatchString)
+		case fUpdated:
+			match, err := updatedMatcher(f.Args)
+			if err != nil {
+				return nil, err
+			}
+			m.updated = append(m.updated, match)
+		case fStatus:
+			if !validStatus(f.Args[0]) {
+				return nil, fmt.Errorf("invalid status: %q", f.Args[0])
+			}
+		case fQA:
+			m.qa = selectedQAChecks(f.Args)
+			m.withQA = true
+		}
+	}
+	return m, nil
+}
+
+func (m *fragmentMatcher) empty() bool {
+	return len(m.orig) == 0 && len(m.trans) == 0 && len(m.updated) == 0 && !m.withQA
+}
+
+// needsVersions reports whether match looks at the translation versions.
+func (m *fragmentMatcher) needsVersions() bool {
+	return len(m.trans) > 0 || len(m.updated) > 0 || m.withQA
+}
+
+// versionMatches reports whether the text of a translation version
+// matches all the translation filters.
+func (m *fragmentMatcher) versionMatches(text string) bool {
+	for _, match := range m.trans {
+		if !match(text) {
+			return false
+		}
+	}
+	return true
+}
+
+func (m *fragmentMatcher) match(f *Fragment, versions []TranslationVersion) bool {
+	for _, match := range m.orig {
+		if !match(f.Text) {
+			return false
+		}
+	}
+	if len(m.trans) > 0 {
+		found := false
+		for _, v := range versions {
+			if m.versionMatches(v.Text) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	for _, match := range m.updated {
+		found := match(f.Updated)
+		for _, v := range versions {
+			found = found || match(v.Updated)
+		}
+		if !found {
+			return false
+		}
+	}
+	return !m.withQA || len(qaIssues(f.Text, versions, m.qa)) > 0
+}
+
+// SavedFilter is a named combination of filters of the book page; Query
+// is the query string of the page.
+type SavedFilter struct {
+	Name  string `json:"name"`
+	Query string `json:"query"`
+}
+
+func (f SavedFilter) URL() template.URL {
+	q, _ := url.ParseQuery(f.Query)
+	return template.URL("?" + q.Encode())
+}
+
+// filterQuery returns the query string of the book page without the
+// parameters which aren't part of the filters.
+func filterQuery(form url.Values) string {
+	q := make(url.Values)
+	for k, v := range form {
+		if k != "page" && k != "url" {
+			q[k] = v
+		}
+	}
+	return q.Encode()
+}
+
+func savedFilters(tx *bolt.Tx, bid uint64) ([]SavedFilter, error) {
+	filters := []SavedFilter{}
+	fb := tx.Bucket([]byte("filters")).Bucket(encode(bid))
+	if fb == nil {
+		return filters, nil
+	}
+	err := fb.ForEach(func(_, v []byte) error {
+		var f SavedFilter
+		if err := json.Unmarshal(v, &f); err != nil {
+			return err
+		}
+		filters = append(filters, f)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return filters, nil
+}
+
+func (db *DB) SavedFilters(bid uint64) ([]SavedFilter, error) {
+	var filters []SavedFilter
+	err := db.View(func(tx *bolt.Tx) error {
+		if _, err := indexedBook(tx, bid); err != nil {
+			return err
+		}
+		var err error
+		filters, err = savedFilters(tx, bid)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return filters, nil
+}
+
+func (db *DB) SaveFilter(bid uint64, f SavedFilter) error {
+	return db.Update(func(tx *bolt.Tx) error {
+		if _, err := indexedBook(tx, bid); err != nil {
+			return err
+		}
+		fb, err := tx.Bucket([]byte("filters")).CreateBucketIfNotExists(encode(bid))
+		if err != nil {
+			return err
+		}
+		data, err := json.Marshal(f)
+		if err != nil {
+			return err
+		}
+		return fb.Put([]byte(f.Name), data)
+	})
+}
+
+func (db *DB) RemoveFilter(bid uint64, name string) error {
+	return db.Update(func(tx *bolt.Tx) error {
+		if _, err := indexedBook(tx, bid); err != nil {
+			return err
+		}
+		fb := tx.Bucket([]byte("filters")).Bucket(encode(bid))
+		if fb == nil || fb.Get([]byte(name)) == nil {
+			return ErrNotFound
+		}
+		return fb.Delete([]byte(name))
+	})
+}
+
+func (a *App) Filters(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	bid, err := u64(vars["book_id"])
+	if err != nil {
+		http.Error(w, "Invalid book ID", http.StatusBadRequest)
+		return
+	}
+	name := strings.TrimSpace(r.FormValue("name"))
+	if name == "" {
+		http.Error(w, "Name must not be empty!", http.StatusBadRequest)
+		return
+	}
+
+	switch r.Method {
+	case "POST":
+		query, err := url.ParseQuery(strings.TrimPrefix(r.FormValue("query"), "?"))
+		if err != nil || len(filtersFromRequest(query)) == 0 {
+			http.Error(w, "Invalid filter", http.StatusBadRequest)
+			return
+		}
+		f := SavedFilter{name, filterQuery(query)}
+		if err := a.db.SaveFilter(bid, f); err != nil {
+			if err == ErrNotFound {
+				http.Error(w, "Book not found", 404)
+				return
+			}
+			internalError(w, err)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(f)
+
+	case "DELETE":
+		if err := a.db.RemoveFilter(bid, name); err != nil {
+			if err == ErrNotFound {
+				http.Error(w, "Filter not found", 404)
+				return
+			}
+			internalError(w, err)
+		}
+	}
+}
@@ -0,0 +1,34 @@
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import "testing"
+
+// TestLengthFilterWords checks that the length filter counts the words of
+// any script.
+func TestLengthFilterWords(t *testing.T) {
+	for _, tc := range []struct {
+		args []string
+		text string
+		want bool
+	}{
+		{[]string{"more", "2", "words"}, "Привет, дорогой мир!", true},
+		{[]string{"less", "3", "words"}, "Привет, дорогой мир!", false},
+		{[]string{"less", "3", "words"}, "Hello, world!", true},
+	} {
+		if got := lengthMatcher(tc.args)(tc.text); got != tc.want {
+			t.Errorf("%v on %q: got %v, want %v", tc.args, tc.text, got, tc.want)
+		}
+	}
+}
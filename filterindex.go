@@ -0,0 +1,570 @@
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/boltdb/bolt"
+)
+
+// The filter index of a book lives in filter_index/{book ID}:
+//
+//	fragments/{fragment ID}            the indexEntry of the fragment
+//	untranslated/{order key}           one bucket per filter set
+//	...
+//	orig_trigrams/{trigram}\0{order key}   the trigrams of the words of the originals
+//	trans_trigrams/{trigram}\0{order key}  the trigrams of the words of the translations
+//	counts                                 the sizes of the counted sets
+//
+// The sets and the trigrams are keyed by the order keys of the fragments,
+// with the fragment IDs as the values, so that cursors walk them in the
+// order of the book and several of them can be intersected a page at a
+// time. The trigrams of the originals also give the candidates of the
+// translation memory. Words are lowercased.
+
+const (
+	setUntranslated = "untranslated"
+	setCommented    = "commented"
+	setStarred      = "starred"
+	setMultiple     = "multiple"
+	setStale        = "stale"
+	setReview       = "review"
+	setApproved     = "approved"
+	setPreferred    = "preferred"
+	setUnresolved   = "unresolved"
+	setDraft        = "draft"
+)
+
+// countedSets are the filter sets whose sizes are kept up to date, so that
+// the stats of a book don't have to read them.
+var countedSets = map[string]bool{setReview: true, setApproved: true}
+
+// indexEntry is what the filter index knows about a fragment. Key is the
+// order key the fragment is filed under.
+type indexEntry struct {
+	Key   uint64   `json:"key,omitempty"`
+	Sets  []string `json:"sets,omitempty"`
+	Orig  []string `json:"orig,omitempty"`
+	Trans []string `json:"trans,omitempty"`
+}
+
+func indexWords(texts ...string) []string {
+	seen := make(map[string]bool)
+	var words []string
+	for _, text := range texts {
+		for _, w := range rWord.FindAllString(strings.ToLower(text), -1) {
+			if !seen[w] {
+				seen[w] = true
+				words = append(words, w)
+			}
+		}
+	}
+	sort.Strings(words)
+	return words
+}
+
+// trigrams returns the trigrams of the words, the keys under which they
+// are indexed. Words shorter than three letters have none.
+func trigrams(words []string) []string {
+	seen := make(map[string]bool)
+	var tt []string
+	for _, w := range words {
+		r := []rune(w)
+		for i := 0; i+3 <= len(r); i++ {
+			if t := string(r[i : i+3]); !seen[t] {
+				seen[t] = true
+				tt = append(tt, t)
+			}
+		}
+	}
+	sort.Strings(tt)
+	return tt
+}
+
+func trigramKey(t string, key []byte) []byte {
+	return append([]byte(t+"\x00"), key...)
+}
+
+// computeIndexEntry builds the index entry of a fragment from its current
+// data. The entry is empty if the fragment doesn't exist or has no place
+// in the order of the book.
+func computeIndexEntry(tx *bolt.Tx, bid, fid uint64) (indexEntry, error) {
+	var e indexEntry
+	fb := tx.Bucket([]byte("fragments")).Bucket(encode(bid))
+	var pb *bolt.Bucket
+	// The order is kept apart since the migration that follows the
+	// one which built the first filter indexes.
+	if b := tx.Bucket([]byte("positions")); b != nil {
+		pb = b.Bucket(encode(bid))
+	}
+	if fb == nil || pb == nil {
+		return e, nil
+	}
+	key := pb.Get(encode(fid))
+	if key == nil {
+		return e, nil
+	}
+	var f Fragment
+	if found, err := unmarshal(fb, fid, &f); err != nil || !found {
+		return e, err
+	}
+	e.Key = binary.BigEndian.Uint64(key)
+
+	vb := tx.Bucket([]byte("versions")).Bucket(encode(bid))
+	var texts []string
+	stale := false
+	preferred := false
+	for _, vid := range f.VersionsIDs {
+		var v TranslationVersion
+		if found, err := unmarshal(vb, vid, &v); err != nil {
+			return e, err
+		} else if !found {
+			continue
+		}
+		texts = append(texts, v.Text)
+		if vid == f.PreferredVersion {
+			preferred = true
+		}
+		if v.Updated.Before(f.SourceUpdated) {
+			stale = true
+		}
+	}
+
+	if len(texts) == 0 {
+		e.Sets = append(e.Sets, setUntranslated)
+	}
+	if len(f.Threads) > 0 {
+		e.Sets = append(e.Sets, setCommented)
+	}
+	if f.HasUnresolvedThreads() {
+		e.Sets = append(e.Sets, setUnresolved)
+	}
+	if f.Starred {
+		e.Sets = append(e.Sets, setStarred)
+	}
+	if len(texts) >= 2 {
+		e.Sets = append(e.Sets, setMultiple)
+	}
+	if stale {
+		e.Sets = append(e.Sets, setStale)
+	}
+	if preferred {
+		e.Sets = append(e.Sets, setPreferred)
+	}
+	if len(texts) > 0 {
+		switch f.Status {
+		case statusReview:
+			e.Sets = append(e.Sets, setReview)
+		case statusApproved:
+			e.Sets = append(e.Sets, setApproved)
+		default:
+			e.Sets = append(e.Sets, setDraft)
+		}
+	}
+	e.Orig = indexWords(f.Text)
+	e.Trans = indexWords(texts...)
+	return e, nil
+}
+
+// difference returns the elements of a which are not in b.
+func difference(a, b []string) []string {
+	in := make(map[string]bool, len(b))
+	for _, s := range b {
+		in[s] = true
+	}
+	var d []string
+	for _, s := range a {
+		if !in[s] {
+			d = append(d, s)
+		}
+	}
+	return d
+}
+
+// setIndexEntry replaces the index entry of the fragment with e, updating
+// the filter sets and the word indexes.
+func setIndexEntry(tx *bolt.Tx, bid, fid uint64, e indexEntry) error {
+	pb, err := tx.Bucket([]byte("filter_index")).CreateBucketIfNotExists(encode(bid))
+	if err != nil {
+		return err
+	}
+	eb, err := pb.CreateBucketIfNotExists([]byte("fragments"))
+	if err != nil {
+		return err
+	}
+	var old indexEntry
+	if _, err := unmarshal(eb, fid, &old); err != nil {
+		return err
+	}
+	oldKey, key := orderKey(old.Key), orderKey(e.Key)
+
+	removed, added := difference(old.Sets, e.Sets), difference(e.Sets, old.Sets)
+	if err := updateSetCounts(pb, added, removed); err != nil {
+		return err
+	}
+	// A fragment which has moved is filed anew under its new key.
+	moved := old.Key != e.Key
+	if moved {
+		removed, added = old.Sets, e.Sets
+	}
+	for _, name := range removed {
+		if b := pb.Bucket([]byte(name)); b != nil {
+			if err := b.Delete(oldKey); err != nil {
+				return err
+			}
+		}
+	}
+	for _, name := range added {
+		b, err := pb.CreateBucketIfNotExists([]byte(name))
+		if err != nil {
+			return err
+		}
+		if err := b.Put(key, encode(fid)); err != nil {
+			return err
+		}
+	}
+
+	for _, idx := range []struct {
+		name     string
+		old, new []string
+	}{
+		{"orig_trigrams", trigrams(old.Orig), trigrams(e.Orig)},
+		{"trans_trigrams", trigrams(old.Trans), trigrams(e.Trans)},
+	} {
+		tb, err := pb.CreateBucketIfNotExists([]byte(idx.name))
+		if err != nil {
+			return err
+		}
+		removed, added := difference(idx.old, idx.new), difference(idx.new, idx.old)
+		if moved {
+			removed, added = idx.old, idx.new
+		}
+		for _, t := range removed {
+			if err := tb.Delete(trigramKey(t, oldKey)); err != nil {
+				return err
+			}
+		}
+		for _, t := range added {
+			if err := tb.Put(trigramKey(t, key), encode(fid)); err != nil {
+				return err
+			}
+		}
+	}
+
+	if reflect.DeepEqual(e, indexEntry{}) {
+		return eb.Delete(encode(fid))
+	}
+	return marshal(eb, fid, e)
+}
+
+// updateSetCounts adjusts the sizes of the counted sets to a fragment
+// being added to and removed from the sets.
+func updateSetCounts(pb *bolt.Bucket, added, removed []string) error {
+	counts := make(map[string]int)
+	if data := pb.Get([]byte("counts")); data != nil {
+		if err := json.Unmarshal(data, &counts); err != nil {
+			return err
+		}
+	}
+	changed := false
+	for _, name := range added {
+		if countedSets[name] {
+			counts[name]++
+			changed = true
+		}
+	}
+	for _, name := range removed {
+		if countedSets[name] {
+			counts[name]--
+			changed = true
+		}
+	}
+	if !changed {
+		return nil
+	}
+	data, err := json.Marshal(counts)
+	if err != nil {
+		return err
+	}
+	return pb.Put([]byte("counts"), data)
+}
+
+// setCount returns the size of the named counted set of the book.
+func setCount(tx *bolt.Tx, bid uint64, name string) int {
+	pb := tx.Bucket([]byte("filter_index")).Bucket(encode(bid))
+	if pb == nil {
+		return 0
+	}
+	var counts map[string]int
+	if data := pb.Get([]byte("counts")); data != nil {
+		if err := json.Unmarshal(data, &counts); err != nil {
+			logError(err)
+		}
+	}
+	return counts[name]
+}
+
+// countFilterSets stores the sizes of the counted sets of every book,
+// for the filter indexes built before they were counted.
+func countFilterSets(tx *bolt.Tx) error {
+	var pbs []*bolt.Bucket
+	if err := tx.Bucket([]byte("filter_index")).ForEach(func(k, v []byte) error {
+		if v == nil {
+			pbs = append(pbs, tx.Bucket([]byte("filter_index")).Bucket(k))
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+	for _, pb := range pbs {
+		counts := make(map[string]int)
+		for name := range countedSets {
+			if b := pb.Bucket([]byte(name)); b != nil {
+				if err := b.ForEach(func(_, _ []byte) error {
+					counts[name]++
+					return nil
+				}); err != nil {
+					return err
+				}
+			}
+		}
+		data, err := json.Marshal(counts)
+		if err != nil {
+			return err
+		}
+		if err := pb.Put([]byte("counts"), data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// reindexFragment brings the filter index of the fragment up to date. It
+// must be called in the same transaction by every method which changes a
+// fragment or its versions.
+func reindexFragment(tx *bolt.Tx, bid uint64, fids ...uint64) error {
+	for _, fid := range fids {
+		e, err := computeIndexEntry(tx, bid, fid)
+		if err != nil {
+			return err
+		}
+		if err := setIndexEntry(tx, bid, fid, e); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// moveIndexEntry files the index entry of the fragment anew under its
+// current order key. It must be called when the order key changes.
+func moveIndexEntry(tx *bolt.Tx, bid, fid uint64) error {
+	pb := tx.Bucket([]byte("filter_index")).Bucket(encode(bid))
+	if pb == nil {
+		return nil
+	}
+	eb := pb.Bucket([]byte("fragments"))
+	if eb == nil {
+		return nil
+	}
+	var e indexEntry
+	if found, err := unmarshal(eb, fid, &e); err != nil || !found {
+		return err
+	}
+	key := tx.Bucket([]byte("positions")).Bucket(encode(bid)).Get(encode(fid))
+	if key == nil {
+		return nil
+	}
+	e.Key = binary.BigEndian.Uint64(key)
+	return setIndexEntry(tx, bid, fid, e)
+}
+
+// A keyIter walks the order keys of a set of fragments.
+type keyIter interface {
+	// seek returns the first key of the set which is not less than k, or
+	// nil if there is none.
+	seek(k []byte) []byte
+}
+
+// bucketIter walks the keys of a bucket; with no cursor, it is empty.
+type bucketIter struct {
+	c *bolt.Cursor
+}
+
+func (it bucketIter) seek(k []byte) []byte {
+	if it.c == nil {
+		return nil
+	}
+	key, _ := it.c.Seek(k)
+	return key
+}
+
+// trigramIter walks the fragments with a trigram.
+type trigramIter struct {
+	c      *bolt.Cursor
+	prefix []byte
+}
+
+func (it trigramIter) seek(k []byte) []byte {
+	key, _ := it.c.Seek(append(it.prefix[:len(it.prefix):len(it.prefix)], k...))
+	if !bytes.HasPrefix(key, it.prefix) {
+		return nil
+	}
+	return key[len(it.prefix):]
+}
+
+// setIter returns an iterator over the named filter set of the filter
+// index pb, which may be nil.
+func setIter(pb *bolt.Bucket, name string) keyIter {
+	if pb != nil {
+		if b := pb.Bucket([]byte(name)); b != nil {
+			return bucketIter{b.Cursor()}
+		}
+	}
+	return bucketIter{}
+}
+
+// trigramIters returns an iterator for every trigram of the query in the
+// named trigram index of pb, which may be nil. The fragments in all of
+// them may contain the query; the caller has to check them. ok is false if
+// the query has no words of three letters or more, so the index is of no
+// use.
+func trigramIters(pb *bolt.Bucket, name, query string) (iters []keyIter, ok bool) {
+	tt := trigrams(rWord.FindAllString(strings.ToLower(query), -1))
+	if len(tt) == 0 {
+		return nil, false
+	}
+	var tb *bolt.Bucket
+	if pb != nil {
+		tb = pb.Bucket([]byte(name))
+	}
+	if tb == nil {
+		return []keyIter{bucketIter{}}, true
+	}
+	for _, t := range tt {
+		iters = append(iters, trigramIter{tb.Cursor(), trigramKey(t, nil)})
+	}
+	return iters, true
+}
+
+// intersect returns the first key which is not less than k and is in all
+// the sets, or nil if there is none.
+func intersect(iters []keyIter, k []byte) []byte {
+	for i, agreed := 0, 0; agreed < len(iters); i = (i + 1) % len(iters) {
+		next := iters[i].seek(k)
+		if next == nil {
+			return nil
+		}
+		if bytes.Equal(next, k) {
+			agreed++
+		} else {
+			k, agreed = next, 1
+		}
+	}
+	return k
+}
+
+// nextKey returns the order key that follows k.
+func nextKey(k []byte) []byte {
+	return orderKey(binary.BigEndian.Uint64(k) + 1)
+}
+
+// indexedSet returns the fragments of the book which are in the named
+// filter set.
+func indexedSet(tx *bolt.Tx, bid uint64, name string) map[uint64]bool {
+	set := make(map[uint64]bool)
+	pb := tx.Bucket([]byte("filter_index")).Bucket(encode(bid))
+	if pb == nil {
+		return set
+	}
+	b := pb.Bucket([]byte(name))
+	if b == nil {
+		return set
+	}
+	c := b.Cursor()
+	for k, v := c.First(); k != nil; k, v = c.Next() {
+		set[decode(v)] = true
+	}
+	return set
+}
+
+// indexedCandidates returns the fragments which may contain the query
+// according to the named trigram index: they have all the trigrams of its
+// words. The caller has to check the candidates. ok is false if the index
+// is of no use for the query.
+func indexedCandidates(tx *bolt.Tx, bid uint64, name, query string) (candidates map[uint64]bool, ok bool) {
+	iters, ok := trigramIters(tx.Bucket([]byte("filter_index")).Bucket(encode(bid)), name, query)
+	if !ok {
+		return nil, false
+	}
+	candidates = make(map[uint64]bool)
+	ob := tx.Bucket([]byte("order")).Bucket(encode(bid))
+	if ob == nil {
+		return candidates, true
+	}
+	for k := intersect(iters, orderKey(0)); k != nil; k = intersect(iters, nextKey(k)) {
+		if v := ob.Get(k); v != nil {
+			candidates[decode(v)] = true
+		}
+	}
+	return candidates, true
+}
+
+// rebuildFilterIndex recreates the filter index of the book from scratch.
+func rebuildFilterIndex(tx *bolt.Tx, bid uint64, fids []uint64) error {
+	if err := tx.Bucket([]byte("filter_index")).DeleteBucket(encode(bid)); err != nil && err != bolt.ErrBucketNotFound {
+		return err
+	}
+	return reindexFragment(tx, bid, fids...)
+}
+
+// rebuildFilterIndexes recreates the filter index of every book, for the
+// indexes built before they were kept in the order of the books.
+func rebuildFilterIndexes(tx *bolt.Tx) error {
+	for _, name := range []string{"index", "trash"} {
+		books, err := listBooks(tx, name)
+		if err != nil {
+			return err
+		}
+		for _, book := range books {
+			if err := rebuildFilterIndex(tx, book.ID, fragmentIDs(tx, book.ID)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func buildFilterIndexes(tx *bolt.Tx) error {
+	if _, err := tx.CreateBucketIfNotExists([]byte("filter_index")); err != nil {
+		return err
+	}
+	for _, name := range []string{"index", "trash"} {
+		if err := tx.Bucket([]byte(name)).ForEach(func(_, v []byte) error {
+			var book Book
+			if err := json.Unmarshal(v, &book); err != nil {
+				return err
+			}
+			return rebuildFilterIndex(tx, book.ID, book.FragmentsIDs)
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestFilteredPages checks the pages of filtered fragments against a
+// filter applied to the whole book, with fragments inserted in the middle
+// so that the order keys are respaced.
+func TestFilteredPages(t *testing.T) {
+	db, err := OpenDatabase(filepath.Join(t.TempDir(), "tl.db"), 0600, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	var texts []string
+	for i := 1; i <= 600; i++ {
+		texts = append(texts, fmt.Sprintf("Fragment number %d", i))
+	}
+	bid, err := db.AddBook("Book", texts, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 30; i++ {
+		if _, err := db.AddFragment(bid, 300, fmt.Sprintf("Inserted number %d", i)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for fid := uint64(1); fid <= 630; fid += 7 {
+		if _, _, err := db.Translate(bid, fid, 0, "Перевод"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	all, err := db.BookWithTranslations(bid, 0, -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tc := range []struct {
+		filter bookFilter
+		match  func(f Fragment) bool
+	}{
+		{bookFilter{Kind: fUntranslated}, func(f Fragment) bool { return len(f.Versions) == 0 }},
+		{bookFilter{fOriginalContains, []string{"number 5"}}, func(f Fragment) bool { return strings.Contains(f.Text, "number 5") }},
+		{bookFilter{fTranslationContains, []string{"ревод"}}, func(f Fragment) bool { return len(f.Versions) > 0 }},
+	} {
+		var want []Fragment
+		for _, f := range all.Fragments {
+			if tc.match(f) {
+				want = append(want, f)
+			}
+		}
+		for from := 0; from < len(want); from += 50 {
+			book, err := db.BookWithTranslations(bid, from, 50, tc.filter)
+			if err != nil {
+				t.Fatal(err)
+			}
+			page := want[from:min(len(want), from+50)]
+			if len(book.Fragments) != len(page) {
+				t.Fatalf("%v from %d: got %d fragments, want %d", tc.filter, from, len(book.Fragments), len(page))
+			}
+			for i, f := range book.Fragments {
+				if f.ID != page[i].ID || f.SeqNum != page[i].SeqNum {
+					t.Fatalf("%v from %d: got fragment %d #%d, want %d #%d", tc.filter, from, f.ID, f.SeqNum, page[i].ID, page[i].SeqNum)
+				}
+			}
+			if more := from+50 < len(want); book.MoreFragments != more {
+				t.Errorf("%v from %d: MoreFragments is %v", tc.filter, from, book.MoreFragments)
+			}
+		}
+	}
+
+	if problems, err := db.Fsck(false); err != nil || len(problems) > 0 {
+		t.Fatal(problems, err)
+	}
+}
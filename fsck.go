@@ -0,0 +1,383 @@
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/boltdb/bolt"
+)
+
+// Fsck checks the consistency of the database and returns a description
+// of every problem found. If repair is true, the problems are fixed in the
+// same transaction.
+func (db *DB) Fsck(repair bool) ([]string, error) {
+	var problems []string
+	fn := func(tx *bolt.Tx) error {
+		problems = problems[:0]
+		report := func(format string, args ...interface{}) {
+			problems = append(problems, fmt.Sprintf(format, args...))
+		}
+		for _, name := range []string{"index", "trash"} {
+			if err := fsckBooks(tx, name, repair, report); err != nil {
+				return err
+			}
+		}
+		return fsckScratchpads(tx, repair, report)
+	}
+	var err error
+	if repair {
+		err = db.Update(fn)
+	} else {
+		err = db.View(fn)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return problems, nil
+}
+
+func decode(k []byte) uint64 {
+	return binary.LittleEndian.Uint64(k)
+}
+
+func fsckBooks(tx *bolt.Tx, name string, repair bool, report func(string, ...interface{})) error {
+	b := tx.Bucket([]byte(name))
+	sb := tx.Bucket([]byte("stats"))
+	var books []Book
+	wrongID := make(map[uint64]bool)
+	if err := b.ForEach(func(k, v []byte) error {
+		var book Book
+		if err := json.Unmarshal(v, &book.bookInfo); err != nil {
+			report("%s: book %d: cannot parse: %v", name, decode(k), err)
+			return nil
+		}
+		if book.ID != decode(k) {
+			report("%s: book %d: wrong ID %d", name, decode(k), book.ID)
+			book.ID = decode(k)
+			wrongID[book.ID] = true
+		}
+		if data := sb.Get(k); data != nil {
+			if err := json.Unmarshal(data, &book.bookStats); err != nil {
+				report("%s: book %d: cannot parse stats: %v", name, book.ID, err)
+			}
+		}
+		books = append(books, book)
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	for _, book := range books {
+		changed, err := fsckBook(tx, &book, repair, func(format string, args ...interface{}) {
+			report("%s: book %d: "+format, append([]interface{}{name, book.ID}, args...)...)
+		})
+		if err != nil {
+			return err
+		}
+		if !repair {
+			continue
+		}
+		if wrongID[book.ID] {
+			if err := marshal(b, book.ID, book.bookInfo); err != nil {
+				return err
+			}
+		}
+		if changed {
+			if err := putStats(tx, book.ID, book.bookStats); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func fsckBook(tx *bolt.Tx, book *Book, repair bool, report func(string, ...interface{})) (bool, error) {
+	changed := false
+	key := encode(book.ID)
+
+	var missing bool
+	for _, name := range []string{"fragments", "versions", "order", "positions"} {
+		if tx.Bucket([]byte(name)).Bucket(key) == nil {
+			report("missing %s bucket", name)
+			missing = true
+		}
+	}
+	if missing {
+		if !repair {
+			return false, nil
+		}
+		for _, name := range []string{"fragments", "versions", "order", "positions"} {
+			if _, err := tx.Bucket([]byte(name)).CreateBucketIfNotExists(key); err != nil {
+				return false, err
+			}
+		}
+	}
+	fb := tx.Bucket([]byte("fragments")).Bucket(key)
+	vb := tx.Bucket([]byte("versions")).Bucket(key)
+	ob := tx.Bucket([]byte("order")).Bucket(key)
+	pb := tx.Bucket([]byte("positions")).Bucket(key)
+
+	seen := make(map[uint64]bool)
+	usedVersions := make(map[uint64]bool)
+	var fids []uint64
+	orderChanged := false
+	translated := 0
+	// days collects the day counters of the translated fragments.
+	var days bookStats
+	blocks := make(map[uint64]uint64)
+	c := ob.Cursor()
+	for k, v := c.First(); k != nil; k, v = c.Next() {
+		blocks[binary.BigEndian.Uint64(k)>>orderBlockShift]++
+		fid := decode(v)
+		if seen[fid] {
+			report("fragment %d is listed more than once", fid)
+			orderChanged = true
+			continue
+		}
+		seen[fid] = true
+		if !bytes.Equal(pb.Get(v), k) {
+			report("fragment %d: wrong position", fid)
+			orderChanged = true
+		}
+
+		data := fb.Get(v)
+		if data == nil {
+			report("dangling fragment ID %d", fid)
+			orderChanged = true
+			continue
+		}
+		var f Fragment
+		if err := json.Unmarshal(data, &f); err != nil {
+			report("fragment %d: cannot parse: %v", fid, err)
+			orderChanged = true
+			if repair {
+				if err := fb.Delete(encode(fid)); err != nil {
+					return false, err
+				}
+			}
+			continue
+		}
+
+		fchanged := false
+		var first time.Time
+		vids := make([]uint64, 0, len(f.VersionsIDs))
+		for _, vid := range f.VersionsIDs {
+			if usedVersions[vid] {
+				report("fragment %d: version %d is listed more than once", fid, vid)
+				fchanged = true
+				continue
+			}
+			data := vb.Get(encode(vid))
+			if data == nil {
+				report("fragment %d: dangling version ID %d", fid, vid)
+				fchanged = true
+				continue
+			}
+			usedVersions[vid] = true
+			var v TranslationVersion
+			if err := json.Unmarshal(data, &v); err != nil {
+				report("fragment %d: version %d: cannot parse: %v", fid, vid, err)
+				fchanged = true
+				if repair {
+					if err := vb.Delete(encode(vid)); err != nil {
+						return false, err
+					}
+				}
+				continue
+			}
+			vids = append(vids, vid)
+			if first.IsZero() || v.Created.Before(first) {
+				first = v.Created
+			}
+		}
+		if fchanged && repair {
+			f.VersionsIDs = vids
+			if err := marshal(fb, fid, f); err != nil {
+				return false, err
+			}
+		}
+
+		fids = append(fids, fid)
+		if len(vids) > 0 {
+			translated++
+			if !first.IsZero() {
+				days.countTranslated(first, wordCount(f.Text), 1)
+			}
+		}
+	}
+	if err := pb.ForEach(func(k, v []byte) error {
+		if !seen[decode(k)] {
+			report("stale position of fragment %d", decode(k))
+			orderChanged = true
+		}
+		return nil
+	}); err != nil {
+		return false, err
+	}
+	stored := make(map[uint64]uint64)
+	if cb := tx.Bucket([]byte("order_counts")).Bucket(key); cb != nil {
+		if err := cb.ForEach(func(k, v []byte) error {
+			stored[binary.BigEndian.Uint64(k)] = decode(v)
+			return nil
+		}); err != nil {
+			return false, err
+		}
+	}
+	if !reflect.DeepEqual(blocks, stored) {
+		report("the order blocks are counted wrong")
+		orderChanged = true
+	}
+	if repair && orderChanged {
+		if err := setFragmentOrder(tx, book.ID, fids); err != nil {
+			return false, err
+		}
+	}
+
+	var orphans [][]byte
+	if err := fb.ForEach(func(k, _ []byte) error {
+		if !seen[decode(k)] {
+			report("orphaned fragment %d", decode(k))
+			orphans = append(orphans, k)
+		}
+		return nil
+	}); err != nil {
+		return false, err
+	}
+	if repair {
+		for _, k := range orphans {
+			if err := fb.Delete(k); err != nil {
+				return false, err
+			}
+		}
+	}
+
+	orphans = orphans[:0]
+	if err := vb.ForEach(func(k, _ []byte) error {
+		if !usedVersions[decode(k)] {
+			report("orphaned version %d", decode(k))
+			orphans = append(orphans, k)
+		}
+		return nil
+	}); err != nil {
+		return false, err
+	}
+	if repair {
+		for _, k := range orphans {
+			if err := removeVersions(tx, book.ID, decode(k)); err != nil {
+				return false, err
+			}
+		}
+	}
+
+	if err := fsckFilterIndex(tx, book.ID, fids, repair, report); err != nil {
+		return false, err
+	}
+
+	if book.FragmentsTotal != len(fids) {
+		report("fragments_total is %d, should be %d", book.FragmentsTotal, len(fids))
+		changed = true
+	}
+	if book.FragmentsTranslated != translated {
+		report("fragments_translated is %d, should be %d", book.FragmentsTranslated, translated)
+		changed = true
+	}
+
+	if !reflect.DeepEqual(book.Days, days.Days) {
+		report("the day counters are wrong")
+		changed = true
+	}
+
+	book.FragmentsIDs = fids
+	book.FragmentsTotal = len(fids)
+	book.FragmentsTranslated = translated
+	book.Days = days.Days
+
+	return changed, nil
+}
+
+func fsckFilterIndex(tx *bolt.Tx, bid uint64, fids []uint64, repair bool, report func(string, ...interface{})) error {
+	var eb *bolt.Bucket
+	if pb := tx.Bucket([]byte("filter_index")).Bucket(encode(bid)); pb != nil {
+		eb = pb.Bucket([]byte("fragments"))
+	}
+	stored := make(map[uint64]bool)
+	if eb != nil {
+		if err := eb.ForEach(func(k, _ []byte) error {
+			stored[decode(k)] = true
+			return nil
+		}); err != nil {
+			return err
+		}
+	}
+
+	outdated := false
+	for _, fid := range fids {
+		e, err := computeIndexEntry(tx, bid, fid)
+		if err != nil {
+			return err
+		}
+		var old indexEntry
+		if eb != nil {
+			if _, err := unmarshal(eb, fid, &old); err != nil {
+				report("fragment %d: cannot parse the filter index entry: %v", fid, err)
+				outdated = true
+				continue
+			}
+		}
+		if !reflect.DeepEqual(e, old) {
+			report("fragment %d: filter index is out of date", fid)
+			outdated = true
+		}
+		delete(stored, fid)
+	}
+	for fid := range stored {
+		report("filter index entry for nonexistent fragment %d", fid)
+		outdated = true
+	}
+
+	if repair && outdated {
+		return rebuildFilterIndex(tx, bid, fids)
+	}
+	return nil
+}
+
+func fsckScratchpads(tx *bolt.Tx, repair bool, report func(string, ...interface{})) error {
+	b := tx.Bucket([]byte("scratchpad"))
+	var bad [][]byte
+	if err := b.ForEach(func(k, v []byte) error {
+		var sp Scratchpad
+		if err := json.Unmarshal(v, &sp); err != nil {
+			report("scratchpad %d: cannot parse: %v", decode(k), err)
+			bad = append(bad, k)
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+	if repair {
+		for _, k := range bad {
+			if err := b.Delete(k); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
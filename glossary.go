@@ -0,0 +1,401 @@
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"html"
+	"html/template"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/boltdb/bolt"
+	"github.com/gorilla/mux"
+)
+
+// Term is an entry of the glossary of a book.
+type Term struct {
+	ID           uint64    `json:"id"`
+	Created      time.Time `json:"created"`
+	Updated      time.Time `json:"updated"`
+	Source       string    `json:"source"`
+	Target       string    `json:"target"`
+	Notes        string    `json:"notes"`
+	PartOfSpeech string    `json:"pos"`
+}
+
+func sortTerms(terms []Term) {
+	sort.SliceStable(terms, func(i, j int) bool {
+		return strings.ToLower(terms[i].Source) < strings.ToLower(terms[j].Source)
+	})
+}
+
+// glossaryTerms returns the glossary of the book sorted by the source term.
+func glossaryTerms(tx *bolt.Tx, bid uint64) ([]Term, error) {
+	terms := []Term{}
+	gb := tx.Bucket([]byte("glossary")).Bucket(encode(bid))
+	if gb == nil {
+		return terms, nil
+	}
+	if err := gb.ForEach(func(_, v []byte) error {
+		var t Term
+		if err := json.Unmarshal(v, &t); err != nil {
+			return err
+		}
+		terms = append(terms, t)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	sortTerms(terms)
+	return terms, nil
+}
+
+func (db *DB) Glossary(bid uint64) (Book, []Term, error) {
+	var book Book
+	var terms []Term
+	err := db.View(func(tx *bolt.Tx) error {
+		var err error
+		book, err = indexedBook(tx, bid)
+		if err != nil {
+			return err
+		}
+		terms, err = glossaryTerms(tx, bid)
+		return err
+	})
+	if err != nil {
+		return Book{}, nil, err
+	}
+	return book, terms, nil
+}
+
+func (db *DB) AddTerm(bid uint64, t Term) (Term, error) {
+	err := db.Update(func(tx *bolt.Tx) error {
+		if _, err := indexedBook(tx, bid); err != nil {
+			return err
+		}
+		gb, err := tx.Bucket([]byte("glossary")).CreateBucketIfNotExists(encode(bid))
+		if err != nil {
+			return err
+		}
+		t.ID, _ = gb.NextSequence()
+		t.Created = time.Now()
+		t.Updated = t.Created
+		return marshal(gb, t.ID, t)
+	})
+	if err != nil {
+		return Term{}, err
+	}
+	return t, nil
+}
+
+func (db *DB) UpdateTerm(bid uint64, t Term) (Term, error) {
+	err := db.Update(func(tx *bolt.Tx) error {
+		if _, err := indexedBook(tx, bid); err != nil {
+			return err
+		}
+		gb := tx.Bucket([]byte("glossary")).Bucket(encode(bid))
+		if gb == nil {
+			return ErrNotFound
+		}
+		var old Term
+		if found, err := unmarshal(gb, t.ID, &old); err != nil {
+			return err
+		} else if !found {
+			return ErrNotFound
+		}
+		t.Created = old.Created
+		t.Updated = time.Now()
+		return marshal(gb, t.ID, t)
+	})
+	if err != nil {
+		return Term{}, err
+	}
+	return t, nil
+}
+
+func (db *DB) RemoveTerm(bid, tid uint64) error {
+	return db.Update(func(tx *bolt.Tx) error {
+		if _, err := indexedBook(tx, bid); err != nil {
+			return err
+		}
+		gb := tx.Bucket([]byte("glossary")).Bucket(encode(bid))
+		if gb == nil || gb.Get(encode(tid)) == nil {
+			return ErrNotFound
+		}
+		return gb.Delete(encode(tid))
+	})
+}
+
+// termSpan is an occurrence of a glossary term in a text.
+type termSpan struct {
+	from, to int
+	term     *Term
+}
+
+// glossaryMatcher finds glossary terms in texts. Terms are matched as
+// whole words, ignoring the case and the amount of whitespace between the
+// words of a term.
+type glossaryMatcher struct {
+	terms []Term
+	res   []*regexp.Regexp
+}
+
+func newGlossaryMatcher(terms []Term) *glossaryMatcher {
+	m := &glossaryMatcher{}
+	for _, t := range terms {
+		if strings.TrimSpace(t.Source) != "" {
+			m.terms = append(m.terms, t)
+		}
+	}
+	// Longer terms win over the shorter ones they overlap.
+	sort.SliceStable(m.terms, func(i, j int) bool {
+		return utf8.RuneCountInString(m.terms[i].Source) > utf8.RuneCountInString(m.terms[j].Source)
+	})
+	m.res = make([]*regexp.Regexp, len(m.terms))
+	for i, t := range m.terms {
+		words := strings.Fields(t.Source)
+		for j, w := range words {
+			words[j] = regexp.QuoteMeta(w)
+		}
+		m.res[i] = regexp.MustCompile(`(?i)` + strings.Join(words, `\s+`))
+	}
+	return m
+}
+
+func isWordRune(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// atWordBoundaries reports whether text[from:to] is neither preceded nor
+// followed by a letter or a digit.
+func atWordBoundaries(text string, from, to int) bool {
+	if r, _ := utf8.DecodeLastRuneInString(text[:from]); from > 0 && isWordRune(r) {
+		return false
+	}
+	if r, _ := utf8.DecodeRuneInString(text[to:]); to < len(text) && isWordRune(r) {
+		return false
+	}
+	return true
+}
+
+// find returns the non-overlapping occurrences of the terms in the text in
+// the order they appear.
+func (m *glossaryMatcher) find(text string) []termSpan {
+	var spans []termSpan
+	for i, re := range m.res {
+	next:
+		for _, idx := range re.FindAllStringIndex(text, -1) {
+			from, to := idx[0], idx[1]
+			if !atWordBoundaries(text, from, to) {
+				continue
+			}
+			for _, s := range spans {
+				if from < s.to && s.from < to {
+					continue next
+				}
+			}
+			spans = append(spans, termSpan{from, to, &m.terms[i]})
+		}
+	}
+	sort.Slice(spans, func(i, j int) bool { return spans[i].from < spans[j].from })
+	return spans
+}
+
+// termsIn returns the terms which occur in the text.
+func (m *glossaryMatcher) termsIn(text string) []Term {
+	var terms []Term
+	seen := make(map[uint64]bool)
+	for _, s := range m.find(text) {
+		if !seen[s.term.ID] {
+			seen[s.term.ID] = true
+			terms = append(terms, *s.term)
+		}
+	}
+	sortTerms(terms)
+	return terms
+}
+
+// attachTerms sets the Terms of the fragments to the glossary terms found
+// in their originals.
+func attachTerms(fragments []Fragment, terms []Term) {
+	if len(terms) == 0 {
+		return
+	}
+	m := newGlossaryMatcher(terms)
+	for i := range fragments {
+		fragments[i].Terms = m.termsIn(fragments[i].Text)
+	}
+}
+
+func (t Term) tooltip() string {
+	s := t.Target
+	if t.PartOfSpeech != "" {
+		s += " (" + t.PartOfSpeech + ")"
+	}
+	if t.Notes != "" {
+		s += "\n" + t.Notes
+	}
+	return s
+}
+
+// renderTerms is render which marks the glossary terms.
+func renderTerms(s string, terms []Term) template.HTML {
+	if len(terms) == 0 {
+		return render(s)
+	}
+	var buf bytes.Buffer
+	i := 0
+	for _, span := range newGlossaryMatcher(terms).find(s) {
+		buf.WriteString(string(render(s[i:span.from])))
+		buf.WriteString(`<span class="term" title="` + html.EscapeString(span.term.tooltip()) + `">`)
+		buf.WriteString(string(render(s[span.from:span.to])))
+		buf.WriteString("</span>")
+		i = span.to
+	}
+	buf.WriteString(string(render(s[i:])))
+	return template.HTML(buf.String())
+}
+
+// fragmentTerms returns the glossary terms of the book which occur in the
+// text.
+func (a *App) fragmentTerms(bid uint64, text string) ([]Term, error) {
+	_, terms, err := a.db.Glossary(bid)
+	if err != nil {
+		return nil, err
+	}
+	return newGlossaryMatcher(terms).termsIn(text), nil
+}
+
+func termFromRequest(r *http.Request) Term {
+	return Term{
+		Source:       strings.TrimSpace(r.FormValue("source")),
+		Target:       strings.TrimSpace(r.FormValue("target")),
+		Notes:        strings.TrimSpace(r.FormValue("notes")),
+		PartOfSpeech: strings.TrimSpace(r.FormValue("pos")),
+	}
+}
+
+func (a *App) Glossary(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	bid, err := u64(vars["book_id"])
+	if err != nil {
+		http.Error(w, "Invalid book ID", http.StatusBadRequest)
+		return
+	}
+
+	switch r.Method {
+	case "GET":
+		book, terms, err := a.db.Glossary(bid)
+		if err != nil {
+			if err == ErrNotFound {
+				http.NotFound(w, r)
+				return
+			}
+			internalError(w, err)
+			return
+		}
+
+		if r.FormValue("f") == "json" {
+			w.Header().Set("Content-Type", "application/json")
+			if err := json.NewEncoder(w).Encode(terms); err != nil {
+				logError(err)
+			}
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/html")
+		if err := glossaryTmpl.Execute(w, struct {
+			Book  Book
+			URL   string
+			Terms []Term
+		}{
+			book,
+			r.FormValue("url"),
+			terms,
+		}); err != nil {
+			logError(err)
+		}
+
+	case "POST":
+		t := termFromRequest(r)
+		if t.Source == "" {
+			http.Error(w, "Term must not be empty!", http.StatusBadRequest)
+			return
+		}
+		t, err := a.db.AddTerm(bid, t)
+		if err != nil {
+			if err == ErrNotFound {
+				http.Error(w, "Book not found", 404)
+				return
+			}
+			internalError(w, err)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(t)
+	}
+}
+
+func (a *App) Term(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	bid, err := u64(vars["book_id"])
+	if err != nil {
+		http.Error(w, "Invalid book ID", http.StatusBadRequest)
+		return
+	}
+	tid, err := u64(vars["term_id"])
+	if err != nil {
+		http.Error(w, "Invalid term ID", http.StatusBadRequest)
+		return
+	}
+
+	switch r.Method {
+	case "POST":
+		t := termFromRequest(r)
+		if t.Source == "" {
+			http.Error(w, "Term must not be empty!", http.StatusBadRequest)
+			return
+		}
+		t.ID = tid
+		t, err := a.db.UpdateTerm(bid, t)
+		if err != nil {
+			if err == ErrNotFound {
+				http.Error(w, "Term not found", 404)
+				return
+			}
+			internalError(w, err)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(t)
+
+	case "DELETE":
+		if err := a.db.RemoveTerm(bid, tid); err != nil {
+			if err == ErrNotFound {
+				http.Error(w, "Term not found", 404)
+				return
+			}
+			internalError(w, err)
+		}
+	}
+}
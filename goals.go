@@ -0,0 +1,269 @@
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/boltdb/bolt"
+	"github.com/gorilla/mux"
+)
+
+// heatmapWeeks is the number of weeks in the calendar heatmap of a goal.
+const heatmapWeeks = 53
+
+// DailyGoal is the amount of work to do on a book every day.
+type DailyGoal struct {
+	Amount int `json:"amount"`
+	// Unit is "words" (of the original) or "fragments".
+	Unit string `json:"unit"`
+}
+
+func validGoalUnit(unit string) bool {
+	return unit == "words" || unit == "fragments"
+}
+
+// of returns the amount of the work of a day in the units of the goal.
+func (g DailyGoal) of(c dayCount) int {
+	if g.Unit == "fragments" {
+		return c.Fragments
+	}
+	return c.Words
+}
+
+// dayCount is the work done on a book in a day: the fragments translated
+// for the first time and the words of their originals.
+type dayCount struct {
+	Fragments int `json:"fragments"`
+	Words     int `json:"words"`
+}
+
+// countTranslated adds n fragments with the given number of words,
+// translated for the first time at t, to the day counters. n is -1 to take
+// a fragment back.
+func (st *bookStats) countTranslated(t time.Time, words, n int) {
+	d := day(t)
+	c := st.Days[d]
+	c.Fragments += n
+	c.Words += n * words
+	if c.Fragments <= 0 {
+		delete(st.Days, d)
+		return
+	}
+	if st.Days == nil {
+		st.Days = make(map[string]dayCount)
+	}
+	st.Days[d] = c
+}
+
+// heatDay is a day of the calendar heatmap.
+type heatDay struct {
+	Date   string
+	Amount int
+	// Level is 0 for the days without any work, 1 and 2 for less than a
+	// half and less than the goal, 3 and 4 for the goal and twice as much.
+	Level  int
+	Future bool
+}
+
+type goalProgress struct {
+	DailyGoal
+	Today int
+	// Streak is the number of the days in a row the goal was met, up to
+	// today or, if it isn't met yet today, up to yesterday.
+	Streak  int
+	Heatmap [][]heatDay
+}
+
+func (p goalProgress) Met() bool { return p.Today >= p.Amount }
+
+func (p goalProgress) Percent() int { return pct(min(p.Today, p.Amount), p.Amount) }
+
+// computeGoalProgress returns the progress toward the goal given the day
+// counters of the book.
+func computeGoalProgress(g DailyGoal, days map[string]dayCount, now time.Time) goalProgress {
+	today := day(now)
+	p := goalProgress{DailyGoal: g, Today: g.of(days[today])}
+
+	d := now
+	if !p.Met() {
+		d = d.AddDate(0, 0, -1)
+	}
+	for g.of(days[day(d)]) >= g.Amount {
+		p.Streak++
+		d = d.AddDate(0, 0, -1)
+	}
+
+	monday, _ := time.ParseInLocation(dateLayout, weekStart(today), time.Local)
+	start := monday.AddDate(0, 0, -7*(heatmapWeeks-1))
+	for w := 0; w < heatmapWeeks; w++ {
+		week := make([]heatDay, 7)
+		for i := range week {
+			date := start.AddDate(0, 0, 7*w+i).Format(dateLayout)
+			amount := g.of(days[date])
+			level := 0
+			switch {
+			case amount == 0:
+			case 2*amount < g.Amount:
+				level = 1
+			case amount < g.Amount:
+				level = 2
+			case amount < 2*g.Amount:
+				level = 3
+			default:
+				level = 4
+			}
+			week[i] = heatDay{date, amount, level, date > today}
+		}
+		p.Heatmap = append(p.Heatmap, week)
+	}
+	return p
+}
+
+// goalProgress returns the progress toward the daily goal of the book, or
+// nil if the book has no goal.
+func (a *App) goalProgress(book Book) (*goalProgress, error) {
+	if book.Goal == nil {
+		return nil, nil
+	}
+	days, err := a.db.TranslatedDays(book.ID)
+	if err != nil {
+		return nil, err
+	}
+	p := computeGoalProgress(*book.Goal, days, time.Now())
+	return &p, nil
+}
+
+// TranslatedDays returns the day counters of the book.
+func (db *DB) TranslatedDays(bid uint64) (map[string]dayCount, error) {
+	var book Book
+	err := db.View(func(tx *bolt.Tx) error {
+		var err error
+		book, err = indexedBook(tx, bid)
+		return err
+	})
+	return book.Days, err
+}
+
+// firstVersionTime returns the time the first of the versions was created,
+// or the zero time if there are none.
+func firstVersionTime(vb *bolt.Bucket, vids []uint64) (time.Time, error) {
+	var first time.Time
+	for _, vid := range vids {
+		var v struct {
+			Created time.Time `json:"created"`
+		}
+		if found, err := unmarshal(vb, vid, &v); err != nil {
+			return time.Time{}, err
+		} else if found && (first.IsZero() || v.Created.Before(first)) {
+			first = v.Created
+		}
+	}
+	return first, nil
+}
+
+// translatedDays counts the fragments of the book by the day of their first
+// version. It reads the whole book, so it is only used to fill the day
+// counters of the books which don't have them.
+func translatedDays(tx *bolt.Tx, bid uint64) (map[string]dayCount, error) {
+	var st bookStats
+	fb := tx.Bucket([]byte("fragments")).Bucket(encode(bid))
+	vb := tx.Bucket([]byte("versions")).Bucket(encode(bid))
+	if fb == nil || vb == nil {
+		return nil, nil
+	}
+	err := fb.ForEach(func(_, data []byte) error {
+		var f Fragment
+		if err := json.Unmarshal(data, &f); err != nil {
+			return err
+		}
+		first, err := firstVersionTime(vb, f.VersionsIDs)
+		if err != nil {
+			return err
+		}
+		if !first.IsZero() {
+			st.countTranslated(first, wordCount(f.Text), 1)
+		}
+		return nil
+	})
+	return st.Days, err
+}
+
+// countTranslatedDays fills the day counters of all the books.
+func countTranslatedDays(tx *bolt.Tx) error {
+	for _, name := range []string{"index", "trash"} {
+		var bids []uint64
+		if err := tx.Bucket([]byte(name)).ForEach(func(k, _ []byte) error {
+			bids = append(bids, decode(k))
+			return nil
+		}); err != nil {
+			return err
+		}
+		for _, bid := range bids {
+			days, err := translatedDays(tx, bid)
+			if err != nil {
+				return err
+			}
+			if _, err := updateStats(tx, bid, func(st *bookStats) { st.Days = days }); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (db *DB) SetDailyGoal(bid uint64, g *DailyGoal) error {
+	return db.updateBookInfo(bid, func(book *bookInfo) { book.Goal = g })
+}
+
+func (a *App) DailyGoal(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	bid, err := u64(vars["book_id"])
+	if err != nil {
+		http.Error(w, "Invalid book ID", http.StatusBadRequest)
+		return
+	}
+
+	var g *DailyGoal
+	if r.Method == "POST" {
+		amount, err := strconv.Atoi(r.FormValue("amount"))
+		if err != nil || amount < 0 {
+			http.Error(w, "Invalid amount", http.StatusBadRequest)
+			return
+		}
+		unit := r.FormValue("unit")
+		if !validGoalUnit(unit) {
+			http.Error(w, "Invalid unit", http.StatusBadRequest)
+			return
+		}
+		if amount > 0 {
+			g = &DailyGoal{amount, unit}
+		}
+	}
+
+	if err := a.db.SetDailyGoal(bid, g); err != nil {
+		if err == ErrNotFound {
+			http.Error(w, "Book not found", 404)
+			return
+		}
+		internalError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(g)
+}
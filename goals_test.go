@@ -0,0 +1,82 @@
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestTranslatedDays(t *testing.T) {
+	for _, db := range openTestStorages(t) {
+		bid, err := db.AddTranslatedBook("Book", [][]string{
+			{"one two", "раз два"},
+			{"three", ""},
+			{"four five six", ""},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		book, err := db.BookByID(bid)
+		if err != nil {
+			t.Fatal(err)
+		}
+		fids := book.FragmentsIDs
+		check := func(step string, fragments, words int) {
+			t.Helper()
+			days, err := db.TranslatedDays(bid)
+			if err != nil {
+				t.Fatal(err)
+			}
+			want := map[string]dayCount{day(time.Now()): {fragments, words}}
+			if fragments == 0 {
+				want = nil
+			}
+			if len(days) != len(want) || len(want) > 0 && !reflect.DeepEqual(days, want) {
+				t.Errorf("%T: %s: got %v, want %v", db, step, days, want)
+			}
+		}
+		check("add", 1, 2)
+
+		v1, _, err := db.Translate(bid, fids[1], 0, "три")
+		if err != nil {
+			t.Fatal(err)
+		}
+		v2, _, err := db.Translate(bid, fids[1], 0, "третий")
+		if err != nil {
+			t.Fatal(err)
+		}
+		check("translate", 2, 3)
+
+		if err := db.UpdateFragment(bid, fids[0], "one two three"); err != nil {
+			t.Fatal(err)
+		}
+		check("edit the original", 2, 4)
+
+		if _, err := db.RemoveVersion(bid, fids[1], v1.ID); err != nil {
+			t.Fatal(err)
+		}
+		check("remove the first version", 2, 4)
+		if _, err := db.RemoveVersion(bid, fids[1], v2.ID); err != nil {
+			t.Fatal(err)
+		}
+		check("remove the last version", 1, 3)
+
+		if _, err := db.RemoveFragment(bid, fids[0]); err != nil {
+			t.Fatal(err)
+		}
+		check("remove the fragment", 0, 0)
+	}
+}
@@ -0,0 +1,332 @@
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"encoding/json"
+	"html/template"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/boltdb/bolt"
+	"github.com/gorilla/mux"
+)
+
+type Revision struct {
+	ID      uint64    `json:"id"`
+	Created time.Time `json:"created"`
+	Text    string    `json:"text"`
+}
+
+// appendRevision records the new text of a version (or of a fragment's
+// original) in the named history bucket. The previous text of an object
+// which has no history yet (it was created before the history was kept)
+// is recorded first, so that it isn't lost.
+func appendRevision(tx *bolt.Tx, name string, bid, id uint64, prev *Revision, now time.Time, text string) error {
+	pb, err := tx.Bucket([]byte(name)).CreateBucketIfNotExists(encode(bid))
+	if err != nil {
+		return err
+	}
+	hb, err := pb.CreateBucketIfNotExists(encode(id))
+	if err != nil {
+		return err
+	}
+
+	if hb.Sequence() == 0 && prev != nil {
+		rid, _ := hb.NextSequence()
+		if err := marshal(hb, rid, Revision{
+			ID:      rid,
+			Created: prev.Created,
+			Text:    prev.Text,
+		}); err != nil {
+			return err
+		}
+	}
+	if last := hb.Sequence(); last != 0 {
+		var r Revision
+		if _, err := unmarshal(hb, last, &r); err != nil {
+			return err
+		}
+		if r.Text == text {
+			return nil
+		}
+	}
+
+	rid, _ := hb.NextSequence()
+	return marshal(hb, rid, Revision{
+		ID:      rid,
+		Created: now,
+		Text:    text,
+	})
+}
+
+// revisions returns the history of an object from the named history bucket
+// sorted from the oldest to the newest revision, or nil if there is none.
+func revisions(tx *bolt.Tx, name string, bid, id uint64) ([]Revision, error) {
+	pb := tx.Bucket([]byte(name)).Bucket(encode(bid))
+	if pb == nil {
+		return nil, nil
+	}
+	hb := pb.Bucket(encode(id))
+	if hb == nil {
+		return nil, nil
+	}
+	var result []Revision
+	if err := hb.ForEach(func(_, v []byte) error {
+		var r Revision
+		if err := json.Unmarshal(v, &r); err != nil {
+			return err
+		}
+		result = append(result, r)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].ID < result[j].ID
+	})
+	return result, nil
+}
+
+func (db *DB) History(bid, fid, vid uint64) ([]Revision, error) {
+	var revs []Revision
+	err := db.View(func(tx *bolt.Tx) error {
+		if _, err := indexedBook(tx, bid); err != nil {
+			return err
+		}
+		if !hasFragment(tx, bid, fid) {
+			return ErrNotFound
+		}
+
+		fb := tx.Bucket([]byte("fragments")).Bucket(encode(bid))
+		var f Fragment
+		if found, err := unmarshal(fb, fid, &f); err != nil {
+			return err
+		} else if !found {
+			return ErrNotFound
+		}
+		if !has(f.VersionsIDs, vid) {
+			return ErrNotFound
+		}
+
+		var err error
+		revs, err = revisions(tx, "history", bid, vid)
+		if err != nil || len(revs) > 0 {
+			return err
+		}
+
+		vb := tx.Bucket([]byte("versions")).Bucket(encode(bid))
+		var v TranslationVersion
+		if found, err := unmarshal(vb, vid, &v); err != nil {
+			return err
+		} else if !found {
+			return ErrNotFound
+		}
+		revs = []Revision{{
+			ID:      1,
+			Created: v.Updated,
+			Text:    v.Text,
+		}}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return revs, nil
+}
+
+// SourceHistory returns the revisions of the fragment's original.
+func (db *DB) SourceHistory(bid, fid uint64) ([]Revision, error) {
+	var revs []Revision
+	err := db.View(func(tx *bolt.Tx) error {
+		if _, err := indexedBook(tx, bid); err != nil {
+			return err
+		}
+		if !hasFragment(tx, bid, fid) {
+			return ErrNotFound
+		}
+
+		var err error
+		revs, err = revisions(tx, "source_history", bid, fid)
+		if err != nil || len(revs) > 0 {
+			return err
+		}
+
+		fb := tx.Bucket([]byte("fragments")).Bucket(encode(bid))
+		var f Fragment
+		if found, err := unmarshal(fb, fid, &f); err != nil {
+			return err
+		} else if !found {
+			return ErrNotFound
+		}
+		revs = []Revision{{
+			ID:      1,
+			Created: f.Created,
+			Text:    f.Text,
+		}}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return revs, nil
+}
+
+// RevertVersion sets the text of the version to the text of one of its
+// earlier revisions. The revert itself is recorded as a new revision.
+func RevertVersion(db Storage, bid, fid, vid, rid uint64) (TranslationVersion, int, error) {
+	revs, err := db.History(bid, fid, vid)
+	if err != nil {
+		return TranslationVersion{}, 0, err
+	}
+	for _, r := range revs {
+		if r.ID == rid {
+			return db.Translate(bid, fid, vid, r.Text)
+		}
+	}
+	return TranslationVersion{}, 0, ErrNotFound
+}
+
+func (a *App) VersionHistory(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	bid, err := u64(vars["book_id"])
+	if err != nil {
+		http.Error(w, "Invalid book ID", http.StatusBadRequest)
+		return
+	}
+	fid, err := u64(vars["fragment_id"])
+	if err != nil {
+		http.Error(w, "Invalid fragment ID", http.StatusBadRequest)
+		return
+	}
+	vid, err := u64(vars["version_id"])
+	if err != nil {
+		http.Error(w, "Invalid version ID", http.StatusBadRequest)
+		return
+	}
+
+	switch r.Method {
+	case "GET":
+		revs, err := a.db.History(bid, fid, vid)
+		if err != nil {
+			if err == ErrNotFound {
+				http.Error(w, "Version not found", 404)
+				return
+			}
+			internalError(w, err)
+			return
+		}
+
+		type revision struct {
+			Revision
+			HTML template.HTML `json:"html"`
+		}
+		result := make([]revision, len(revs))
+		for i, r := range revs {
+			result[i] = revision{r, render(r.Text)}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(result)
+
+	case "POST":
+		rid, err := u64(r.FormValue("revision_id"))
+		if err != nil {
+			http.Error(w, "Invalid revision ID", http.StatusBadRequest)
+			return
+		}
+
+		v, fragmentsTranslated, err := RevertVersion(a.db, bid, fid, vid, rid)
+		if err != nil {
+			if err == ErrNotFound {
+				http.Error(w, "Version or revision not found", 404)
+				return
+			}
+			internalError(w, err)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(struct {
+			TranslationVersion
+			ID                  uint64        `json:"id"`
+			Text                template.HTML `json:"text"`
+			FragmentsTranslated int           `json:"fragments_translated"`
+		}{
+			v,
+			v.ID,
+			render(v.Text),
+			fragmentsTranslated,
+		})
+	}
+}
+
+func (a *App) SourceHistory(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	bid, err := u64(vars["book_id"])
+	if err != nil {
+		http.Error(w, "Invalid book ID", http.StatusBadRequest)
+		return
+	}
+	fid, err := u64(vars["fragment_id"])
+	if err != nil {
+		http.Error(w, "Invalid fragment ID", http.StatusBadRequest)
+		return
+	}
+
+	revs, err := a.db.SourceHistory(bid, fid)
+	if err != nil {
+		if err == ErrNotFound {
+			http.Error(w, "Fragment not found", 404)
+			return
+		}
+		internalError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	if r.FormValue("from") != "" || r.FormValue("to") != "" {
+		from, err1 := strconv.Atoi(r.FormValue("from"))
+		to, err2 := strconv.Atoi(r.FormValue("to"))
+		if err1 != nil || err2 != nil || from < 1 || to < 1 || from > len(revs) || to > len(revs) {
+			http.Error(w, "Invalid revision ID", http.StatusBadRequest)
+			return
+		}
+		json.NewEncoder(w).Encode(struct {
+			Diff template.HTML `json:"diff"`
+		}{
+			renderDiff(revs[from-1].Text, revs[to-1].Text),
+		})
+		return
+	}
+
+	type revision struct {
+		Revision
+		HTML template.HTML `json:"html"`
+		Diff template.HTML `json:"diff"`
+	}
+	result := make([]revision, len(revs))
+	for i, r := range revs {
+		result[i] = revision{Revision: r, HTML: render(r.Text)}
+		if i > 0 {
+			result[i].Diff = renderDiff(revs[i-1].Text, r.Text)
+		}
+	}
+	json.NewEncoder(w).Encode(result)
+}
@@ -25,14 +25,14 @@ import (
 	"strconv"
 	"strings"
 
-	"github.com/boltdb/bolt"
 	"github.com/gorilla/mux"
 	"github.com/gorilla/securecookie"
 	"github.com/gorilla/sessions"
 )
 
 type App struct {
-	db DB
+	db         Storage
+	backupName string
 }
 
 var (
@@ -86,8 +86,73 @@ func (a *App) Index(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	sortBy := ""
+	if c, err := r.Cookie("sort"); err == nil {
+		sortBy = c.Value
+	}
+	sortBooks(books, sortBy)
+
+	// The archived books are only shown on a page of their own; the
+	// collections are subsets of the rest.
+	coll := r.FormValue("collection")
+	archived := r.FormValue("archived") == "1"
+	var active, shown []Book
+	archivedCount := 0
+	for _, b := range books {
+		if b.Archived {
+			archivedCount++
+		} else {
+			active = append(active, b)
+		}
+		if b.Archived == archived && (coll == "" || b.Collection == coll) {
+			shown = append(shown, b)
+		}
+	}
+
+	goals := make(map[uint64]*goalProgress)
+	for _, b := range shown {
+		p, err := a.goalProgress(b)
+		if err != nil {
+			internalError(w, err)
+			return
+		}
+		if p != nil {
+			goals[b.ID] = p
+		}
+	}
+
+	filter := bookIndexFilterFromRequest(r)
+	group := r.FormValue("group")
 	w.Header().Set("Content-Type", "text/html")
-	if err := indexTmpl.Execute(w, books); err != nil {
+	if err := indexTmpl.Execute(w, struct {
+		Books         []Book
+		Shown         []Book
+		Groups        []bookGroup
+		Filter        bookIndexFilter
+		Facets        bookFacets
+		Group         string
+		Collections   []collection
+		Collection    string
+		Archived      bool
+		ArchivedCount int
+		Sort          string
+		SortOrders    []sortOrder
+		Goals         map[uint64]*goalProgress
+	}{
+		books,
+		shown,
+		groupBooks(filterBooks(shown, filter), group),
+		filter,
+		facets(shown),
+		group,
+		collections(active),
+		coll,
+		archived,
+		archivedCount,
+		sortBy,
+		bookSortOrders,
+		goals,
+	}); err != nil {
 		logError(err)
 	}
 }
@@ -135,41 +200,15 @@ func (a *App) Book(w http.ResponseWriter, r *http.Request) {
 		const size = 50
 		off := (page - 1) * size
 
-		var book Book
-		var err error
-		switch r.FormValue("f") {
-		case "u":
-			book, err = a.db.BookWithTranslations(bid, off, size, fUntranslated)
-		case "c":
-			book, err = a.db.BookWithTranslations(bid, off, size, fCommented)
-		case "s":
-			book, err = a.db.BookWithTranslations(bid, off, size, fStarred)
-		case "2":
-			book, err = a.db.BookWithTranslations(bid, off, size, fWithTwoOrMoreVersions)
-		case "o":
-			what := r.FormValue("to")
-			if what == "" {
-				http.Redirect(w, r, r.URL.Path, http.StatusFound)
-				return
-
-			}
-			book, err = a.db.BookWithTranslations(bid, off, size, fOriginalContains, what)
-		case "t":
-			what := r.FormValue("tt")
-			if what == "" {
-				http.Redirect(w, r, r.URL.Path, http.StatusFound)
-				return
-
-			}
-			book, err = a.db.BookWithTranslations(bid, off, size, fTranslationContains, what)
-		case "l":
-			book, err = a.db.BookWithTranslations(bid, off, size, fOriginalLength, r.FormValue("comp"), r.FormValue("n"), r.FormValue("unit"))
-		default:
-			book, err = a.db.BookWithTranslations(bid, off, size, fNone)
-			if err == nil && book.LastVisitedPage != page {
-				if err := a.db.UpdateLastVisitedPage(bid, page); err != nil {
-					logError(err)
-				}
+		filters := filtersFromRequest(r.Form)
+		if _, err := newFragmentMatcher(filters); err != nil {
+			http.Error(w, "Invalid filter: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		book, err := a.db.BookWithTranslations(bid, off, size, filters...)
+		if err == nil && len(filters) == 0 && book.LastVisitedPage != page {
+			if err := a.db.UpdateLastVisitedPage(bid, page); err != nil {
+				logError(err)
 			}
 		}
 		if err != nil {
@@ -178,7 +217,7 @@ func (a *App) Book(w http.ResponseWriter, r *http.Request) {
 				return
 			}
 			if err == ErrInvalidOffset {
-				redirectToPageNumber(w, r, divRoundUp(len(book.FragmentsIDs), size))
+				redirectToPageNumber(w, r, divRoundUp(book.FragmentsTotal, size))
 				return
 			}
 			internalError(w, err)
@@ -189,9 +228,22 @@ func (a *App) Book(w http.ResponseWriter, r *http.Request) {
 			url:          r.URL,
 			PageNumber:   page,
 			TotalItems:   len(book.FragmentsIDs),
+			More:         book.MoreFragments,
 			itemsPerPage: size,
 		}
 
+		saved, err := a.db.SavedFilters(bid)
+		if err != nil {
+			internalError(w, err)
+			return
+		}
+
+		goal, err := a.goalProgress(book)
+		if err != nil {
+			internalError(w, err)
+			return
+		}
+
 		c, err := r.Cookie("show-orig-toolbox")
 		showOrigToolbox := err == nil && c.Value == "1"
 		c, err = r.Cookie("fluid")
@@ -203,6 +255,11 @@ func (a *App) Book(w http.ResponseWriter, r *http.Request) {
 			URL             string
 			ShowOrigToolbox bool
 			Fluid           bool
+			QAChecks        []qaCheckOption
+			Statuses        []fragmentStatus
+			SavedFilters    []SavedFilter
+			FilterQuery     string
+			GoalProgress    *goalProgress
 		}{
 			book,
 			pg,
@@ -210,6 +267,11 @@ func (a *App) Book(w http.ResponseWriter, r *http.Request) {
 			r.URL.String(),
 			showOrigToolbox,
 			fluid,
+			qaCheckOptions(r.Form["qa"]),
+			fragmentStatuses,
+			saved,
+			filterQuery(r.URL.Query()),
+			goal,
 		}); err != nil {
 			logError(err)
 		}
@@ -299,12 +361,26 @@ func (a *App) AddBook(w http.ResponseWriter, r *http.Request) {
 				return
 			}
 
+			meta, content, err := cutMetaLines(content)
+			if err != nil {
+				sess, _ := store.Get(r, "tl_sess")
+				sess.AddFlash("Invalid metadata: " + err.Error())
+				sess.Values["title"] = title
+				sess.Save(r, w)
+				http.Redirect(w, r, r.URL.Path, http.StatusSeeOther)
+				return
+			}
+
 			autotranslate := r.PostFormValue("autotranslate") != ""
 			bid, err = a.db.AddBook(title, split(content), autotranslate)
 			if err != nil {
 				internalError(w, err)
 				return
 			}
+			if err := a.db.UpdateBookMeta(bid, meta); err != nil {
+				internalError(w, err)
+				return
+			}
 
 		case "/add/csv":
 			f, _, err := r.FormFile("csvfile")
@@ -326,11 +402,25 @@ func (a *App) AddBook(w http.ResponseWriter, r *http.Request) {
 				return
 			}
 
+			meta, records, err := cutMetaRows(records)
+			if err != nil {
+				sess, _ := store.Get(r, "tl_sess")
+				sess.AddFlash("Invalid metadata: " + err.Error())
+				sess.Values["title"] = title
+				sess.Save(r, w)
+				http.Redirect(w, r, r.URL.Path, http.StatusSeeOther)
+				return
+			}
+
 			bid, err = a.db.AddTranslatedBook(title, records)
 			if err != nil {
 				internalError(w, err)
 				return
 			}
+			if err := a.db.UpdateBookMeta(bid, meta); err != nil {
+				internalError(w, err)
+				return
+			}
 
 		case "/add/json":
 			f, _, err := r.FormFile("jsonfile")
@@ -346,7 +436,7 @@ func (a *App) AddBook(w http.ResponseWriter, r *http.Request) {
 				return
 			}
 
-			bid, err = a.db.ImportBookFromJSON(data)
+			bid, err = ImportBookFromJSON(a.db, data)
 			if err != nil {
 				internalError(w, err)
 				return
@@ -378,7 +468,7 @@ func (a *App) ReadBook(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	book, err := a.db.BookWithTranslations(bid, 0, -1, fNone)
+	book, err := a.db.BookWithTranslations(bid, 0, -1)
 	if err != nil {
 		if err == ErrNotFound {
 			http.NotFound(w, r)
@@ -407,7 +497,7 @@ func (a *App) ExportBook(w http.ResponseWriter, r *http.Request) {
 	default:
 		http.NotFound(w, r)
 		return
-	case "plaintext", "plaintext-orig", "csv", "jsonl", "json":
+	case "plaintext", "plaintext-orig", "csv", "jsonl", "json", "qa":
 	}
 
 	vars := mux.Vars(r)
@@ -418,7 +508,7 @@ func (a *App) ExportBook(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if format == "json" {
-		data, err := a.db.ExportBookToJSON(bid)
+		data, err := ExportBookToJSON(a.db, bid)
 		if err != nil {
 			if err == ErrNotFound {
 				http.NotFound(w, r)
@@ -434,7 +524,11 @@ func (a *App) ExportBook(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	book, err := a.db.BookWithTranslations(bid, 0, -1, fNone)
+	var filters []bookFilter
+	if format == "qa" {
+		filters = []bookFilter{{fQA, r.Form["qa"]}}
+	}
+	book, err := a.db.BookWithTranslations(bid, 0, -1, filters...)
 	if err != nil {
 		if err == ErrNotFound {
 			http.NotFound(w, r)
@@ -444,14 +538,24 @@ func (a *App) ExportBook(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// The title and the metadata precede the fragments in the plain
+	// text, CSV and JSON Lines exports, in a form the imports recognize.
+	writeTextMeta := func() {
+		for _, line := range book.metaLines() {
+			fmt.Fprintln(w, line)
+		}
+		w.Write([]byte{'\n'})
+	}
+
 	switch format {
 	case "plaintext":
 		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
 		w.Header().Set("Content-Disposition", `attachment; filename="book.txt"`)
+		writeTextMeta()
 		for _, f := range book.Fragments {
-			t := f.Text
-			if len(f.Versions) > 0 {
-				t = f.Versions[0].Text
+			t := f.Translation(false)
+			if t == "" {
+				t = f.Text
 			}
 			fmt.Fprintln(w, t)
 			w.Write([]byte{'\n'})
@@ -459,6 +563,7 @@ func (a *App) ExportBook(w http.ResponseWriter, r *http.Request) {
 	case "plaintext-orig":
 		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
 		w.Header().Set("Content-Disposition", `attachment; filename="book.txt"`)
+		writeTextMeta()
 		for _, f := range book.Fragments {
 			fmt.Fprintln(w, f.Text)
 			w.Write([]byte{'\n'})
@@ -467,29 +572,52 @@ func (a *App) ExportBook(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "text/csv; charset=utf-8")
 		w.Header().Set("Content-Disposition", `attachment; filename="book.csv"`)
 		cw := csv.NewWriter(w)
+		cw.WriteAll(book.metaRows())
+		for _, f := range book.Fragments {
+			cw.Write([]string{f.Text, f.Translation(false)})
+		}
+		cw.Flush()
+	case "qa":
+		w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+		w.Header().Set("Content-Disposition", `attachment; filename="qa.csv"`)
+		cw := csv.NewWriter(w)
+		cw.Write([]string{"#", "Fragment", "Version", "Check", "Issue", "Original", "Translation"})
 		for _, f := range book.Fragments {
-			t := ""
-			if len(f.Versions) > 0 {
-				t = f.Versions[0].Text
+			for _, issue := range f.Issues {
+				cw.Write([]string{
+					fmt.Sprint(f.SeqNum),
+					fmt.Sprint(f.ID),
+					fmt.Sprint(issue.VersionID),
+					issue.Check,
+					issue.Message,
+					f.Text,
+					f.versionText(issue.VersionID),
+				})
 			}
-			cw.Write([]string{f.Text, t})
 		}
 		cw.Flush()
 	case "jsonl":
 		w.Header().Set("Content-Type", "application/jsonl; charset=utf-8")
 		w.Header().Set("Content-Disposition", `attachment; filename="book.jsonl"`)
 		enc := json.NewEncoder(w)
+		enc.Encode(struct {
+			Book interface{} `json:"book"`
+		}{
+			struct {
+				Title string `json:"title"`
+				BookMeta
+			}{
+				book.Title,
+				book.BookMeta,
+			},
+		})
 		for _, f := range book.Fragments {
-			t := ""
-			if len(f.Versions) > 0 {
-				t = f.Versions[0].Text
-			}
 			enc.Encode(struct {
 				Source      string `json:"source"`
 				Translation string `json:"translation"`
 			}{
 				f.Text,
-				t,
+				f.Translation(false),
 			})
 		}
 	}
@@ -553,15 +681,23 @@ func (a *App) AddFragment(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	terms, err := a.fragmentTerms(bid, f.Text)
+	if err != nil {
+		internalError(w, err)
+		return
+	}
+
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(struct {
 		Fragment
-		SeqNum int           `json:"seq_num"`
 		Text   template.HTML `json:"text"`
+		Terms  []Term        `json:"terms"`
+		SeqNum int           `json:"seq_num"`
 	}{
 		f,
+		renderTerms(f.Text, terms),
+		terms,
 		f.SeqNum,
-		render(f.Text),
 	})
 }
 
@@ -593,11 +729,19 @@ func (a *App) UpdateFragment(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	terms, err := a.fragmentTerms(bid, text)
+	if err != nil {
+		internalError(w, err)
+		return
+	}
+
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(struct {
-		Text template.HTML `json:"text"`
+		Text  template.HTML `json:"text"`
+		Terms []Term        `json:"terms"`
 	}{
-		render(text),
+		renderTerms(text, terms),
+		terms,
 	})
 }
 
@@ -661,39 +805,6 @@ func (a *App) StarFragment(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNoContent)
 }
 
-func (a *App) CommentFragment(w http.ResponseWriter, r *http.Request) {
-	vars := mux.Vars(r)
-
-	bid, err := u64(vars["book_id"])
-	if err != nil {
-		http.Error(w, "Invalid book ID", http.StatusBadRequest)
-		return
-	}
-	fid, err := u64(vars["fragment_id"])
-	if err != nil {
-		http.Error(w, "Invalid fragment ID", http.StatusBadRequest)
-		return
-	}
-	text := strings.TrimSpace(r.FormValue("text"))
-
-	err = a.db.CommentFragment(bid, fid, text)
-	if err != nil {
-		if err == ErrNotFound {
-			http.Error(w, "Fragment not found", 404)
-			return
-		}
-		internalError(w, err)
-		return
-	}
-
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(struct {
-		Text string `json:"text"`
-	}{
-		text,
-	})
-}
-
 func (a *App) Translate(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 
@@ -718,6 +829,24 @@ func (a *App) Translate(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	switch r.FormValue("typography") {
+	case "preview":
+		t := typograph(text)
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(struct {
+			Text    string        `json:"text"`
+			Diff    template.HTML `json:"diff"`
+			Changed bool          `json:"changed"`
+		}{
+			t,
+			renderDiff(text, t),
+			t != text,
+		})
+		return
+	case "apply":
+		text = typograph(text)
+	}
+
 	v, fragmentsTranslated, err := a.db.Translate(bid, fid, vid, text)
 	if err != nil {
 		if err == ErrNotFound {
@@ -780,12 +909,10 @@ func (a *App) RemoveVersion(w http.ResponseWriter, r *http.Request) {
 }
 
 func (a *App) Backup(w http.ResponseWriter, r *http.Request) {
-	if err := a.db.View(func(tx *bolt.Tx) error {
+	if err := a.db.Backup(w, func(size int64) {
 		w.Header().Set("Content-Type", "application/octet-stream")
-		w.Header().Set("Content-Disposition", `attachment; filename="tl.db"`)
-		w.Header().Set("Content-Length", strconv.Itoa(int(tx.Size())))
-		_, err := tx.WriteTo(w)
-		return err
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", a.backupName))
+		w.Header().Set("Content-Length", strconv.FormatInt(size, 10))
 	}); err != nil {
 		logError(err)
 	}
@@ -0,0 +1,73 @@
+(function() {
+  'use strict';
+  $(document).ready(() => {
+    let $form = $('#term-form');
+    let $submit = $form.find(':submit');
+    let $cancel = $form.find('.cancel');
+
+    function reset() {
+      $form[0].reset();
+      $form.attr('action', $form.data('action'));
+      $submit.text('Add');
+      $cancel.hide();
+    }
+
+    $form.on('submit', e => {
+      e.preventDefault();
+      $submit.attr('disabled', true);
+      $.ajax({
+        method: 'POST',
+        url: $form.attr('action'),
+        data: $form.serialize(),
+      })
+        .done(() => location.reload())
+        .fail(xhr => {
+          $submit.attr('disabled', false);
+          alert(xhr.responseText);
+        });
+    });
+    $cancel.on('click', reset);
+
+    $('.x-edit-term').on('click', e => {
+      let $tr = $(e.target).closest('tr');
+      $form.find('[name=source]').val($tr.find('.source').text());
+      $form.find('[name=target]').val($tr.find('.target').text());
+      $form.find('[name=pos]').val($tr.find('.pos').text());
+      $form.find('[name=notes]').val($(e.target).data('notes'));
+      $form.attr('action', $form.data('action') + '/' + $tr.data('term-id'));
+      $submit.text('Save');
+      $cancel.show();
+      $form.find('[name=source]').focus();
+    });
+
+    $('.x-remove-term').on('click', e => {
+      let $tr = $(e.target).closest('tr');
+      let dlg = bootbox.confirm({
+        message:
+          '<b>Remove the following term?</b><br><br>' +
+          $('<div>')
+            .text($tr.find('.source').text())
+            .html(),
+        buttons: {
+          confirm: {
+            label: 'Remove',
+            className: 'btn-danger',
+          },
+        },
+        callback: result => {
+          if (!result) return;
+          $.ajax({
+            method: 'DELETE',
+            url: $form.data('action') + '/' + $tr.data('term-id'),
+          })
+            .done(() => {
+              dlg.modal('hide');
+              $tr.remove();
+            })
+            .fail((xhr, status, err) => alert(err));
+        },
+      });
+    });
+  });
+})();
+// vim: ts=2 sts=2 sw=2 et
@@ -87,7 +87,49 @@
     return format(d);
   };
 
+  const bookURL = e =>
+    '/book/' +
+    $(e.target)
+      .closest('tr')
+      .data('id');
+
+  function moveToCollection(e) {
+    let url = bookURL(e) + '/collection';
+    bootbox.prompt({
+      title: 'Move to the collection (leave empty to take out of any):',
+      value: $(e.target)
+        .closest('tr')
+        .data('collection'),
+      callback: name => {
+        if (name === null) return;
+        $.ajax({ url, method: 'POST', data: { collection: name } })
+          .done(() => location.reload())
+          .fail(xhr => alert(xhr.responseText));
+      },
+    });
+  }
+
+  function archive(e, method) {
+    $.ajax({ url: bookURL(e) + '/archived', method })
+      .done(() => location.reload())
+      .fail(xhr => alert(xhr.responseText));
+  }
+
   $(document).ready(() => {
+    $('.x-collection').on('click', moveToCollection);
+    $('.x-archive').on('click', e => archive(e, 'POST'));
+    $('.x-unarchive').on('click', e => archive(e, 'DELETE'));
+    $('.dropdown-sort a').on('click', e => {
+      e.preventDefault();
+      Cookies.set('sort', $(e.target).data('sort'));
+      location.reload();
+    });
+    $('.index-filter select').on('change', e => {
+      $(e.target)
+        .closest('form')
+        .submit();
+    });
+
     setInterval(() => {
       $('time').each((_, el) => {
         const $el = $(el);
@@ -11,7 +11,7 @@
       let bid = $checked.attr('value');
       let title = $checked.parent().text();
       let dlg = bootbox.confirm({
-        message: '<b>Remove the following book?</b><br><br>' + title,
+        message: '<b>Move the following book to the trash?</b><br><br>' + title,
         buttons: {
           confirm: {
             label: 'Remove',
@@ -0,0 +1,25 @@
+(function() {
+  'use strict';
+  $(document).ready(() => {
+    let $form = $('#replace-form');
+    let $apply = $form.find('.apply');
+    $apply.on('click', () => {
+      $apply.attr('disabled', true);
+      $.ajax({
+        method: 'POST',
+        url: $form.attr('action'),
+        data: $form.serialize(),
+      })
+        .done(data => {
+          bootbox.alert(data.changed + ' versions changed.', () =>
+            location.reload()
+          );
+        })
+        .fail(xhr => {
+          $apply.attr('disabled', false);
+          alert(xhr.responseText);
+        });
+    });
+  });
+})();
+// vim: ts=2 sts=2 sw=2 et
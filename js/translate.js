@@ -46,21 +46,12 @@
     let $next = null;
     $form.ajaxForm({
       dataType: 'json',
-      beforeSerialize: () => {
-        let text = $textarea.val();
-        if (!/[а-яё]/i.test(text)) return;
-        text = text.replace(/(^|\s)- /g, '$1— ');
-        text = text.replace(/(\s)-$/, '$1—');
-        text = text.replace(/(^|[-\s([])"(\S)/g, '$1«$2');
-        text = text.replace(/(\S)"([-\s.,!?:;…)\]]|$)/g, '$1»$2');
-        text = text.replace(/\.\.\./g, '…');
-        $textarea.val(text);
-      },
       beforeSubmit: () => $submit.attr('disabled', true),
       success: data => {
         cancelEdit = null;
         let $html = $($('#version-tmpl').html());
         $html.attr('id', 'v' + data.id);
+        if ($div.hasClass('preferred')) $html.addClass('preferred');
         $html.find('.text').html(data.text);
         updateProgress(fragments_total, data.fragments_translated);
         $form.replaceWith($html);
@@ -109,85 +100,118 @@
       }
     });
     $form.on('click', '.cancel', cancelEdit);
+    $form.on('click', '.typography', () => previewTypography($form));
     if (vid) {
       $div.replaceWith($form);
     } else {
       $row.find('td.t').append($form);
     }
     $textarea.autoGrow().focus();
+    loadTranslationMemory(fid, $form);
+  }
+
+  function previewTypography($form) {
+    let $textarea = $form.find('textarea');
+    $.ajax({
+      url: $form.attr('action'),
+      method: 'POST',
+      data: { version_id: 0, text: $textarea.val(), typography: 'preview' },
+    }).done(data => {
+      let $container = $form.find('.typography-container').empty();
+      if (!data.changed) return;
+      $container.append(
+        $('<div class="typography-preview history">').html(data.diff)
+      );
+      $textarea
+        .val(data.text)
+        .keyup()
+        .focus();
+    });
+  }
+
+  function loadTranslationMemory(fid, $form) {
+    $.ajax({ url: '/book/' + book_id + '/' + fid + '/tm', method: 'GET' }).done(
+      data => {
+        if (!data.length) return;
+        let $list = $('<ul class="list-unstyled tm">');
+        data.forEach(m => {
+          $list.append(
+            $('<li>')
+              .data('text', m.translation)
+              .attr('title', m.title + ': ' + m.original)
+              .append(
+                $('<span class="label label-default">').text(m.score + '%'),
+                ' ',
+                $('<span>').html(m.html)
+              )
+          );
+        });
+        $list.on('click', 'li', e => {
+          let $textarea = $form.find('textarea');
+          $textarea
+            .val($(e.currentTarget).data('text'))
+            .keyup()
+            .focus();
+        });
+        $form.find('.tm-container').append($list);
+      }
+    );
+  }
+
+  const md = markdownit({
+    linkify: true,
+    typographer: true,
+    quotes: '«»„“',
+  });
+
+  // updateCommentIcon records whether the fragment has comment threads and
+  // whether any of them is unresolved.
+  function updateCommentIcon($icon, threads) {
+    $icon
+      .attr('data-commented', threads.length ? 1 : null)
+      .toggleClass('unresolved', threads.some(t => !t.resolved));
+  }
+
+  function showCommentIcon($icon) {
+    $icon
+      .removeClass('fa-times-circle fa-comment fa-comment-o')
+      .addClass($icon.attr('data-commented') ? 'fa-comment' : 'fa-comment-o');
   }
 
   function closeCommentary(e) {
-    let $target = $(e.target);
-    let $commentaryRow = $target.closest('tr');
+    let $commentaryRow = $(e.target).closest('tr');
     $commentaryRow
       .removeClass('shown')
       .find('td')
       .html('');
-    let $comment = $commentaryRow.prev().find('.x-comment');
-    $comment.removeClass('fa-times-circle');
-    if ($comment.data('comment')) {
-      $comment.addClass('fa-comment');
-    } else {
-      $comment.addClass('fa-comment-o');
-    }
+    showCommentIcon($commentaryRow.prev().find('.x-comment'));
   }
 
-  function comment(e) {
-    let $target = $(e.target);
-    let $row = $target.closest('tr');
-    let $commentaryRow = $row.next();
-    $commentaryRow.toggleClass('shown');
-    if (!$commentaryRow.hasClass('shown')) {
-      $target.removeClass('fa-times-circle');
-      if ($target.data('comment')) {
-        $target.addClass('fa-comment');
-      } else {
-        $target.addClass('fa-comment-o');
-      }
-      return;
-    }
-    $target.removeClass('fa-comment fa-comment-o').addClass('fa-times-circle');
-    let fid = $row.attr('id').substr(1);
-    let $form = $($('#commentary-form-tmpl').html());
-    $form.attr('action', '/book/' + book_id + '/' + fid + '/comment');
+  function commentForm($form, success) {
     let $submit = $form.find(':submit');
-    let $edit = $form.find('.btn-edit');
-    let $div = $form.find('.text');
-    let render = text => {
-      let md = markdownit({
-        linkify: true,
-        typographer: true,
-        quotes: '«»„“',
-      });
-      $div.html(md.render(String(text)));
-      $div.on('dblclick', editCommentary);
-      $submit.hide();
-      $edit.show();
-    };
-    let editCommentary = () => {
-      $div.off('dblclick');
-      $edit.hide();
-      let $textarea = $('<textarea name="text" spellcheck="false">');
-      $div.html('').append($textarea);
-      $textarea.text($target.data('comment'));
-      $submit.attr('disabled', false).show();
-      $textarea.on('keydown', e => {
-        if (e.ctrlKey && e.which == 13) {
-          e.stopPropagation();
-          $submit.click();
-        }
-      });
-      // Delay autoGrow until the textarea's width property is initialized (Google Chrome).
-      setTimeout(() => $textarea.autoGrow().focus(), 1);
-    };
-    $edit.on('click', editCommentary);
+    let $textarea = $form.find('textarea');
+    $textarea.on('keydown', e => {
+      if (e.ctrlKey && e.which == 13) {
+        e.stopPropagation();
+        $submit.click();
+      }
+    });
     $form.ajaxForm({
       dataType: 'json',
+      beforeSerialize: () => {
+        let $author = $form.find('[name=author]');
+        if ($author.is('[type=hidden]')) {
+          $author.val(Cookies.get('author') || '');
+        } else {
+          Cookies.set('author', $author.val().trim());
+        }
+      },
       beforeSubmit: () => $submit.attr('disabled', true),
       success: data => {
-        $target.data('comment', data.text);
-        render(data.text);
+        $submit.attr('disabled', false);
+        $textarea.val('');
+        $form.find('.alert-container').html('');
+        success(data);
       },
       error: data => {
         $submit.attr('disabled', false);
@@ -196,16 +220,115 @@
         $form.find('.alert-container').html($alert);
       },
     });
-    let text = $target.data('comment');
-    if (text) {
-      render(text);
-    } else {
-      editCommentary();
+  }
+
+  function comment(e) {
+    let $icon = $(e.target);
+    let $row = $icon.closest('tr');
+    let $commentaryRow = $row.next();
+    $commentaryRow.toggleClass('shown');
+    if (!$commentaryRow.hasClass('shown')) {
+      $commentaryRow.find('td').html('');
+      showCommentIcon($icon);
+      return;
     }
+    $icon.removeClass('fa-comment fa-comment-o').addClass('fa-times-circle');
+    let fid = $row.attr('id').substr(1);
+    let url = '/book/' + book_id + '/' + fid + '/comments';
+    let threads = [];
+    let $commentary = $($('#commentary-tmpl').html());
+    let $threads = $commentary.find('.threads');
+
+    let renderComment = (thread, $thread, c) => {
+      let $c = $($('#comment-tmpl').html());
+      $c.find('.author').text(c.author || 'Anonymous');
+      $c.find('time')
+        .attr('datetime', c.created)
+        .text(new Date(c.created).toLocaleString());
+      $c.find('.text').html(md.render(c.text));
+      $c.find('.x-remove-comment').on('click', () => {
+        // Removing the only comment of a thread removes the thread.
+        let last = thread.comments.length === 1;
+        bootbox.confirm(
+          last ? 'Remove the thread?' : 'Remove the comment?',
+          result => {
+            if (!result) return;
+            $.ajax({
+              method: 'DELETE',
+              url: url + '/' + thread.id + '/' + c.id,
+            })
+              .done(() => {
+                if (last) {
+                  threads.splice(threads.indexOf(thread), 1);
+                  $thread.remove();
+                } else {
+                  thread.comments.splice(thread.comments.indexOf(c), 1);
+                  $c.remove();
+                }
+                updateCommentIcon($icon, threads);
+              })
+              .fail((xhr, status, err) => alert(err));
+          }
+        );
+      });
+      return $c;
+    };
+
+    let renderThread = thread => {
+      let $thread = $($('#thread-tmpl').html());
+      let $comments = $thread.find('.comments');
+      thread.comments.forEach(c =>
+        $comments.append(renderComment(thread, $thread, c))
+      );
+      let $resolve = $thread.find('.btn-resolve');
+      let showResolved = () => {
+        $thread.toggleClass('resolved', thread.resolved);
+        $resolve.text(thread.resolved ? 'Reopen' : 'Resolve');
+      };
+      showResolved();
+      $resolve.on('click', () => {
+        $.ajax({
+          method: thread.resolved ? 'DELETE' : 'POST',
+          url: url + '/' + thread.id + '/resolved',
+        })
+          .done(() => {
+            thread.resolved = !thread.resolved;
+            showResolved();
+            updateCommentIcon($icon, threads);
+          })
+          .fail((xhr, status, err) => alert(err));
+      });
+      let $form = $thread.find('.reply-form');
+      $form.attr('action', url + '/' + thread.id);
+      commentForm($form, c => {
+        thread.comments.push(c);
+        $comments.append(renderComment(thread, $thread, c));
+      });
+      return $thread;
+    };
+
+    let $form = $commentary.find('.commentary-form');
+    $form.attr('action', url);
+    $form.find('[name=author]').val(Cookies.get('author') || '');
+    commentForm($form, thread => {
+      threads.push(thread);
+      $threads.append(renderThread(thread));
+      updateCommentIcon($icon, threads);
+    });
+
     $commentaryRow
       .find('td')
       .html('')
-      .append($form);
+      .append($commentary);
+
+    $.getJSON(url)
+      .done(data => {
+        threads = data;
+        threads.forEach(thread => $threads.append(renderThread(thread)));
+        updateCommentIcon($icon, threads);
+        if (!threads.length) $form.find('textarea').focus();
+      })
+      .fail((xhr, status, err) => alert(err));
   }
 
   function remove(e) {
@@ -243,6 +366,110 @@
     });
   }
 
+  function prefer(e) {
+    let $div = $(e.target).closest('div[id^=v]');
+    let vid = $div.attr('id').substr(1);
+    let fid = $div
+      .closest('tr')
+      .attr('id')
+      .substr(1);
+    let preferred = $div.hasClass('preferred');
+    $.ajax({
+      method: preferred ? 'DELETE' : 'POST',
+      url: '/book/' + book_id + '/' + fid + '/' + vid + '/preferred',
+    })
+      .done(() => {
+        $div.siblings('div[id^=v]').removeClass('preferred');
+        $div.toggleClass('preferred', !preferred);
+      })
+      .fail((xhr, status, err) => alert(err));
+  }
+
+  function showHistory(e) {
+    let $div = $(e.target).closest('div[id^=v]');
+    let vid = $div.attr('id').substr(1);
+    let fid = $div
+      .closest('tr')
+      .attr('id')
+      .substr(1);
+    let url = '/book/' + book_id + '/' + fid + '/' + vid + '/history';
+    $.ajax({ url, method: 'GET' })
+      .done(data => {
+        let $list = $('<div class="history">');
+        data.reverse().forEach((rev, i) => {
+          let $rev = $('<div class="revision">');
+          let $header = $('<div class="revision-header">');
+          $header.append(
+            $('<time>')
+              .attr('datetime', rev.created)
+              .text(new Date(rev.created).toLocaleString())
+          );
+          if (i > 0) {
+            $header.append(
+              $(
+                '<button type="button" class="btn btn-default btn-xs pull-right">'
+              )
+                .text('Revert')
+                .data('revision-id', rev.id)
+            );
+          }
+          $rev.append($header, $('<blockquote>').html(rev.html));
+          $list.append($rev);
+        });
+        let dlg = bootbox.dialog({
+          title: 'History',
+          message: $list,
+          onEscape: true,
+          backdrop: true,
+        });
+        $list.on('click', 'button', e => {
+          $.ajax({
+            url,
+            method: 'POST',
+            data: { revision_id: $(e.target).data('revision-id') },
+          })
+            .done(data => {
+              dlg.modal('hide');
+              $div.find('.text').html(data.text);
+            })
+            .fail((xhr, status, err) => alert(err));
+        });
+      })
+      .fail((xhr, status, err) => alert(err));
+  }
+
+  function showSourceHistory(e) {
+    let fid = $(e.target)
+      .closest('tr')
+      .attr('id')
+      .substr(1);
+    $.ajax({
+      url: '/book/' + book_id + '/' + fid + '/history',
+      method: 'GET',
+    })
+      .done(data => {
+        let $list = $('<div class="history">');
+        data.reverse().forEach(rev => {
+          let $rev = $('<div class="revision">');
+          let $header = $('<div class="revision-header">');
+          $header.append(
+            $('<time>')
+              .attr('datetime', rev.created)
+              .text(new Date(rev.created).toLocaleString())
+          );
+          $rev.append($header, $('<blockquote>').html(rev.diff || rev.html));
+          $list.append($rev);
+        });
+        bootbox.dialog({
+          title: 'History of the original',
+          message: $list,
+          onEscape: true,
+          backdrop: true,
+        });
+      })
+      .fail((xhr, status, err) => alert(err));
+  }
+
   function star(e) {
     let $icon = $(e.target);
     let fid = $icon
@@ -275,6 +502,35 @@
       .fail((xhr, status, err) => alert(err));
   }
 
+  const statuses = {
+    draft: { next: 'review', title: 'Draft' },
+    review: { next: 'approved', title: 'Needs review' },
+    approved: { next: 'draft', title: 'Approved' },
+  };
+
+  function cycleStatus(e) {
+    let $icon = $(e.target);
+    let fid = $icon
+      .closest('tr')
+      .attr('id')
+      .substr(1);
+    let status = $icon.attr('data-status');
+    let next = statuses[status].next;
+    $.ajax({
+      method: 'POST',
+      url: '/book/' + book_id + '/' + fid + '/status',
+      data: { status: next },
+    })
+      .done(() => {
+        $icon
+          .removeClass('status-' + status)
+          .addClass('status-' + next)
+          .attr('data-status', next)
+          .attr('title', statuses[next].title);
+      })
+      .fail((xhr, status, err) => alert(err));
+  }
+
   function toggleFluid() {
     let c = $('#container');
     if (c.hasClass('container-fluid')) {
@@ -408,7 +664,10 @@
           .html('<i class="fa fa-arrow-right x-translate"></i>');
         $newRow
           .find('td:last-child')
-          .html('<i class="fa fa-comment-o x-comment"></i>');
+          .html(
+            '<i class="fa fa-comment-o x-comment"></i> ' +
+              '<i class="fa x-status status-draft" data-status="draft" title="Draft"></i>'
+          );
         let $html = $($('#orig-tmpl').html());
         $html.find('.text').html(data.text);
         $html = $html.add(
@@ -629,14 +888,87 @@
     };
   }
 
+  function editMeta() {
+    let $form = $($('#book-meta-form-tmpl').html());
+    Object.keys(book_meta).forEach(k => {
+      let v = book_meta[k];
+      $form.find(`[name="${k}"]`).val(Array.isArray(v) ? v.join(', ') : v);
+    });
+    bootbox.dialog({
+      title: 'Metadata',
+      message: $form,
+      onEscape: true,
+      backdrop: true,
+      buttons: {
+        cancel: { label: 'Cancel' },
+        ok: {
+          label: 'Save',
+          className: 'btn-primary',
+          callback: () => {
+            $.ajax({
+              url: '/book/' + book_id + '/meta',
+              method: 'POST',
+              data: $form.serialize(),
+            })
+              .done(() => location.reload())
+              .fail(xhr => alert(xhr.responseText));
+          },
+        },
+      },
+    });
+  }
+
+  function editGoal() {
+    let $form = $($('#goal-form-tmpl').html());
+    if (book_goal) {
+      $form.find('[name="amount"]').val(book_goal.amount);
+      $form.find('[name="unit"]').val(book_goal.unit);
+    }
+    const save = method => {
+      $.ajax({
+        url: '/book/' + book_id + '/goal',
+        method,
+        data: method === 'POST' ? $form.serialize() : undefined,
+      })
+        .done(() => location.reload())
+        .fail(xhr => alert(xhr.responseText));
+    };
+    let buttons = {
+      cancel: { label: 'Cancel' },
+      ok: {
+        label: 'Save',
+        className: 'btn-primary',
+        callback: () => save('POST'),
+      },
+    };
+    if (book_goal) {
+      buttons.remove = {
+        label: 'Remove the goal',
+        className: 'btn-danger pull-left',
+        callback: () => save('DELETE'),
+      };
+    }
+    bootbox.dialog({
+      title: 'Daily goal',
+      message: $form,
+      onEscape: true,
+      backdrop: true,
+      buttons,
+    });
+  }
+
   $(document).ready(() => {
     $('.translator')
       .on('click', '.x-translate, .x-edit', edit)
       .on('click', '.x-remove', remove)
+      .on('click', '.x-history', showHistory)
+      .on('click', '.x-prefer', prefer)
+      .on('click', '.x-orig-history', showSourceHistory)
       .on('click', '.x-comment', comment)
       .on('click', '.commentary-form .btn-close', closeCommentary)
       .on('click', '.x-star', star)
       .on('click', '.x-unstar', unstar)
+      .on('click', '.x-status', cycleStatus)
       .on('click', '.x-expand', toggleOrigToolbox)
       .on('click', '.x-remove-orig', removeOrig)
       .on('click', '.x-edit-orig', editOrig)
@@ -756,18 +1088,53 @@
     updateProgress(fragments_total, fragments_translated);
     $('.filter-dropdown')
       .on('click', 'label', e => {
-        $(e.target)
-          .closest('label')
-          .find('[type="radio"]')
+        let $target = $(e.target);
+        if (!$target.is('input, select') || $target.is('[name="f"]')) return;
+        $target
+          .closest('.dropdown-filter > li')
+          .find('[name="f"]')
           .prop('checked', true);
       })
       .on('click', '.dropdown-menu', e => e.stopPropagation());
-    $('#orig_contains, #trans_contains').on('click', e =>
-      $(e.target)
-        .next()
-        .focus()
+    $('#orig_contains, #trans_contains, #orig_matches, #trans_matches').on(
+      'click',
+      e =>
+        $(e.target)
+          .next()
+          .focus()
     );
+    $('.x-save-filter').on('click', e => {
+      let query = $(e.target).data('query');
+      bootbox.prompt('Save the filter as:', name => {
+        if (!name) return;
+        $.ajax({
+          url: '/book/' + book_id + '/filters',
+          method: 'POST',
+          data: { name: name, query: query },
+        })
+          .done(() => location.reload())
+          .fail(xhr => alert(xhr.responseText));
+      });
+    });
+    $('.x-remove-filter').on('click', e => {
+      let name = $(e.currentTarget).data('name');
+      bootbox.confirm('Remove the filter "' + name + '"?', result => {
+        if (!result) return;
+        $.ajax({
+          url: '/book/' + book_id + '/filters?' + $.param({ name: name }),
+          method: 'DELETE',
+        })
+          .done(() => location.reload())
+          .fail(xhr => alert(xhr.responseText));
+      });
+    });
     $('.fa-window-restore').on('click', toggleFluid);
+    $('.x-edit-meta').on('click', editMeta);
+    $('.x-edit-goal').on('click', editGoal);
+    $('.x-toggle-heatmap').on('click', e => {
+      e.preventDefault();
+      $('.heatmap').toggle();
+    });
     if (location.hash) {
       const $hl = $(location.hash);
       $hl.addClass('highlight');
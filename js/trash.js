@@ -0,0 +1,43 @@
+(function() {
+  'use strict';
+  $(document).ready(() => {
+    $('.button-restore').click(e => {
+      e.preventDefault();
+      let $tr = $(e.target).closest('tr');
+      $.ajax({
+        method: 'POST',
+        url: '/trash/' + $tr.data('book-id'),
+      })
+        .done(() => $tr.remove())
+        .fail((xhr, status, err) => alert(err));
+    });
+    $('.button-purge').click(e => {
+      e.preventDefault();
+      let $tr = $(e.target).closest('tr');
+      let title = $tr.find('.title').text();
+      let dlg = bootbox.confirm({
+        message:
+          '<b>Permanently remove the following book?</b><br><br>' + title,
+        buttons: {
+          confirm: {
+            label: 'Purge',
+            className: 'btn-danger',
+          },
+        },
+        callback: result => {
+          if (!result) return;
+          $.ajax({
+            method: 'DELETE',
+            url: '/trash/' + $tr.data('book-id'),
+          })
+            .done(() => {
+              dlg.modal('hide');
+              $tr.remove();
+            })
+            .fail((xhr, status, err) => alert(err));
+        },
+      });
+    });
+  });
+})();
+// vim: ts=2 sts=2 sw=2 et
@@ -0,0 +1,22 @@
+(function() {
+  'use strict';
+  $(document).ready(() => {
+    let $form = $('#typography-form');
+    let $submit = $form.find(':submit');
+    $form.on('submit', e => {
+      e.preventDefault();
+      $submit.attr('disabled', true);
+      $.ajax({ method: 'POST', url: $form.attr('action') })
+        .done(data => {
+          bootbox.alert(data.changed + ' translations changed.', () =>
+            location.reload()
+          );
+        })
+        .fail(xhr => {
+          $submit.attr('disabled', false);
+          alert(xhr.responseText);
+        });
+    });
+  });
+})();
+// vim: ts=2 sts=2 sw=2 et
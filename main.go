@@ -18,16 +18,25 @@ package main
 
 import (
 	"flag"
+	"fmt"
 	"log"
 	"net/http"
 	"os"
+	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/gorilla/mux"
 )
 
 var (
 	addr       = flag.String("http", "", "HTTP service address (default :$PORT or :3000)")
-	dataSource = flag.String("db", "tl.db", "Path to the translation database")
+	dataSource = flag.String("db", "tl.db", "Path to the translation database (prefix with sqlite: for SQLite)")
+
+	trashRetention = flag.Duration("trash-retention", 30*24*time.Hour, "How long to keep removed books in the trash (0 means forever)")
+
+	qaMinRatio = flag.Float64("qa-min-ratio", 0.5, "QA: the lowest acceptable ratio of the length of a translation to the length of the original")
+	qaMaxRatio = flag.Float64("qa-max-ratio", 2, "QA: the highest acceptable ratio of the length of a translation to the length of the original")
 )
 
 func main() {
@@ -41,11 +50,71 @@ func main() {
 		*addr = "127.0.0.1:" + port
 	}
 
-	db, err := OpenDatabase(*dataSource, 0600, nil)
+	db, err := OpenStorage(*dataSource)
 	if err != nil {
 		log.Fatal(err)
 	}
-	app := App{db}
+
+	switch flag.Arg(0) {
+	case "":
+	case "gc":
+		bdb, ok := db.(*DB)
+		if !ok {
+			log.Fatal("gc is only supported for boltdb databases")
+		}
+		stats, err := bdb.CollectGarbage()
+		if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Printf("Removed data of %d deleted books: %d fragments, %d versions, %d revisions, %d scratchpads (%d bytes).\n",
+			stats.Books, stats.Fragments, stats.Versions, stats.Revisions, stats.Scratchpads, stats.Bytes)
+		db.Close()
+		return
+	case "fsck":
+		fs := flag.NewFlagSet("fsck", flag.ExitOnError)
+		repair := fs.Bool("repair", false, "Repair the problems found")
+		fs.Parse(flag.Args()[1:])
+		bdb, ok := db.(*DB)
+		if !ok {
+			log.Fatal("fsck is only supported for boltdb databases")
+		}
+		problems, err := bdb.Fsck(*repair)
+		if err != nil {
+			log.Fatal(err)
+		}
+		for _, p := range problems {
+			fmt.Println(p)
+		}
+		db.Close()
+		if len(problems) > 0 && !*repair {
+			os.Exit(1)
+		}
+		return
+	case "convert":
+		if flag.NArg() != 2 {
+			log.Fatal("usage: tl -db SOURCE convert DESTINATION")
+		}
+		dst, err := OpenStorage(flag.Arg(1))
+		if err != nil {
+			log.Fatal(err)
+		}
+		n, err := Convert(db, dst)
+		if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Printf("Copied %d books.\n", n)
+		dst.Close()
+		db.Close()
+		return
+	default:
+		log.Fatalf("unknown command: %q", flag.Arg(0))
+	}
+
+	if *trashRetention > 0 {
+		go purgeTrashPeriodically(db, *trashRetention)
+	}
+
+	app := App{db, filepath.Base(strings.TrimPrefix(strings.TrimPrefix(*dataSource, "sqlite:"), "bolt:"))}
 
 	r := mux.NewRouter()
 
@@ -53,6 +122,12 @@ func main() {
 	r.HandleFunc("/add", app.AddBook).Methods("GET", "POST")
 	r.HandleFunc("/add/{csv|json}", app.AddBook).Methods("POST")
 	r.HandleFunc("/remove", app.RemoveBook).Methods("GET")
+	r.HandleFunc("/trash", app.Trash).Methods("GET")
+	r.HandleFunc(`/trash/{book_id:[0-9]+}`, app.RestoreBook).Methods("POST")
+	r.HandleFunc(`/trash/{book_id:[0-9]+}`, app.PurgeBook).Methods("DELETE")
+	r.HandleFunc("/search", app.Search).Methods("GET")
+	r.HandleFunc("/stats", app.Statistics).Methods("GET")
+	r.HandleFunc("/report", app.Report).Methods("GET")
 	r.HandleFunc("/aligner", app.Aligner).Methods("GET", "POST")
 	r.HandleFunc("/plugins/academic", app.Academic).Methods("GET")
 	r.HandleFunc("/plugins/oxford", app.Oxford).Methods("GET")
@@ -64,6 +139,30 @@ func main() {
 		Methods("GET")
 	r.HandleFunc("/book/{book_id:[0-9]+}/scratchpad", app.Scratchpad).
 		Methods("GET", "POST")
+	r.HandleFunc("/book/{book_id:[0-9]+}/glossary", app.Glossary).
+		Methods("GET", "POST")
+	r.HandleFunc("/book/{book_id:[0-9]+}/glossary/{term_id:[0-9]+}", app.Term).
+		Methods("POST", "DELETE")
+	r.HandleFunc("/book/{book_id:[0-9]+}/terminology", app.Terminology).
+		Methods("GET")
+	r.HandleFunc("/book/{book_id:[0-9]+}/typography", app.Typography).
+		Methods("GET", "POST")
+	r.HandleFunc("/book/{book_id:[0-9]+}/replace", app.Replace).
+		Methods("GET", "POST")
+	r.HandleFunc("/book/{book_id:[0-9]+}/stats", app.BookStatistics).
+		Methods("GET")
+	r.HandleFunc("/book/{book_id:[0-9]+}/report", app.BookReport).
+		Methods("GET")
+	r.HandleFunc("/book/{book_id:[0-9]+}/meta", app.BookMeta).
+		Methods("POST")
+	r.HandleFunc("/book/{book_id:[0-9]+}/collection", app.BookCollection).
+		Methods("POST")
+	r.HandleFunc("/book/{book_id:[0-9]+}/archived", app.ArchiveBook).
+		Methods("POST", "DELETE")
+	r.HandleFunc("/book/{book_id:[0-9]+}/goal", app.DailyGoal).
+		Methods("POST", "DELETE")
+	r.HandleFunc("/book/{book_id:[0-9]+}/filters", app.Filters).
+		Methods("POST", "DELETE")
 	r.HandleFunc(`/book/{book_id:[0-9]+}/export`, app.ExportBook).
 		Methods("GET")
 	r.HandleFunc("/book/{book_id:[0-9]+}/{fragment_id:[0-9]+}", app.Fragment).
@@ -74,14 +173,30 @@ func main() {
 		Methods("POST")
 	r.HandleFunc("/book/{book_id:[0-9]+}/{fragment_id:[0-9]+}", app.RemoveFragment).
 		Methods("DELETE")
+	r.HandleFunc("/book/{book_id:[0-9]+}/{fragment_id:[0-9]+}/history", app.SourceHistory).
+		Methods("GET")
+	r.HandleFunc("/book/{book_id:[0-9]+}/{fragment_id:[0-9]+}/tm", app.TranslationMemory).
+		Methods("GET")
 	r.HandleFunc("/book/{book_id:[0-9]+}/{fragment_id:[0-9]+}/star", app.StarFragment).
 		Methods("POST", "DELETE")
-	r.HandleFunc("/book/{book_id:[0-9]+}/{fragment_id:[0-9]+}/comment", app.CommentFragment).
+	r.HandleFunc("/book/{book_id:[0-9]+}/{fragment_id:[0-9]+}/status", app.FragmentStatus).
+		Methods("POST")
+	r.HandleFunc("/book/{book_id:[0-9]+}/{fragment_id:[0-9]+}/comments", app.Comments).
+		Methods("GET", "POST")
+	r.HandleFunc("/book/{book_id:[0-9]+}/{fragment_id:[0-9]+}/comments/{thread_id:[0-9]+}", app.Thread).
 		Methods("POST")
+	r.HandleFunc("/book/{book_id:[0-9]+}/{fragment_id:[0-9]+}/comments/{thread_id:[0-9]+}/resolved", app.ResolveThread).
+		Methods("POST", "DELETE")
+	r.HandleFunc("/book/{book_id:[0-9]+}/{fragment_id:[0-9]+}/comments/{thread_id:[0-9]+}/{comment_id:[0-9]+}", app.RemoveComment).
+		Methods("DELETE")
 	r.HandleFunc("/book/{book_id:[0-9]+}/{fragment_id:[0-9]+}/translate", app.Translate).
 		Methods("POST")
 	r.HandleFunc("/book/{book_id:[0-9]+}/{fragment_id:[0-9]+}/{version_id:[0-9]+}", app.RemoveVersion).
 		Methods("DELETE")
+	r.HandleFunc("/book/{book_id:[0-9]+}/{fragment_id:[0-9]+}/{version_id:[0-9]+}/history", app.VersionHistory).
+		Methods("GET", "POST")
+	r.HandleFunc("/book/{book_id:[0-9]+}/{fragment_id:[0-9]+}/{version_id:[0-9]+}/preferred", app.PreferredVersion).
+		Methods("POST", "DELETE")
 
 	r.Handle("/{_:css|js|js/lib|fonts}/{.*}", http.FileServer(FS(false))).Methods("GET")
 
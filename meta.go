@@ -0,0 +1,362 @@
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/gorilla/mux"
+)
+
+// BookMeta describes the original of a book.
+type BookMeta struct {
+	SourceLang    string   `json:"source_lang,omitempty"`
+	TargetLang    string   `json:"target_lang,omitempty"`
+	Author        string   `json:"author,omitempty"`
+	OriginalTitle string   `json:"original_title,omitempty"`
+	SourceURL     string   `json:"source_url,omitempty"`
+	Notes         string   `json:"notes,omitempty"`
+	Tags          []string `json:"tags,omitempty"`
+}
+
+var rLangCode = regexp.MustCompile(`^[a-z]{2,3}(-[a-z0-9]+)*$`)
+
+// parseTags splits a comma separated list of tags, dropping the empty ones
+// and the duplicates.
+func parseTags(s string) []string {
+	var tags []string
+	seen := make(map[string]bool)
+	for _, t := range strings.Split(s, ",") {
+		t = strings.Join(strings.Fields(t), " ")
+		if t == "" || seen[strings.ToLower(t)] {
+			continue
+		}
+		seen[strings.ToLower(t)] = true
+		tags = append(tags, t)
+	}
+	return tags
+}
+
+func bookMetaFromRequest(r *http.Request) (BookMeta, error) {
+	return parseBookMeta(r.FormValue)
+}
+
+// parseBookMeta builds the metadata from the values of its fields, as they
+// are named in the book form.
+func parseBookMeta(value func(key string) string) (BookMeta, error) {
+	m := BookMeta{
+		SourceLang:    strings.ToLower(strings.TrimSpace(value("source_lang"))),
+		TargetLang:    strings.ToLower(strings.TrimSpace(value("target_lang"))),
+		Author:        strings.TrimSpace(value("author")),
+		OriginalTitle: strings.TrimSpace(value("original_title")),
+		SourceURL:     strings.TrimSpace(value("source_url")),
+		Notes:         strings.TrimSpace(value("notes")),
+		Tags:          parseTags(value("tags")),
+	}
+	for _, lang := range []string{m.SourceLang, m.TargetLang} {
+		if lang != "" && !rLangCode.MatchString(lang) {
+			return BookMeta{}, fmt.Errorf("invalid language code: %q", lang)
+		}
+	}
+	if m.SourceURL != "" {
+		u, err := url.Parse(m.SourceURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return BookMeta{}, fmt.Errorf("invalid URL: %q", m.SourceURL)
+		}
+	}
+	return m, nil
+}
+
+// Languages returns the language pair of the book, e.g. "en → ru", or an
+// empty string if neither language is known.
+func (m BookMeta) Languages() string {
+	if m.SourceLang == "" && m.TargetLang == "" {
+		return ""
+	}
+	src, dst := m.SourceLang, m.TargetLang
+	if src == "" {
+		src = "?"
+	}
+	if dst == "" {
+		dst = "?"
+	}
+	return src + " → " + dst
+}
+
+func (m BookMeta) TagList() string {
+	return strings.Join(m.Tags, ", ")
+}
+
+func (m BookMeta) HasTag(tag string) bool {
+	for _, t := range m.Tags {
+		if strings.EqualFold(t, tag) {
+			return true
+		}
+	}
+	return false
+}
+
+// The plain text and CSV exports start with the title and the metadata of
+// the book, a "# key: value" line or a "# key", value row per field, keyed
+// like the fields of the book form. The imports recognize them and give
+// the new book the metadata.
+
+// metaKeys are the keys of the metadata lines, in the order they are
+// written.
+var metaKeys = []string{"title", "author", "original_title", "source_lang", "target_lang", "source_url", "notes", "tags"}
+
+// metaRows returns the keys and values of the non-empty metadata fields of
+// the book.
+func (b Book) metaRows() [][]string {
+	var rows [][]string
+	for i, value := range []string{
+		b.Title,
+		b.Author,
+		b.OriginalTitle,
+		b.SourceLang,
+		b.TargetLang,
+		b.SourceURL,
+		b.Notes,
+		b.TagList(),
+	} {
+		if value != "" {
+			rows = append(rows, []string{"# " + metaKeys[i], value})
+		}
+	}
+	return rows
+}
+
+// metaKey returns the key of a metadata line or row, which is "# "
+// followed by one of metaKeys.
+func metaKey(s string) (string, bool) {
+	if !strings.HasPrefix(s, "# ") {
+		return "", false
+	}
+	key := s[2:]
+	for _, k := range metaKeys {
+		if k == key {
+			return key, true
+		}
+	}
+	return "", false
+}
+
+// The line breaks in the values of the metadata lines are escaped.
+var (
+	metaLineEscaper   = strings.NewReplacer(`\`, `\\`, "\n", `\n`, "\r", `\r`)
+	metaLineUnescaper = strings.NewReplacer(`\\`, `\`, `\n`, "\n", `\r`, "\r")
+)
+
+// metaLines returns the metadata lines of the book.
+func (b Book) metaLines() []string {
+	var lines []string
+	for _, row := range b.metaRows() {
+		lines = append(lines, row[0]+": "+metaLineEscaper.Replace(row[1]))
+	}
+	return lines
+}
+
+// cutMetaLines parses the metadata lines at the start of a plain text and
+// returns the rest of the text.
+func cutMetaLines(text string) (BookMeta, string, error) {
+	values := make(map[string]string)
+	lines := rNewline.Split(text, -1)
+	for len(lines) > 0 {
+		line := strings.TrimSpace(lines[0])
+		if line != "" {
+			i := strings.Index(line, ": ")
+			if i == -1 {
+				break
+			}
+			key, ok := metaKey(line[:i])
+			if !ok {
+				break
+			}
+			values[key] = metaLineUnescaper.Replace(line[i+2:])
+		}
+		lines = lines[1:]
+	}
+	m, err := parseBookMeta(func(key string) string { return values[key] })
+	return m, strings.Join(lines, "\n"), err
+}
+
+// cutMetaRows parses the metadata rows at the start of a CSV file and
+// returns the rest of the rows.
+func cutMetaRows(records [][]string) (BookMeta, [][]string, error) {
+	values := make(map[string]string)
+	for len(records) > 0 {
+		key, ok := metaKey(records[0][0])
+		if !ok {
+			break
+		}
+		values[key] = records[0][1]
+		records = records[1:]
+	}
+	m, err := parseBookMeta(func(key string) string { return values[key] })
+	return m, records, err
+}
+
+// bookIndexFilter restricts the books shown on the index page.
+type bookIndexFilter struct {
+	Tag    string
+	Lang   string
+	Author string
+}
+
+func bookIndexFilterFromRequest(r *http.Request) bookIndexFilter {
+	return bookIndexFilter{
+		Tag:    strings.TrimSpace(r.FormValue("tag")),
+		Lang:   strings.ToLower(strings.TrimSpace(r.FormValue("lang"))),
+		Author: strings.TrimSpace(r.FormValue("author")),
+	}
+}
+
+func (f bookIndexFilter) empty() bool { return f == bookIndexFilter{} }
+
+func (f bookIndexFilter) match(b Book) bool {
+	return (f.Tag == "" || b.HasTag(f.Tag)) &&
+		(f.Lang == "" || b.SourceLang == f.Lang || b.TargetLang == f.Lang) &&
+		(f.Author == "" || strings.EqualFold(b.Author, f.Author))
+}
+
+func filterBooks(books []Book, f bookIndexFilter) []Book {
+	if f.empty() {
+		return books
+	}
+	var filtered []Book
+	for _, b := range books {
+		if f.match(b) {
+			filtered = append(filtered, b)
+		}
+	}
+	return filtered
+}
+
+// bookGroup is a heading of the index page with the books under it.
+type bookGroup struct {
+	Name  string
+	Books []Book
+}
+
+// groupBooks groups the books by author, language pair, tag or collection,
+// keeping their order within a group. A book with several tags is in
+// several groups. The books which have nothing to group by come last, in a
+// group without a name.
+func groupBooks(books []Book, by string) []bookGroup {
+	keys := func(b Book) []string {
+		switch by {
+		case "author":
+			return []string{b.Author}
+		case "lang":
+			return []string{b.Languages()}
+		case "collection":
+			return []string{b.Collection}
+		case "tag":
+			if len(b.Tags) == 0 {
+				return []string{""}
+			}
+			return b.Tags
+		}
+		return []string{""}
+	}
+	index := make(map[string]int)
+	var groups []bookGroup
+	for _, b := range books {
+		for _, k := range keys(b) {
+			i, ok := index[strings.ToLower(k)]
+			if !ok {
+				i = len(groups)
+				index[strings.ToLower(k)] = i
+				groups = append(groups, bookGroup{Name: k})
+			}
+			groups[i].Books = append(groups[i].Books, b)
+		}
+	}
+	sort.SliceStable(groups, func(i, j int) bool {
+		a, b := groups[i].Name, groups[j].Name
+		if a == "" || b == "" {
+			return b == "" && a != ""
+		}
+		return strings.ToLower(a) < strings.ToLower(b)
+	})
+	return groups
+}
+
+// bookFacets lists the values of the metadata of the books which the index
+// page can be filtered by.
+type bookFacets struct {
+	Tags    []string
+	Langs   []string
+	Authors []string
+}
+
+func facets(books []Book) bookFacets {
+	var f bookFacets
+	seen := make(map[string]bool)
+	add := func(list *[]string, kind, v string) {
+		if v == "" || seen[kind+strings.ToLower(v)] {
+			return
+		}
+		seen[kind+strings.ToLower(v)] = true
+		*list = append(*list, v)
+	}
+	for _, b := range books {
+		for _, t := range b.Tags {
+			add(&f.Tags, "tag", t)
+		}
+		add(&f.Langs, "lang", b.SourceLang)
+		add(&f.Langs, "lang", b.TargetLang)
+		add(&f.Authors, "author", b.Author)
+	}
+	for _, list := range [][]string{f.Tags, f.Langs, f.Authors} {
+		sort.Slice(list, func(i, j int) bool { return strings.ToLower(list[i]) < strings.ToLower(list[j]) })
+	}
+	return f
+}
+
+func (db *DB) UpdateBookMeta(bid uint64, m BookMeta) error {
+	return db.updateBookInfo(bid, func(book *bookInfo) { book.BookMeta = m })
+}
+
+func (a *App) BookMeta(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	bid, err := u64(vars["book_id"])
+	if err != nil {
+		http.Error(w, "Invalid book ID", http.StatusBadRequest)
+		return
+	}
+
+	m, err := bookMetaFromRequest(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := a.db.UpdateBookMeta(bid, m); err != nil {
+		if err == ErrNotFound {
+			http.Error(w, "Book not found", 404)
+			return
+		}
+		internalError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(m)
+}
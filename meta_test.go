@@ -0,0 +1,60 @@
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestMetaRoundTrip checks that the metadata written to the plain text and
+// CSV exports is read back by the imports.
+func TestMetaRoundTrip(t *testing.T) {
+	var book Book
+	book.Title = "Emma"
+	book.BookMeta = BookMeta{
+		SourceLang:    "en",
+		TargetLang:    "ru",
+		Author:        "Jane Austen",
+		OriginalTitle: "Emma",
+		SourceURL:     "https://example.com/emma",
+		Notes:         "The 1815 edition,\nwith a \\n in the notes.",
+		Tags:          []string{"classic", "novel"},
+	}
+
+	text := strings.Join(book.metaLines(), "\n") + "\n\n# First fragment\nSecond fragment\n"
+	m, rest, err := cutMetaLines(text)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(m, book.BookMeta) {
+		t.Errorf("plain text: got %+v, want %+v", m, book.BookMeta)
+	}
+	if got := split(rest); !reflect.DeepEqual(got, []string{"# First fragment", "Second fragment"}) {
+		t.Errorf("plain text: got fragments %q", got)
+	}
+
+	records := append(book.metaRows(), []string{"# First fragment", "Перевод"})
+	m, records, err = cutMetaRows(records)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(m, book.BookMeta) {
+		t.Errorf("CSV: got %+v, want %+v", m, book.BookMeta)
+	}
+	if len(records) != 1 || records[0][0] != "# First fragment" {
+		t.Errorf("CSV: got fragments %q", records)
+	}
+}
@@ -0,0 +1,115 @@
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/boltdb/bolt"
+)
+
+type migration struct {
+	description string
+	migrate     func(tx *bolt.Tx) error
+}
+
+// migrations[i] upgrades the database from schema version i to i+1.
+// Never change or reorder existing migrations; append new ones instead.
+var migrations = []migration{
+	{"create the initial buckets", createBuckets("index", "fragments", "versions", "scratchpad")},
+	{"create the trash and history buckets", createBuckets("trash", "history", "source_history")},
+	{"build the filter indexes", buildFilterIndexes},
+	{"move the fragment order and the stats out of the book records", splitBookRecords},
+	{"create the glossary bucket", createBuckets("glossary")},
+	{"create the saved filters bucket", createBuckets("filters")},
+	{"move the comments into comment threads", upgradeComments},
+	{"count the fragments in review and approved", countFilterSets},
+	{"count the translated fragments by day", countTranslatedDays},
+	{"count the fragments by blocks of the order", countOrderBlocks},
+	{"rebuild the filter indexes in the order of the books", rebuildFilterIndexes},
+}
+
+func createBuckets(names ...string) func(tx *bolt.Tx) error {
+	return func(tx *bolt.Tx) error {
+		for _, name := range names {
+			if _, err := tx.CreateBucketIfNotExists([]byte(name)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+func schemaVersion(tx *bolt.Tx) (int, error) {
+	b := tx.Bucket([]byte("meta"))
+	if b == nil {
+		return 0, nil
+	}
+	data := b.Get([]byte("schema_version"))
+	if data == nil {
+		return 0, nil
+	}
+	return strconv.Atoi(string(data))
+}
+
+// migrate brings the database up to the latest schema version. A copy of
+// an existing database is saved next to it before any migration is run.
+func migrate(db *bolt.DB, path string, mode os.FileMode) error {
+	var version int
+	var empty bool
+	if err := db.View(func(tx *bolt.Tx) error {
+		var err error
+		version, err = schemaVersion(tx)
+		empty = tx.Bucket([]byte("index")) == nil
+		return err
+	}); err != nil {
+		return err
+	}
+	if version > len(migrations) {
+		return fmt.Errorf("database schema version %d is newer than supported (%d)", version, len(migrations))
+	}
+	if version == len(migrations) {
+		return nil
+	}
+
+	if !empty {
+		backup := fmt.Sprintf("%s.v%d-%s.bak", path, version, time.Now().Format("20060102150405"))
+		if err := db.View(func(tx *bolt.Tx) error {
+			return tx.CopyFile(backup, mode)
+		}); err != nil {
+			return fmt.Errorf("backup before migration: %v", err)
+		}
+		log.Printf("saved a backup of the database to %s", backup)
+	}
+
+	return db.Update(func(tx *bolt.Tx) error {
+		for i := version; i < len(migrations); i++ {
+			if !empty {
+				log.Printf("migrating the database to schema version %d: %s", i+1, migrations[i].description)
+			}
+			if err := migrations[i].migrate(tx); err != nil {
+				return fmt.Errorf("migration to schema version %d: %v", i+1, err)
+			}
+		}
+		b, err := tx.CreateBucketIfNotExists([]byte("meta"))
+		if err != nil {
+			return err
+		}
+		return b.Put([]byte("schema_version"), []byte(strconv.Itoa(len(migrations))))
+	})
+}
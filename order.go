@@ -0,0 +1,360 @@
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"encoding/binary"
+	"encoding/json"
+
+	"github.com/boltdb/bolt"
+)
+
+// The order of the fragments of a book is kept in two buckets:
+//
+//	order/{book ID}/{order key}   -> fragment ID
+//	positions/{book ID}/{fragment ID} -> order key
+//
+// and order_counts/{book ID}/{block} counts the keys of every block of
+// 1<<orderBlockShift keys, so that the sequence number of a fragment can be
+// found without counting all the fragments before it.
+//
+// Order keys are big-endian, so that a cursor walks the fragments in
+// order. They are spaced orderGap apart; a fragment inserted between two
+// others gets the key halfway between theirs. When there is no room left,
+// only the fragments that follow, up to the first one far enough away, are
+// spaced out again, so that inserting many fragments in a row does not
+// rewrite the order of the whole book.
+
+const (
+	orderGap = 1 << 20
+	// minOrderGap is the room left before a fragment when the fragments
+	// that follow it are spaced out.
+	minOrderGap = orderGap >> 4
+	// orderBlockShift makes a block hold 256 fragments appended to a book.
+	orderBlockShift = 28
+)
+
+func orderKey(k uint64) []byte {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, k)
+	return b
+}
+
+// bookOrder holds the buckets of the order of a book.
+type bookOrder struct {
+	tx             *bolt.Tx
+	bid            uint64
+	ob, pb, counts *bolt.Bucket
+}
+
+func orderBuckets(tx *bolt.Tx, bid uint64) (*bookOrder, error) {
+	o := &bookOrder{tx: tx, bid: bid}
+	var err error
+	if o.ob, err = tx.Bucket([]byte("order")).CreateBucketIfNotExists(encode(bid)); err != nil {
+		return nil, err
+	}
+	if o.pb, err = tx.Bucket([]byte("positions")).CreateBucketIfNotExists(encode(bid)); err != nil {
+		return nil, err
+	}
+	if o.counts, err = tx.Bucket([]byte("order_counts")).CreateBucketIfNotExists(encode(bid)); err != nil {
+		return nil, err
+	}
+	return o, nil
+}
+
+func orderBlock(k uint64) []byte {
+	return orderKey(k >> orderBlockShift)
+}
+
+// countBlock adds n to the count of the keys of the block of k.
+func (o *bookOrder) countBlock(k uint64, n int) error {
+	block := orderBlock(k)
+	var count uint64
+	if v := o.counts.Get(block); v != nil {
+		count = decode(v)
+	}
+	count += uint64(n)
+	if count == 0 {
+		return o.counts.Delete(block)
+	}
+	return o.counts.Put(block, encode(count))
+}
+
+func (o *bookOrder) put(k, fid uint64) error {
+	if err := o.ob.Put(orderKey(k), encode(fid)); err != nil {
+		return err
+	}
+	if err := o.pb.Put(encode(fid), orderKey(k)); err != nil {
+		return err
+	}
+	return o.countBlock(k, 1)
+}
+
+func (o *bookOrder) delete(k uint64) error {
+	if err := o.ob.Delete(orderKey(k)); err != nil {
+		return err
+	}
+	return o.countBlock(k, -1)
+}
+
+// fragmentIDs returns the IDs of the fragments of the book in order.
+func fragmentIDs(tx *bolt.Tx, bid uint64) []uint64 {
+	ids := []uint64{}
+	ob := tx.Bucket([]byte("order")).Bucket(encode(bid))
+	if ob == nil {
+		return ids
+	}
+	c := ob.Cursor()
+	for k, v := c.First(); k != nil; k, v = c.Next() {
+		ids = append(ids, decode(v))
+	}
+	return ids
+}
+
+func hasFragment(tx *bolt.Tx, bid, fid uint64) bool {
+	pb := tx.Bucket([]byte("positions")).Bucket(encode(bid))
+	return pb != nil && pb.Get(encode(fid)) != nil
+}
+
+// appendFragments puts the fragments at the end of the book.
+func appendFragments(tx *bolt.Tx, bid uint64, fids ...uint64) error {
+	o, err := orderBuckets(tx, bid)
+	if err != nil {
+		return err
+	}
+	var last uint64
+	if k, _ := o.ob.Cursor().Last(); k != nil {
+		last = binary.BigEndian.Uint64(k)
+	}
+	for _, fid := range fids {
+		last += orderGap
+		if err := o.put(last, fid); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// insertFragment puts the fragment after the fragment fidAfter, or at the
+// beginning of the book if fidAfter is 0.
+func insertFragment(tx *bolt.Tx, bid, fidAfter, fid uint64) error {
+	o, err := orderBuckets(tx, bid)
+	if err != nil {
+		return err
+	}
+	c := o.ob.Cursor()
+	var lo uint64
+	var next []byte
+	if fidAfter == 0 {
+		next, _ = c.First()
+	} else {
+		key := o.pb.Get(encode(fidAfter))
+		if key == nil {
+			return ErrNotFound
+		}
+		lo = binary.BigEndian.Uint64(key)
+		c.Seek(key)
+		next, _ = c.Next()
+	}
+	if next == nil {
+		return o.put(lo+orderGap, fid)
+	}
+	if hi := binary.BigEndian.Uint64(next); hi-lo >= 2 {
+		return o.put(lo+(hi-lo)/2, fid)
+	}
+	if err := o.space(lo); err != nil {
+		return err
+	}
+	return insertFragment(tx, bid, fidAfter, fid)
+}
+
+// space spaces out evenly the keys that follow the key lo, so that there
+// is at least minOrderGap between lo and the next key.
+func (o *bookOrder) space(lo uint64) error {
+	var keys, fids []uint64
+	var hi uint64
+	c := o.ob.Cursor()
+	for k, v := c.Seek(orderKey(lo + 1)); ; k, v = c.Next() {
+		if k == nil {
+			// There is no limit past the last fragment.
+			hi = lo + uint64(len(keys)+2)*orderGap
+			break
+		}
+		key := binary.BigEndian.Uint64(k)
+		if len(keys) > 0 && key-lo >= uint64(len(keys)+2)*minOrderGap {
+			hi = key
+			break
+		}
+		keys = append(keys, key)
+		fids = append(fids, decode(v))
+	}
+	for _, key := range keys {
+		if err := o.delete(key); err != nil {
+			return err
+		}
+	}
+	step := (hi - lo) / uint64(len(keys)+2)
+	for i, fid := range fids {
+		if err := o.put(lo+step*uint64(i+2), fid); err != nil {
+			return err
+		}
+		if err := moveIndexEntry(o.tx, o.bid, fid); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// removeFragmentOrder removes the fragment from the order of the book.
+func removeFragmentOrder(tx *bolt.Tx, bid, fid uint64) error {
+	o, err := orderBuckets(tx, bid)
+	if err != nil {
+		return err
+	}
+	key := o.pb.Get(encode(fid))
+	if key == nil {
+		return nil
+	}
+	if err := o.delete(binary.BigEndian.Uint64(key)); err != nil {
+		return err
+	}
+	return o.pb.Delete(encode(fid))
+}
+
+// setFragmentOrder replaces the order of the book with fids, spacing the
+// keys evenly.
+func setFragmentOrder(tx *bolt.Tx, bid uint64, fids []uint64) error {
+	for _, name := range []string{"order", "positions", "order_counts"} {
+		if err := tx.Bucket([]byte(name)).DeleteBucket(encode(bid)); err != nil && err != bolt.ErrBucketNotFound {
+			return err
+		}
+	}
+	if err := appendFragments(tx, bid, fids...); err != nil {
+		return err
+	}
+	for _, fid := range fids {
+		if err := moveIndexEntry(tx, bid, fid); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// seqNums returns the sequence numbers of the fragments with the order
+// keys, which must be sorted. The fragments before the block of a key are
+// counted by blocks, only those of its own block one by one.
+func seqNums(tx *bolt.Tx, bid uint64, keys []uint64) []int {
+	nums := make([]int, len(keys))
+	ob := tx.Bucket([]byte("order")).Bucket(encode(bid))
+	cb := tx.Bucket([]byte("order_counts")).Bucket(encode(bid))
+	if ob == nil || cb == nil {
+		return nums
+	}
+	blocks := cb.Cursor()
+	block, count := blocks.First()
+	before := 0 // the fragments in the blocks before block
+	c := ob.Cursor()
+	var k []byte
+	n := 0 // the sequence number of k
+	for i, key := range keys {
+		kb := key >> orderBlockShift
+		if k == nil || binary.BigEndian.Uint64(k)>>orderBlockShift != kb {
+			for block != nil && binary.BigEndian.Uint64(block) < kb {
+				before += int(decode(count))
+				block, count = blocks.Next()
+			}
+			k, _ = c.Seek(orderKey(kb << orderBlockShift))
+			n = before + 1
+		}
+		for k != nil && binary.BigEndian.Uint64(k) < key {
+			k, _ = c.Next()
+			n++
+		}
+		nums[i] = n
+	}
+	return nums
+}
+
+// splitBookRecords moves the fragment order and the stats of every book out
+// of its record into the order, positions and stats buckets.
+func splitBookRecords(tx *bolt.Tx) error {
+	for _, name := range []string{"order", "positions", "order_counts", "stats"} {
+		if _, err := tx.CreateBucketIfNotExists([]byte(name)); err != nil {
+			return err
+		}
+	}
+	for _, name := range []string{"index", "trash"} {
+		b := tx.Bucket([]byte(name))
+		var books []Book
+		if err := b.ForEach(func(_, v []byte) error {
+			var book Book
+			if err := json.Unmarshal(v, &book); err != nil {
+				return err
+			}
+			books = append(books, book)
+			return nil
+		}); err != nil {
+			return err
+		}
+		for _, book := range books {
+			if err := setFragmentOrder(tx, book.ID, book.FragmentsIDs); err != nil {
+				return err
+			}
+			if err := putStats(tx, book.ID, book.bookStats); err != nil {
+				return err
+			}
+			if err := marshal(b, book.ID, book.bookInfo); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// countOrderBlocks counts the fragments of every block of the order of
+// every book, for the books ordered before the blocks were counted.
+func countOrderBlocks(tx *bolt.Tx) error {
+	cb, err := tx.CreateBucketIfNotExists([]byte("order_counts"))
+	if err != nil {
+		return err
+	}
+	var bids [][]byte
+	if err := tx.Bucket([]byte("order")).ForEach(func(k, v []byte) error {
+		if v == nil {
+			bids = append(bids, k)
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+	for _, bid := range bids {
+		counts := make(map[uint64]uint64)
+		if err := tx.Bucket([]byte("order")).Bucket(bid).ForEach(func(k, _ []byte) error {
+			counts[binary.BigEndian.Uint64(k)>>orderBlockShift]++
+			return nil
+		}); err != nil {
+			return err
+		}
+		b, err := cb.CreateBucketIfNotExists(bid)
+		if err != nil {
+			return err
+		}
+		for block, n := range counts {
+			if err := b.Put(orderKey(block), encode(n)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,178 @@
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/boltdb/bolt"
+)
+
+// The benchmarks compare the order buckets with the order kept, as it was
+// before, in a FragmentsIDs slice of the book record.
+
+var benchmarkSizes = []int{100, 20000}
+
+const benchmarkPageSize = 50
+
+type recordOrder struct {
+	FragmentsIDs []uint64 `json:"fragments_ids"`
+}
+
+func openBenchmarkDB(b *testing.B) DB {
+	db, err := OpenDatabase(filepath.Join(b.TempDir(), "tl.db"), 0600, nil)
+	if err != nil {
+		b.Fatal(err)
+	}
+	db.NoSync = true
+	b.Cleanup(func() { db.Close() })
+	return db
+}
+
+// setupOrders puts a book of n fragments in both the order buckets and a
+// book record.
+func setupOrders(b *testing.B, db DB, n int) {
+	fids := make([]uint64, n)
+	for i := range fids {
+		fids[i] = uint64(i + 1)
+	}
+	if err := db.Update(func(tx *bolt.Tx) error {
+		if _, err := tx.CreateBucketIfNotExists([]byte("record_order")); err != nil {
+			return err
+		}
+		if err := marshal(tx.Bucket([]byte("record_order")), 1, recordOrder{fids}); err != nil {
+			return err
+		}
+		return setFragmentOrder(tx, 1, fids)
+	}); err != nil {
+		b.Fatal(err)
+	}
+}
+
+func updateRecordOrder(tx *bolt.Tx, fn func(fids []uint64) []uint64) error {
+	rb := tx.Bucket([]byte("record_order"))
+	var o recordOrder
+	if _, err := unmarshal(rb, 1, &o); err != nil {
+		return err
+	}
+	o.FragmentsIDs = fn(o.FragmentsIDs)
+	return marshal(rb, 1, o)
+}
+
+func insertAfter(fids []uint64, after, fid uint64) []uint64 {
+	i := idx(fids, after) + 1
+	fids = append(fids, 0)
+	copy(fids[i+1:], fids[i:])
+	fids[i] = fid
+	return fids
+}
+
+func removeID(fids []uint64, fid uint64) []uint64 {
+	i := idx(fids, fid)
+	return append(fids[:i], fids[i+1:]...)
+}
+
+// benchmarkOrders runs op on books of every size, against the book record
+// and the order buckets.
+func benchmarkOrders(b *testing.B, record, buckets func(tx *bolt.Tx, n, i int) error, writable bool) {
+	for _, n := range benchmarkSizes {
+		for _, bm := range []struct {
+			name string
+			op   func(tx *bolt.Tx, n, i int) error
+		}{
+			{"record", record},
+			{"buckets", buckets},
+		} {
+			b.Run(fmt.Sprintf("%s/%d", bm.name, n), func(b *testing.B) {
+				db := openBenchmarkDB(b)
+				setupOrders(b, db, n)
+				b.ResetTimer()
+				for i := 0; i < b.N; i++ {
+					run := db.View
+					if writable {
+						run = db.Update
+					}
+					if err := run(func(tx *bolt.Tx) error { return bm.op(tx, n, i) }); err != nil {
+						b.Fatal(err)
+					}
+				}
+			})
+		}
+	}
+}
+
+// BenchmarkInsertFragment inserts a fragment in the middle of the book.
+func BenchmarkInsertFragment(b *testing.B) {
+	benchmarkOrders(b,
+		func(tx *bolt.Tx, n, i int) error {
+			return updateRecordOrder(tx, func(fids []uint64) []uint64 {
+				return insertAfter(fids, uint64(n/2), uint64(n+i+1))
+			})
+		},
+		func(tx *bolt.Tx, n, i int) error {
+			return insertFragment(tx, 1, uint64(n/2), uint64(n+i+1))
+		},
+		true)
+}
+
+// BenchmarkMoveFragment moves a fragment from the end of the book to its
+// beginning.
+func BenchmarkMoveFragment(b *testing.B) {
+	benchmarkOrders(b,
+		func(tx *bolt.Tx, n, i int) error {
+			return updateRecordOrder(tx, func(fids []uint64) []uint64 {
+				fid := fids[len(fids)-1]
+				return append([]uint64{fid}, removeID(fids, fid)...)
+			})
+		},
+		func(tx *bolt.Tx, n, i int) error {
+			k, v := tx.Bucket([]byte("order")).Bucket(encode(1)).Cursor().Last()
+			if k == nil {
+				return ErrNotFound
+			}
+			fid := decode(v)
+			if err := removeFragmentOrder(tx, 1, fid); err != nil {
+				return err
+			}
+			return insertFragment(tx, 1, 0, fid)
+		},
+		true)
+}
+
+// BenchmarkLoadPage reads the IDs of the fragments of a page in the middle
+// of the book.
+func BenchmarkLoadPage(b *testing.B) {
+	benchmarkOrders(b,
+		func(tx *bolt.Tx, n, i int) error {
+			var o recordOrder
+			if _, err := unmarshal(tx.Bucket([]byte("record_order")), 1, &o); err != nil {
+				return err
+			}
+			from := n / 2
+			if len(o.FragmentsIDs[from:from+benchmarkPageSize]) != benchmarkPageSize {
+				return ErrNotFound
+			}
+			return nil
+		},
+		func(tx *bolt.Tx, n, i int) error {
+			from := n / 2
+			if len(fragmentIDs(tx, 1)[from:from+benchmarkPageSize]) != benchmarkPageSize {
+				return ErrNotFound
+			}
+			return nil
+		},
+		false)
+}
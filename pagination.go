@@ -34,19 +34,37 @@ func (v query) Page(n int) template.URL {
 	return template.URL(v.Encode())
 }
 
+// Has reports whether one of the values of the key is value.
+func (v query) Has(key, value string) bool {
+	for _, s := range v.Values[key] {
+		if s == value {
+			return true
+		}
+	}
+	return false
+}
+
+// Pagination renders the page links. If More is set, TotalItems are only
+// the items up to the end of the page and there are more, so the pages
+// end with the next one.
 type Pagination struct {
 	url          *url.URL
 	PageNumber   int
 	TotalItems   int
+	More         bool
 	itemsPerPage int
 }
 
 func (p Pagination) TotalPages() int {
-	return (p.TotalItems + p.itemsPerPage - 1) / p.itemsPerPage
+	tp := (p.TotalItems + p.itemsPerPage - 1) / p.itemsPerPage
+	if p.More {
+		tp++
+	}
+	return tp
 }
 
 func (p Pagination) Render() template.HTML {
-	if p.TotalItems <= p.itemsPerPage {
+	if p.TotalItems <= p.itemsPerPage && !p.More {
 		return ""
 	}
 	path := html.EscapeString(p.url.Path)
@@ -89,7 +107,7 @@ func (p Pagination) Render() template.HTML {
 }
 
 func (p Pagination) RenderPrevNextButtons() template.HTML {
-	if p.TotalItems <= p.itemsPerPage {
+	if p.TotalItems <= p.itemsPerPage && !p.More {
 		return ""
 	}
 	path := html.EscapeString(p.url.Path)
@@ -0,0 +1,117 @@
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"net/http"
+
+	"github.com/boltdb/bolt"
+	"github.com/gorilla/mux"
+)
+
+// ChosenVersion returns the version which is the translation of the
+// fragment: the preferred one or, if none is chosen, the first version (or
+// the last one if last is true). ok is false if there are no versions.
+func (f Fragment) ChosenVersion(last bool) (v TranslationVersion, ok bool) {
+	if len(f.Versions) == 0 {
+		return TranslationVersion{}, false
+	}
+	for _, v := range f.Versions {
+		if v.ID == f.PreferredVersion {
+			return v, true
+		}
+	}
+	if last {
+		return f.Versions[len(f.Versions)-1], true
+	}
+	return f.Versions[0], true
+}
+
+// Translation returns the text of the chosen version, or an empty string
+// if the fragment isn't translated.
+func (f Fragment) Translation(last bool) string {
+	v, _ := f.ChosenVersion(last)
+	return v.Text
+}
+
+// SetPreferredVersion marks the version as the chosen translation of the
+// fragment; vidOrZero of 0 clears the choice.
+func (db *DB) SetPreferredVersion(bid, fid, vidOrZero uint64) error {
+	return db.Update(func(tx *bolt.Tx) error {
+		if _, err := indexedBook(tx, bid); err != nil {
+			return err
+		}
+		if !hasFragment(tx, bid, fid) {
+			return ErrNotFound
+		}
+
+		fb := tx.Bucket([]byte("fragments")).Bucket(encode(bid))
+		var f Fragment
+		if found, err := unmarshal(fb, fid, &f); err != nil {
+			return err
+		} else if !found {
+			return ErrNotFound
+		}
+		if vidOrZero != 0 && !has(f.VersionsIDs, vidOrZero) {
+			return ErrNotFound
+		}
+		if f.PreferredVersion == vidOrZero {
+			return nil
+		}
+
+		f.PreferredVersion = vidOrZero
+		if err := marshal(fb, fid, f); err != nil {
+			return err
+		}
+
+		return reindexFragment(tx, bid, fid)
+	})
+}
+
+func (a *App) PreferredVersion(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	bid, err := u64(vars["book_id"])
+	if err != nil {
+		http.Error(w, "Invalid book ID", http.StatusBadRequest)
+		return
+	}
+	fid, err := u64(vars["fragment_id"])
+	if err != nil {
+		http.Error(w, "Invalid fragment ID", http.StatusBadRequest)
+		return
+	}
+	vid, err := u64(vars["version_id"])
+	if err != nil {
+		http.Error(w, "Invalid version ID", http.StatusBadRequest)
+		return
+	}
+
+	switch r.Method {
+	case "POST":
+		err = a.db.SetPreferredVersion(bid, fid, vid)
+	case "DELETE":
+		err = a.db.SetPreferredVersion(bid, fid, 0)
+	}
+	if err != nil {
+		if err == ErrNotFound {
+			http.Error(w, "Version not found", 404)
+			return
+		}
+		internalError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
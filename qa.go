@@ -0,0 +1,239 @@
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// qaMinLength is the length of the shortest original whose translation is
+// checked by the length ratio check.
+const qaMinLength = 20
+
+var (
+	rNumber        = regexp.MustCompile(`\d+`)
+	rDoubleSpace   = regexp.MustCompile(`[^\s] {2,}[^\s]`)
+	rTrailingSpace = regexp.MustCompile(`(?m)[ \t]+$|^[ \t]+`)
+)
+
+// QAIssue is a problem found in a translation version by a QA check.
+type QAIssue struct {
+	VersionID uint64 `json:"version_id"`
+	Check     string `json:"check"`
+	Message   string `json:"message"`
+}
+
+// qaCheck checks a translation of an original and returns a description
+// of the problem found, if any.
+type qaCheck struct {
+	name  string
+	title string
+	check func(orig, trans string) string
+}
+
+var qaChecks = []qaCheck{
+	{"numbers", "Numbers missing in the translation", qaNumbers},
+	{"brackets", "Mismatched brackets or quotes", qaBrackets},
+	{"spaces", "Trailing or double spaces", qaSpaces},
+	{"identical", "Translation identical to the original", qaIdentical},
+	{"length", "Suspicious length ratio", qaLength},
+	{"repeats", "Repeated words", qaRepeats},
+}
+
+// selectedQAChecks returns the checks with the names given, or all checks
+// if names is empty.
+func selectedQAChecks(names []string) []qaCheck {
+	if len(names) == 0 {
+		return qaChecks
+	}
+	var checks []qaCheck
+	for _, c := range qaChecks {
+		for _, name := range names {
+			if c.name == name {
+				checks = append(checks, c)
+				break
+			}
+		}
+	}
+	return checks
+}
+
+// qaCheckOption is a QA check as shown in the filter menu.
+type qaCheckOption struct {
+	Name     string
+	Title    string
+	Selected bool
+}
+
+// qaCheckOptions returns the options for all the checks; if none of them is
+// selected, all are.
+func qaCheckOptions(selected []string) []qaCheckOption {
+	options := make([]qaCheckOption, len(qaChecks))
+	for i, c := range qaChecks {
+		options[i] = qaCheckOption{c.name, c.title, len(selected) == 0}
+		for _, name := range selected {
+			if c.name == name {
+				options[i].Selected = true
+			}
+		}
+	}
+	return options
+}
+
+// qaIssues runs the checks over the translation versions of a fragment.
+func qaIssues(orig string, versions []TranslationVersion, checks []qaCheck) []QAIssue {
+	var issues []QAIssue
+	for _, v := range versions {
+		for _, c := range checks {
+			if msg := c.check(orig, v.Text); msg != "" {
+				issues = append(issues, QAIssue{v.ID, c.name, msg})
+			}
+		}
+	}
+	return issues
+}
+
+// VersionIssues returns the QA issues found in the translation version.
+func (f Fragment) VersionIssues(vid uint64) []QAIssue {
+	var issues []QAIssue
+	for _, issue := range f.Issues {
+		if issue.VersionID == vid {
+			issues = append(issues, issue)
+		}
+	}
+	return issues
+}
+
+func (f Fragment) versionText(vid uint64) string {
+	for _, v := range f.Versions {
+		if v.ID == vid {
+			return v.Text
+		}
+	}
+	return ""
+}
+
+func qaNumbers(orig, trans string) string {
+	count := make(map[string]int)
+	for _, n := range rNumber.FindAllString(trans, -1) {
+		count[n]++
+	}
+	var missing []string
+	for _, n := range rNumber.FindAllString(orig, -1) {
+		if count[n] > 0 {
+			count[n]--
+		} else {
+			missing = append(missing, n)
+		}
+	}
+	if len(missing) == 0 {
+		return ""
+	}
+	return "Missing: " + strings.Join(missing, ", ")
+}
+
+var bracketPairs = []struct{ open, close rune }{
+	{'(', ')'},
+	{'[', ']'},
+	{'{', '}'},
+}
+
+// quotesBalanced reports whether the quotation marks of the text are
+// paired. “ closes „ in Russian and opens ” in English.
+func quotesBalanced(s string) bool {
+	count := func(r rune) int { return strings.Count(s, string(r)) }
+	return count('«') == count('»') &&
+		count('“') == count('„')+count('”') &&
+		count('"')%2 == 0
+}
+
+func qaBrackets(orig, trans string) string {
+	var problems []string
+	for _, p := range bracketPairs {
+		o := strings.Count(orig, string(p.open)) - strings.Count(orig, string(p.close))
+		t := strings.Count(trans, string(p.open)) - strings.Count(trans, string(p.close))
+		if o != t {
+			problems = append(problems, string(p.open)+string(p.close))
+		}
+	}
+	if quotesBalanced(orig) && !quotesBalanced(trans) {
+		problems = append(problems, "quotes")
+	}
+	if len(problems) == 0 {
+		return ""
+	}
+	return "Unbalanced: " + strings.Join(problems, ", ")
+}
+
+func qaSpaces(orig, trans string) string {
+	var problems []string
+	if rTrailingSpace.MatchString(trans) {
+		problems = append(problems, "leading or trailing spaces")
+	}
+	if rDoubleSpace.MatchString(trans) {
+		problems = append(problems, "double spaces")
+	}
+	if len(problems) == 0 {
+		return ""
+	}
+	s := strings.Join(problems, ", ")
+	return strings.ToUpper(s[:1]) + s[1:]
+}
+
+func qaIdentical(orig, trans string) string {
+	if strings.IndexFunc(orig, unicode.IsLetter) < 0 {
+		return ""
+	}
+	if strings.TrimSpace(orig) != strings.TrimSpace(trans) {
+		return ""
+	}
+	return "The translation is the same as the original"
+}
+
+func qaLength(orig, trans string) string {
+	o := utf8.RuneCountInString(orig)
+	if o < qaMinLength {
+		return ""
+	}
+	ratio := float64(utf8.RuneCountInString(trans)) / float64(o)
+	if ratio >= *qaMinRatio && ratio <= *qaMaxRatio {
+		return ""
+	}
+	return fmt.Sprintf("The translation is %.1f times as long as the original", ratio)
+}
+
+func qaRepeats(orig, trans string) string {
+	idxs := rWord.FindAllStringIndex(trans, -1)
+	var repeated []string
+	for i := 1; i < len(idxs); i++ {
+		prev := trans[idxs[i-1][0]:idxs[i-1][1]]
+		w := trans[idxs[i][0]:idxs[i][1]]
+		between := trans[idxs[i-1][1]:idxs[i][0]]
+		if strings.TrimSpace(between) != "" || !strings.EqualFold(prev, w) {
+			continue
+		}
+		if r, _ := utf8.DecodeRuneInString(w); !unicode.IsLetter(r) {
+			continue
+		}
+		repeated = append(repeated, w)
+	}
+	if len(repeated) == 0 {
+		return ""
+	}
+	return "Repeated: " + strings.Join(repeated, ", ")
+}
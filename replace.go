@@ -0,0 +1,209 @@
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"encoding/json"
+	"html/template"
+	"net/http"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/gorilla/mux"
+)
+
+// replaceOptions describe a find and replace operation.
+type replaceOptions struct {
+	Find         string
+	Replace      string
+	Regexp       bool
+	MatchCase    bool
+	PreserveCase bool
+}
+
+func replaceOptionsFromRequest(r *http.Request) replaceOptions {
+	return replaceOptions{
+		Find:         r.FormValue("find"),
+		Replace:      r.FormValue("replace"),
+		Regexp:       r.FormValue("regexp") == "1",
+		MatchCase:    r.FormValue("case") == "1",
+		PreserveCase: r.FormValue("preserve") == "1",
+	}
+}
+
+// withCaseOf changes the case of the replacement to follow the matched
+// text: all capitals or a capitalized first letter.
+func withCaseOf(repl, match string) string {
+	if strings.ToUpper(match) == match && strings.ToLower(match) != match && utf8.RuneCountInString(match) > 1 {
+		return strings.ToUpper(repl)
+	}
+	r, _ := utf8.DecodeRuneInString(match)
+	if repl == "" || !unicode.IsUpper(r) {
+		return repl
+	}
+	first, n := utf8.DecodeRuneInString(repl)
+	return string(unicode.ToUpper(first)) + repl[n:]
+}
+
+// replacer returns a function which replaces all the matches in a text.
+// In regular expression mode, the replacement may refer to the submatches
+// as $1 or ${name}. A text which would become empty is left unchanged.
+func (o replaceOptions) replacer() (func(string) string, error) {
+	pattern := o.Find
+	if !o.Regexp {
+		pattern = regexp.QuoteMeta(pattern)
+	}
+	if !o.MatchCase {
+		pattern = "(?i)" + pattern
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	return func(s string) string {
+		matches := re.FindAllStringSubmatchIndex(s, -1)
+		if len(matches) == 0 {
+			return s
+		}
+		var b []byte
+		i := 0
+		for _, m := range matches {
+			b = append(b, s[i:m[0]]...)
+			repl := o.Replace
+			if o.Regexp {
+				repl = string(re.ExpandString(nil, o.Replace, s, m))
+			}
+			if o.PreserveCase {
+				repl = withCaseOf(repl, s[m[0]:m[1]])
+			}
+			b = append(b, repl...)
+			i = m[1]
+		}
+		b = append(b, s[i:]...)
+		t := string(b)
+		if strings.TrimSpace(t) == "" {
+			return s
+		}
+		return t
+	}, nil
+}
+
+func (a *App) Replace(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	bid, err := u64(vars["book_id"])
+	if err != nil {
+		http.Error(w, "Invalid book ID", http.StatusBadRequest)
+		return
+	}
+
+	opts := replaceOptionsFromRequest(r)
+	var replace func(string) string
+	var replaceErr error
+	if opts.Find != "" {
+		replace, replaceErr = opts.replacer()
+	}
+
+	switch r.Method {
+	case "GET":
+		book, err := a.db.BookWithTranslations(bid, 0, -1)
+		if err != nil {
+			if err == ErrNotFound {
+				http.NotFound(w, r)
+				return
+			}
+			internalError(w, err)
+			return
+		}
+		var changes []VersionChange
+		if replace != nil {
+			changes = versionChanges(book.Fragments, replace)
+		}
+
+		if r.FormValue("f") == "json" {
+			if replaceErr != nil {
+				http.Error(w, "Invalid regular expression: "+replaceErr.Error(), http.StatusBadRequest)
+				return
+			}
+			if changes == nil {
+				changes = []VersionChange{}
+			}
+			w.Header().Set("Content-Type", "application/json")
+			if err := json.NewEncoder(w).Encode(changes); err != nil {
+				logError(err)
+			}
+			return
+		}
+
+		type change struct {
+			VersionChange
+			Diff template.HTML
+		}
+		rendered := make([]change, len(changes))
+		fragments := make(map[uint64]bool)
+		for i, c := range changes {
+			rendered[i] = change{c, renderDiff(c.Old, c.New)}
+			fragments[c.FragmentID] = true
+		}
+		errMsg := ""
+		if replaceErr != nil {
+			errMsg = replaceErr.Error()
+		}
+		w.Header().Set("Content-Type", "text/html")
+		if err := replaceTmpl.Execute(w, struct {
+			Book      Book
+			URL       string
+			Options   replaceOptions
+			Error     string
+			Changes   []change
+			Fragments int
+		}{
+			book,
+			r.FormValue("url"),
+			opts,
+			errMsg,
+			rendered,
+			len(fragments),
+		}); err != nil {
+			logError(err)
+		}
+
+	case "POST":
+		if opts.Find == "" {
+			http.Error(w, "Nothing to find!", http.StatusBadRequest)
+			return
+		}
+		if replaceErr != nil {
+			http.Error(w, "Invalid regular expression: "+replaceErr.Error(), http.StatusBadRequest)
+			return
+		}
+		changes, err := a.db.RewriteVersions(bid, replace)
+		if err != nil {
+			if err == ErrNotFound {
+				http.Error(w, "Book not found", 404)
+				return
+			}
+			internalError(w, err)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(struct {
+			Changed int `json:"changed"`
+		}{
+			len(changes),
+		})
+	}
+}
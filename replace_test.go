@@ -0,0 +1,41 @@
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"fmt"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+)
+
+func TestReplaceKeepsStale(t *testing.T) {
+	for _, db := range openTestStorages(t) {
+		bid, v := addStaleVersion(t, db, "One")
+		a := &App{db: db}
+		form := url.Values{"find": {"one"}, "replace": {"Uno"}}
+		r := httptest.NewRequest("POST", "/", strings.NewReader(form.Encode()))
+		r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		r = mux.SetURLVars(r, map[string]string{"book_id": fmt.Sprint(bid)})
+		w := httptest.NewRecorder()
+		a.Replace(w, r)
+		if w.Code != 200 || !strings.Contains(w.Body.String(), `"changed":1`) {
+			t.Fatalf("%T: %d %s", db, w.Code, w.Body)
+		}
+		checkStillStale(t, db, bid, v)
+	}
+}
@@ -0,0 +1,180 @@
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"encoding/csv"
+	"net/http"
+	"strconv"
+	"time"
+	"unicode/utf8"
+
+	"github.com/gorilla/mux"
+)
+
+// charsPerSheet is the number of characters, spaces included, in an
+// author's sheet.
+const charsPerSheet = 40000
+
+// volume is the amount of translated text.
+type volume struct {
+	Fragments   int
+	SourceWords int
+	SourceChars int
+	TargetWords int
+	TargetChars int
+}
+
+func (v *volume) add(b volume) {
+	v.Fragments += b.Fragments
+	v.SourceWords += b.SourceWords
+	v.SourceChars += b.SourceChars
+	v.TargetWords += b.TargetWords
+	v.TargetChars += b.TargetChars
+}
+
+func (v volume) SourceSheets() string { return sheets(v.SourceChars) }
+
+func (v volume) TargetSheets() string { return sheets(v.TargetChars) }
+
+func sheets(chars int) string {
+	return strconv.FormatFloat(float64(chars)/charsPerSheet, 'f', 3, 64)
+}
+
+// translatedVolume returns the volume of the fragments of the book which
+// were translated for the first time at a time matching inPeriod. The
+// translation of a fragment is its chosen version.
+func translatedVolume(book Book, inPeriod func(time.Time) bool) volume {
+	var v volume
+	for _, f := range book.Fragments {
+		first := f.firstTranslated()
+		if first.IsZero() || !inPeriod(first) {
+			continue
+		}
+		t := f.Translation(false)
+		v.Fragments++
+		v.SourceWords += wordCount(f.Text)
+		v.SourceChars += utf8.RuneCountInString(f.Text)
+		v.TargetWords += wordCount(t)
+		v.TargetChars += utf8.RuneCountInString(t)
+	}
+	return v
+}
+
+type reportRow struct {
+	Book Book
+	volume
+}
+
+func (a *App) serveReport(w http.ResponseWriter, r *http.Request, book *Book, books []Book) {
+	from, to := r.FormValue("from"), r.FormValue("to")
+	inPeriod, err := updatedMatcher([]string{from, to})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var rows []reportRow
+	var total volume
+	for _, b := range books {
+		v := translatedVolume(b, inPeriod)
+		if v.Fragments == 0 && book == nil {
+			continue
+		}
+		rows = append(rows, reportRow{b, v})
+		total.add(v)
+	}
+
+	if r.FormValue("f") == "csv" {
+		w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+		w.Header().Set("Content-Disposition", `attachment; filename="report.csv"`)
+		cw := csv.NewWriter(w)
+		cw.Write([]string{"Book", "Fragments",
+			"Source words", "Source characters", "Source sheets",
+			"Target words", "Target characters", "Target sheets"})
+		record := func(title string, v volume) []string {
+			return []string{title, strconv.Itoa(v.Fragments),
+				strconv.Itoa(v.SourceWords), strconv.Itoa(v.SourceChars), v.SourceSheets(),
+				strconv.Itoa(v.TargetWords), strconv.Itoa(v.TargetChars), v.TargetSheets()}
+		}
+		for _, row := range rows {
+			cw.Write(record(row.Book.Title, row.volume))
+		}
+		if book == nil {
+			cw.Write(record("Total", total))
+		}
+		cw.Flush()
+		if err := cw.Error(); err != nil {
+			logError(err)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html")
+	if err := reportTmpl.Execute(w, struct {
+		Book          *Book
+		URL           string
+		From          string
+		To            string
+		Rows          []reportRow
+		Total         volume
+		CharsPerSheet int
+	}{
+		book,
+		r.FormValue("url"),
+		from,
+		to,
+		rows,
+		total,
+		charsPerSheet,
+	}); err != nil {
+		logError(err)
+	}
+}
+
+func (a *App) BookReport(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	bid, err := u64(vars["book_id"])
+	if err != nil {
+		http.Error(w, "Invalid book ID", http.StatusBadRequest)
+		return
+	}
+
+	book, err := a.db.BookWithTranslations(bid, 0, -1)
+	if err != nil {
+		if err == ErrNotFound {
+			http.NotFound(w, r)
+			return
+		}
+		internalError(w, err)
+		return
+	}
+
+	a.serveReport(w, r, &book, []Book{book})
+}
+
+func (a *App) Report(w http.ResponseWriter, r *http.Request) {
+	books, err := a.db.Books()
+	if err != nil {
+		internalError(w, err)
+		return
+	}
+	books, err = a.booksWithTranslations(books)
+	if err != nil {
+		internalError(w, err)
+		return
+	}
+
+	a.serveReport(w, r, nil, books)
+}
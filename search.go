@@ -0,0 +1,215 @@
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"encoding/json"
+	"html/template"
+	"net/http"
+	"strings"
+
+	"github.com/boltdb/bolt"
+	"github.com/opennota/substring"
+)
+
+// searchLimit is the maximum number of hits returned by a search.
+const searchLimit = 500
+
+// Fields a search hit can be found in.
+const (
+	fieldOriginal    = "original"
+	fieldTranslation = "translation"
+	fieldComment     = "comment"
+	fieldScratchpad  = "scratchpad"
+)
+
+// SearchHit is a piece of text which contains the query. Scratchpad hits
+// have no fragment.
+type SearchHit struct {
+	FragmentID uint64        `json:"fragment_id,omitempty"`
+	VersionID  uint64        `json:"version_id,omitempty"`
+	SeqNum     int           `json:"seq_num,omitempty"`
+	Field      string        `json:"field"`
+	Text       string        `json:"text"`
+	HTML       template.HTML `json:"html"`
+}
+
+// SearchResult holds the hits found in one book.
+type SearchResult struct {
+	BookID uint64      `json:"book_id"`
+	Title  string      `json:"title"`
+	Hits   []SearchHit `json:"hits"`
+}
+
+// searchCollector gathers the hits of a search, grouped by book, until the
+// limit is reached.
+type searchCollector struct {
+	m       *substring.Matcher
+	query   string
+	limit   int
+	n       int
+	results []SearchResult
+}
+
+func newSearchCollector(query string, limit int) *searchCollector {
+	return &searchCollector{
+		m:       substring.NewMatcher(query),
+		query:   query,
+		limit:   limit,
+		results: []SearchResult{},
+	}
+}
+
+func (c *searchCollector) full() bool { return c.n >= c.limit }
+
+// add records the hit if the text matches the query.
+func (c *searchCollector) add(book Book, hit SearchHit) {
+	if c.full() || !c.m.Match(hit.Text) {
+		return
+	}
+	hit.HTML = renderhl(hit.Text, c.query)
+	if n := len(c.results); n == 0 || c.results[n-1].BookID != book.ID {
+		c.results = append(c.results, SearchResult{BookID: book.ID, Title: book.Title})
+	}
+	r := &c.results[len(c.results)-1]
+	r.Hits = append(r.Hits, hit)
+	c.n++
+}
+
+// Search looks for the query in the originals, translations, comments and
+// scratchpads of all the books, the most recently active books first. It
+// returns at most limit hits; truncated is true if the limit was reached.
+func (db *DB) Search(query string, limit int) ([]SearchResult, bool, error) {
+	c := newSearchCollector(query, limit)
+	err := db.View(func(tx *bolt.Tx) error {
+		books, err := listBooks(tx, "index")
+		if err != nil {
+			return err
+		}
+		sortBooksByActivity(books)
+		spb := tx.Bucket([]byte("scratchpad"))
+		for _, book := range books {
+			fb := tx.Bucket([]byte("fragments")).Bucket(encode(book.ID))
+			vb := tx.Bucket([]byte("versions")).Bucket(encode(book.ID))
+			if fb == nil || vb == nil {
+				continue
+			}
+			orig, origOK := indexedCandidates(tx, book.ID, "orig_trigrams", query)
+			trans, transOK := indexedCandidates(tx, book.ID, "trans_trigrams", query)
+			commented := indexedSet(tx, book.ID, setCommented)
+			for i, fid := range fragmentIDs(tx, book.ID) {
+				if c.full() {
+					break
+				}
+				inOrig := !origOK || orig[fid]
+				inTrans := !transOK || trans[fid]
+				if !inOrig && !inTrans && !commented[fid] {
+					continue
+				}
+				var f Fragment
+				if found, err := unmarshal(fb, fid, &f); err != nil {
+					return err
+				} else if !found {
+					continue
+				}
+				hit := SearchHit{FragmentID: fid, SeqNum: i + 1}
+				if inOrig {
+					hit.Field, hit.Text = fieldOriginal, f.Text
+					c.add(book, hit)
+				}
+				if inTrans {
+					for _, vid := range f.VersionsIDs {
+						var v TranslationVersion
+						if found, err := unmarshal(vb, vid, &v); err != nil {
+							return err
+						} else if found {
+							h := hit
+							h.VersionID, h.Field, h.Text = vid, fieldTranslation, v.Text
+							c.add(book, h)
+						}
+					}
+				}
+				for _, t := range f.Threads {
+					for _, comment := range t.Comments {
+						hit.Field, hit.Text = fieldComment, comment.Text
+						c.add(book, hit)
+					}
+				}
+			}
+			var sp Scratchpad
+			if found, err := unmarshal(spb, book.ID, &sp); err != nil {
+				return err
+			} else if found && sp.Text != "" {
+				c.add(book, SearchHit{Field: fieldScratchpad, Text: sp.Text})
+			}
+			if c.full() {
+				break
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, false, err
+	}
+	return c.results, c.full(), nil
+}
+
+func (a *App) Search(w http.ResponseWriter, r *http.Request) {
+	query := strings.TrimSpace(r.FormValue("q"))
+
+	var results []SearchResult
+	var truncated bool
+	if query != "" {
+		var err error
+		results, truncated, err = a.db.Search(query, searchLimit)
+		if err != nil {
+			internalError(w, err)
+			return
+		}
+	}
+
+	if r.FormValue("f") == "json" {
+		w.Header().Set("Content-Type", "application/json")
+		if results == nil {
+			results = []SearchResult{}
+		}
+		if err := json.NewEncoder(w).Encode(struct {
+			Query     string         `json:"query"`
+			Results   []SearchResult `json:"results"`
+			Truncated bool           `json:"truncated"`
+		}{
+			query,
+			results,
+			truncated,
+		}); err != nil {
+			logError(err)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html")
+	if err := searchTmpl.Execute(w, struct {
+		Query     string
+		Results   []SearchResult
+		Truncated bool
+		Limit     int
+	}{
+		query,
+		results,
+		truncated,
+		searchLimit,
+	}); err != nil {
+		logError(err)
+	}
+}
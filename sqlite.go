@@ -0,0 +1,2052 @@
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	_ "modernc.org/sqlite"
+)
+
+// SQLiteDB is the SQLite implementation of Storage.
+type SQLiteDB struct {
+	*sql.DB
+}
+
+// Fragments are kept in the order of their position, which runs from 0
+// without gaps. fragment_seq and version_seq are the last IDs assigned to
+// the fragments and the versions of the book.
+const sqliteSchema = `
+CREATE TABLE IF NOT EXISTS books (
+	id                INTEGER PRIMARY KEY AUTOINCREMENT,
+	title             TEXT NOT NULL,
+	created           TEXT,
+	last_activity     TEXT,
+	last_visited_page INTEGER NOT NULL DEFAULT 0,
+	deleted           TEXT,
+	fragment_seq      INTEGER NOT NULL DEFAULT 0,
+	version_seq       INTEGER NOT NULL DEFAULT 0
+);
+CREATE TABLE IF NOT EXISTS fragments (
+	book_id        INTEGER NOT NULL,
+	id             INTEGER NOT NULL,
+	position       INTEGER NOT NULL,
+	created        TEXT,
+	updated        TEXT,
+	source_updated TEXT,
+	text           TEXT NOT NULL,
+	comment        TEXT NOT NULL DEFAULT '',
+	starred        INTEGER NOT NULL DEFAULT 0,
+	PRIMARY KEY (book_id, id)
+);
+CREATE INDEX IF NOT EXISTS fragments_position ON fragments (book_id, position);
+CREATE TABLE IF NOT EXISTS versions (
+	book_id     INTEGER NOT NULL,
+	id          INTEGER NOT NULL,
+	fragment_id INTEGER NOT NULL,
+	created     TEXT,
+	updated     TEXT,
+	text        TEXT NOT NULL,
+	PRIMARY KEY (book_id, id)
+);
+CREATE INDEX IF NOT EXISTS versions_fragment ON versions (book_id, fragment_id);
+CREATE TABLE IF NOT EXISTS revisions (
+	book_id    INTEGER NOT NULL,
+	version_id INTEGER NOT NULL,
+	id         INTEGER NOT NULL,
+	created    TEXT,
+	text       TEXT NOT NULL,
+	PRIMARY KEY (book_id, version_id, id)
+);
+CREATE TABLE IF NOT EXISTS source_revisions (
+	book_id     INTEGER NOT NULL,
+	fragment_id INTEGER NOT NULL,
+	id          INTEGER NOT NULL,
+	created     TEXT,
+	text        TEXT NOT NULL,
+	PRIMARY KEY (book_id, fragment_id, id)
+);
+CREATE TABLE IF NOT EXISTS scratchpads (
+	book_id INTEGER PRIMARY KEY,
+	created TEXT,
+	updated TEXT,
+	text    TEXT NOT NULL
+);
+`
+
+func OpenSQLite(path string) (*SQLiteDB, error) {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, err
+	}
+	// SQLite allows only one writer; serialize everything rather than
+	// dealing with SQLITE_BUSY.
+	db.SetMaxOpenConns(1)
+	if err := migrateSQLite(db); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return &SQLiteDB{db}, nil
+}
+
+// sqliteMigrations[i] upgrades the database from schema version i to i+1;
+// the version is kept in PRAGMA user_version. Never change or reorder
+// existing migrations; append new ones instead.
+var sqliteMigrations = []string{
+	sqliteSchema,
+	`CREATE TABLE glossary (
+		book_id  INTEGER NOT NULL,
+		id       INTEGER NOT NULL,
+		created  TEXT,
+		updated  TEXT,
+		source   TEXT NOT NULL,
+		target   TEXT NOT NULL DEFAULT '',
+		notes    TEXT NOT NULL DEFAULT '',
+		pos      TEXT NOT NULL DEFAULT '',
+		PRIMARY KEY (book_id, id)
+	);
+	-- term_seq is the last ID assigned to the glossary terms of the book.
+	ALTER TABLE books ADD COLUMN term_seq INTEGER NOT NULL DEFAULT 0;`,
+	`CREATE TABLE saved_filters (
+		book_id  INTEGER NOT NULL,
+		name     TEXT NOT NULL,
+		query    TEXT NOT NULL,
+		PRIMARY KEY (book_id, name)
+	);`,
+	`ALTER TABLE fragments ADD COLUMN status TEXT NOT NULL DEFAULT '';`,
+	`ALTER TABLE fragments ADD COLUMN preferred_version INTEGER NOT NULL DEFAULT 0;`,
+	`CREATE TABLE threads (
+		book_id     INTEGER NOT NULL,
+		fragment_id INTEGER NOT NULL,
+		id          INTEGER NOT NULL,
+		resolved    INTEGER NOT NULL DEFAULT 0,
+		PRIMARY KEY (book_id, fragment_id, id)
+	);
+	CREATE TABLE comments (
+		book_id     INTEGER NOT NULL,
+		fragment_id INTEGER NOT NULL,
+		thread_id   INTEGER NOT NULL,
+		id          INTEGER NOT NULL,
+		author      TEXT NOT NULL DEFAULT '',
+		created     TEXT,
+		text        TEXT NOT NULL,
+		PRIMARY KEY (book_id, fragment_id, thread_id, id)
+	);
+	-- The single comments of the fragments become threads of their own;
+	-- the comment column is no longer used.
+	INSERT INTO threads (book_id, fragment_id, id)
+		SELECT book_id, id, 1 FROM fragments WHERE comment <> '';
+	INSERT INTO comments (book_id, fragment_id, thread_id, id, created, text)
+		SELECT book_id, id, 1, 1, COALESCE(updated, created), comment FROM fragments WHERE comment <> '';
+	UPDATE fragments SET comment = '';`,
+	`ALTER TABLE books ADD COLUMN source_lang TEXT NOT NULL DEFAULT '';
+	ALTER TABLE books ADD COLUMN target_lang TEXT NOT NULL DEFAULT '';
+	ALTER TABLE books ADD COLUMN author TEXT NOT NULL DEFAULT '';
+	ALTER TABLE books ADD COLUMN original_title TEXT NOT NULL DEFAULT '';
+	ALTER TABLE books ADD COLUMN source_url TEXT NOT NULL DEFAULT '';
+	ALTER TABLE books ADD COLUMN notes TEXT NOT NULL DEFAULT '';
+	-- tags is a comma separated list.
+	ALTER TABLE books ADD COLUMN tags TEXT NOT NULL DEFAULT '';`,
+	`ALTER TABLE books ADD COLUMN collection TEXT NOT NULL DEFAULT '';
+	ALTER TABLE books ADD COLUMN archived INTEGER NOT NULL DEFAULT 0;`,
+	`ALTER TABLE books ADD COLUMN goal_amount INTEGER NOT NULL DEFAULT 0;
+	ALTER TABLE books ADD COLUMN goal_unit TEXT NOT NULL DEFAULT '';`,
+	// comment_seq is the last ID given to a thread or a comment of the
+	// fragment.
+	`ALTER TABLE fragments ADD COLUMN comment_seq INTEGER NOT NULL DEFAULT 0;
+	UPDATE fragments SET comment_seq = (SELECT MAX(id) FROM (
+		SELECT id FROM threads t WHERE t.book_id = fragments.book_id AND t.fragment_id = fragments.id
+		UNION ALL
+		SELECT id FROM comments c WHERE c.book_id = fragments.book_id AND c.fragment_id = fragments.id))
+	WHERE EXISTS (SELECT 1 FROM threads t WHERE t.book_id = fragments.book_id AND t.fragment_id = fragments.id);`,
+	// translated_days counts the fragments translated for the first time,
+	// and the words of their originals, by day, for the daily goals. It is
+	// filled by countSQLiteTranslatedDays.
+	`CREATE TABLE translated_days (
+		book_id   INTEGER NOT NULL,
+		day       TEXT NOT NULL,
+		fragments INTEGER NOT NULL,
+		words     INTEGER NOT NULL,
+		PRIMARY KEY (book_id, day)
+	);`,
+}
+
+// sqliteMigrationFuncs do the part of the migrations to the schema versions
+// which can't be done in SQL.
+var sqliteMigrationFuncs = map[int]func(tx *sql.Tx) error{
+	11: countSQLiteTranslatedDays,
+}
+
+func migrateSQLite(db *sql.DB) error {
+	var version int
+	if err := db.QueryRow(`PRAGMA user_version`).Scan(&version); err != nil {
+		return err
+	}
+	if version > len(sqliteMigrations) {
+		return fmt.Errorf("database schema version %d is newer than supported (%d)", version, len(sqliteMigrations))
+	}
+	for i := version; i < len(sqliteMigrations); i++ {
+		tx, err := db.Begin()
+		if err != nil {
+			return err
+		}
+		if _, err := tx.Exec(sqliteMigrations[i]); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("migration to schema version %d: %v", i+1, err)
+		}
+		if fn := sqliteMigrationFuncs[i+1]; fn != nil {
+			if err := fn(tx); err != nil {
+				tx.Rollback()
+				return fmt.Errorf("migration to schema version %d: %v", i+1, err)
+			}
+		}
+		if _, err := tx.Exec(fmt.Sprintf(`PRAGMA user_version = %d`, i+1)); err != nil {
+			tx.Rollback()
+			return err
+		}
+		if err := tx.Commit(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Times are stored in UTC in a fixed-width format, so that they can be
+// compared as strings.
+const sqliteTimeLayout = "2006-01-02 15:04:05.000000000"
+
+func sqlTime(t time.Time) interface{} {
+	if t.IsZero() {
+		return nil
+	}
+	return t.UTC().Format(sqliteTimeLayout)
+}
+
+// sqlTimeDest scans a time stored by sqlTime.
+type sqlTimeDest struct{ t *time.Time }
+
+func (d sqlTimeDest) Scan(v interface{}) error {
+	switch v := v.(type) {
+	case nil:
+		*d.t = time.Time{}
+		return nil
+	case []byte:
+		return d.parse(string(v))
+	case string:
+		return d.parse(v)
+	case time.Time:
+		*d.t = v.Local()
+		return nil
+	}
+	return fmt.Errorf("cannot scan %T into a time", v)
+}
+
+func (d sqlTimeDest) parse(s string) error {
+	t, err := time.ParseInLocation(sqliteTimeLayout, s, time.UTC)
+	if err != nil {
+		return err
+	}
+	*d.t = t.Local()
+	return nil
+}
+
+func (db *SQLiteDB) transaction(fn func(tx *sql.Tx) error) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	if err := fn(tx); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+const sqliteBookColumns = `id, title, created, last_activity, last_visited_page, deleted,
+	source_lang, target_lang, author, original_title, source_url, notes, tags,
+	collection, archived, goal_amount, goal_unit,
+	(SELECT COUNT(*) FROM fragments f WHERE f.book_id = books.id),
+	(SELECT COUNT(*) FROM fragments f WHERE f.book_id = books.id AND
+		EXISTS (SELECT 1 FROM versions v WHERE v.book_id = f.book_id AND v.fragment_id = f.id)),
+	(SELECT COUNT(*) FROM fragments f WHERE f.book_id = books.id AND f.status = 'review' AND
+		EXISTS (SELECT 1 FROM versions v WHERE v.book_id = f.book_id AND v.fragment_id = f.id)),
+	(SELECT COUNT(*) FROM fragments f WHERE f.book_id = books.id AND f.status = 'approved' AND
+		EXISTS (SELECT 1 FROM versions v WHERE v.book_id = f.book_id AND v.fragment_id = f.id))`
+
+func scanBook(row rowScanner) (Book, error) {
+	var book Book
+	var tags string
+	var goal DailyGoal
+	err := row.Scan(&book.ID, &book.Title, sqlTimeDest{&book.Created},
+		sqlTimeDest{&book.LastActivity}, &book.LastVisitedPage, sqlTimeDest{&book.Deleted},
+		&book.SourceLang, &book.TargetLang, &book.Author, &book.OriginalTitle,
+		&book.SourceURL, &book.Notes, &tags,
+		&book.Collection, &book.Archived, &goal.Amount, &goal.Unit,
+		&book.FragmentsTotal, &book.FragmentsTranslated,
+		&book.FragmentsInReview, &book.FragmentsApproved)
+	book.Tags = parseTags(tags)
+	if goal.Amount > 0 {
+		book.Goal = &goal
+	}
+	return book, err
+}
+
+// sqliteBook returns the book with the list of its fragment IDs. Books in
+// the trash are only returned if trashed is true.
+func sqliteBook(tx *sql.Tx, bid uint64, trashed bool) (Book, error) {
+	where := " AND deleted IS NULL"
+	if trashed {
+		where = ""
+	}
+	book, err := scanBook(tx.QueryRow(`SELECT `+sqliteBookColumns+` FROM books WHERE id = ?`+where, bid))
+	if err == sql.ErrNoRows {
+		return Book{}, ErrNotFound
+	} else if err != nil {
+		return Book{}, err
+	}
+	book.FragmentsIDs, err = queryIDs(tx, `SELECT id FROM fragments WHERE book_id = ? ORDER BY position`, bid)
+	if err != nil {
+		return Book{}, err
+	}
+	return book, nil
+}
+
+func queryIDs(tx *sql.Tx, query string, args ...interface{}) ([]uint64, error) {
+	rows, err := tx.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	ids := []uint64{}
+	for rows.Next() {
+		var id uint64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+func (db *SQLiteDB) books(where string) ([]Book, error) {
+	rows, err := db.Query(`SELECT ` + sqliteBookColumns + ` FROM books WHERE ` + where)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var books []Book
+	for rows.Next() {
+		book, err := scanBook(rows)
+		if err != nil {
+			return nil, err
+		}
+		books = append(books, book)
+	}
+	return books, rows.Err()
+}
+
+func (db *SQLiteDB) Books() ([]Book, error) {
+	return db.books(`deleted IS NULL ORDER BY id`)
+}
+
+func (db *SQLiteDB) BooksByActivity() ([]Book, error) {
+	return db.books(`deleted IS NULL ORDER BY last_activity DESC, id DESC`)
+}
+
+func (db *SQLiteDB) TrashedBooks() ([]Book, error) {
+	return db.books(`deleted IS NOT NULL ORDER BY deleted DESC, id DESC`)
+}
+
+func (db *SQLiteDB) BookByID(bid uint64) (Book, error) {
+	var book Book
+	err := db.transaction(func(tx *sql.Tx) error {
+		var err error
+		book, err = sqliteBook(tx, bid, false)
+		return err
+	})
+	if err != nil {
+		return Book{}, err
+	}
+	return book, nil
+}
+
+const sqliteFragmentColumns = `id, created, updated, source_updated, text, starred, status, preferred_version, comment_seq`
+
+func scanFragment(row rowScanner) (Fragment, error) {
+	var f Fragment
+	err := row.Scan(&f.ID, sqlTimeDest{&f.Created}, sqlTimeDest{&f.Updated},
+		sqlTimeDest{&f.SourceUpdated}, &f.Text, &f.Starred, &f.Status, &f.PreferredVersion, &f.CommentSeq)
+	return f, err
+}
+
+// sqliteFragment returns the fragment with the list of its version IDs and
+// its comment threads.
+func sqliteFragment(tx *sql.Tx, bid, fid uint64) (Fragment, error) {
+	f, err := scanFragment(tx.QueryRow(`SELECT `+sqliteFragmentColumns+` FROM fragments
+		WHERE book_id = ? AND id = ?`, bid, fid))
+	if err == sql.ErrNoRows {
+		return Fragment{}, ErrNotFound
+	} else if err != nil {
+		return Fragment{}, err
+	}
+	f.VersionsIDs, err = queryIDs(tx, `SELECT id FROM versions WHERE book_id = ? AND fragment_id = ? ORDER BY id`, bid, fid)
+	if err != nil {
+		return Fragment{}, err
+	}
+	f.Threads, err = sqliteThreads(tx, bid, fid)
+	if err != nil {
+		return Fragment{}, err
+	}
+	return f, nil
+}
+
+// sqliteBookFragment returns the fragment of a book which is not in the trash.
+func sqliteBookFragment(tx *sql.Tx, bid, fid uint64) (Fragment, error) {
+	var n int
+	if err := tx.QueryRow(`SELECT COUNT(*) FROM books WHERE id = ? AND deleted IS NULL`, bid).Scan(&n); err != nil {
+		return Fragment{}, err
+	} else if n == 0 {
+		return Fragment{}, ErrNotFound
+	}
+	return sqliteFragment(tx, bid, fid)
+}
+
+func sqliteVersions(tx *sql.Tx, bid, fid uint64) ([]TranslationVersion, error) {
+	rows, err := tx.Query(`SELECT id, created, updated, text FROM versions
+		WHERE book_id = ? AND fragment_id = ? ORDER BY id`, bid, fid)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var versions []TranslationVersion
+	for rows.Next() {
+		var v TranslationVersion
+		if err := rows.Scan(&v.ID, sqlTimeDest{&v.Created}, sqlTimeDest{&v.Updated}, &v.Text); err != nil {
+			return nil, err
+		}
+		versions = append(versions, v)
+	}
+	return versions, rows.Err()
+}
+
+func sqliteFragmentsTranslated(tx *sql.Tx, bid uint64) (int, error) {
+	var n int
+	err := tx.QueryRow(`SELECT COUNT(*) FROM fragments f WHERE book_id = ? AND
+		EXISTS (SELECT 1 FROM versions v WHERE v.book_id = f.book_id AND v.fragment_id = f.id)`, bid).Scan(&n)
+	return n, err
+}
+
+func sqliteTouch(tx *sql.Tx, bid uint64, now time.Time) error {
+	_, err := tx.Exec(`UPDATE books SET last_activity = ? WHERE id = ?`, sqlTime(now), bid)
+	return err
+}
+
+// nextSeq increments one of the ID counters of the book (fragment_seq or
+// version_seq) and returns its new value.
+func nextSeq(tx *sql.Tx, bid uint64, column string) (uint64, error) {
+	if _, err := tx.Exec(`UPDATE books SET `+column+` = `+column+` + 1 WHERE id = ?`, bid); err != nil {
+		return 0, err
+	}
+	var id uint64
+	err := tx.QueryRow(`SELECT `+column+` FROM books WHERE id = ?`, bid).Scan(&id)
+	return id, err
+}
+
+// filterIDs returns the IDs of the fragments of the book matching a filter
+// which can be answered by a query; ok is false for the other filters.
+func filterIDs(tx *sql.Tx, bid uint64, filter bookFilter) (ids []uint64, ok bool, err error) {
+	const versions = `FROM versions v WHERE v.book_id = f.book_id AND v.fragment_id = f.id`
+	const threads = `FROM threads t WHERE t.book_id = f.book_id AND t.fragment_id = f.id`
+	var query string
+	args := []interface{}{bid}
+	switch filter.Kind {
+	case fUntranslated:
+		query = `SELECT id FROM fragments f WHERE book_id = ? AND
+			NOT EXISTS (SELECT 1 ` + versions + `) ORDER BY position`
+	case fCommented:
+		query = `SELECT id FROM fragments f WHERE book_id = ? AND
+			EXISTS (SELECT 1 ` + threads + `) ORDER BY position`
+	case fUnresolved:
+		query = `SELECT id FROM fragments f WHERE book_id = ? AND
+			EXISTS (SELECT 1 ` + threads + ` AND NOT t.resolved) ORDER BY position`
+	case fStarred:
+		query = `SELECT id FROM fragments WHERE book_id = ? AND starred ORDER BY position`
+	case fWithTwoOrMoreVersions:
+		query = `SELECT id FROM fragments f WHERE book_id = ? AND
+			(SELECT COUNT(*) ` + versions + `) >= 2 ORDER BY position`
+	case fStale:
+		query = `SELECT id FROM fragments f WHERE book_id = ? AND
+			EXISTS (SELECT 1 ` + versions + ` AND v.updated < f.source_updated) ORDER BY position`
+	case fStatus:
+		query = `SELECT id FROM fragments f WHERE book_id = ? AND status = ? AND
+			EXISTS (SELECT 1 ` + versions + `) ORDER BY position`
+		args = append(args, storedStatus(filter.Args[0]))
+	case fNoPreferredVersion:
+		query = `SELECT id FROM fragments f WHERE book_id = ? AND
+			(SELECT COUNT(*) ` + versions + `) >= 2 AND
+			NOT EXISTS (SELECT 1 ` + versions + ` AND v.id = f.preferred_version) ORDER BY position`
+	default:
+		return nil, false, nil
+	}
+	ids, err = queryIDs(tx, query, args...)
+	return ids, true, err
+}
+
+func (db *SQLiteDB) BookWithTranslations(bid uint64, from, size int, filters ...bookFilter) (Book, error) {
+	m, err := newFragmentMatcher(filters)
+	if err != nil {
+		return Book{}, err
+	}
+	var book Book
+	if err := db.transaction(func(tx *sql.Tx) error {
+		var err error
+		book, err = sqliteBook(tx, bid, false)
+		if err != nil {
+			return err
+		}
+
+		if from >= len(book.FragmentsIDs) && from > 0 {
+			return ErrInvalidOffset
+		}
+
+		if size == -1 {
+			size = len(book.FragmentsIDs)
+		}
+
+		seqNum := make(map[uint64]int, len(book.FragmentsIDs))
+		for i, fid := range book.FragmentsIDs {
+			seqNum[fid] = i + 1
+		}
+
+		for _, f := range filters {
+			ids, ok, err := filterIDs(tx, bid, f)
+			if err != nil {
+				return err
+			} else if !ok {
+				continue
+			}
+			set := make(map[uint64]bool, len(ids))
+			for _, id := range ids {
+				set[id] = true
+			}
+			filtered := book.FragmentsIDs[:0]
+			for _, fid := range book.FragmentsIDs {
+				if set[fid] {
+					filtered = append(filtered, fid)
+				}
+			}
+			book.FragmentsIDs = filtered
+		}
+
+		if !m.empty() {
+			filtered := book.FragmentsIDs[:0]
+			for _, fid := range book.FragmentsIDs {
+				f, err := sqliteFragment(tx, bid, fid)
+				if err != nil {
+					return err
+				}
+				var versions []TranslationVersion
+				if m.needsVersions() {
+					if versions, err = sqliteVersions(tx, bid, fid); err != nil {
+						return err
+					}
+				}
+				if m.match(&f, versions) {
+					filtered = append(filtered, fid)
+				}
+			}
+			book.FragmentsIDs = filtered
+		}
+
+		if from >= len(book.FragmentsIDs) {
+			return nil
+		}
+
+		to := min(len(book.FragmentsIDs), from+size)
+		for _, fid := range book.FragmentsIDs[from:to] {
+			f, err := sqliteFragment(tx, bid, fid)
+			if err != nil {
+				return err
+			}
+
+			versions, err := sqliteVersions(tx, bid, fid)
+			if err != nil {
+				return err
+			}
+			for _, v := range versions {
+				if !m.versionMatches(v.Text) {
+					continue
+				}
+				v.Stale = v.Updated.Before(f.SourceUpdated)
+				f.Versions = append(f.Versions, v)
+			}
+
+			if m.withQA {
+				f.Issues = qaIssues(f.Text, f.Versions, m.qa)
+			}
+
+			f.SeqNum = seqNum[fid]
+
+			book.Fragments = append(book.Fragments, f)
+		}
+
+		terms, err := sqliteGlossary(tx, bid)
+		if err != nil {
+			return err
+		}
+		attachTerms(book.Fragments, terms)
+
+		return nil
+	}); err != nil {
+		if err == ErrInvalidOffset {
+			return book, err
+		}
+		return Book{}, err
+	}
+	return book, nil
+}
+
+func (db *SQLiteDB) AddBook(title string, fragments []string, autotranslate bool) (uint64, error) {
+	translated := make([][]string, len(fragments))
+	for i, text := range fragments {
+		translated[i] = []string{text, ""}
+		if autotranslate && !rLetter.MatchString(text) {
+			translated[i][1] = text
+		}
+	}
+	return db.addBook(title, translated, false)
+}
+
+func (db *SQLiteDB) AddTranslatedBook(title string, fragments [][]string) (uint64, error) {
+	return db.addBook(title, fragments, true)
+}
+
+func (db *SQLiteDB) addBook(title string, fragments [][]string, trim bool) (uint64, error) {
+	now := time.Now()
+	var bid uint64
+	err := db.transaction(func(tx *sql.Tx) error {
+		res, err := tx.Exec(`INSERT INTO books (title, created) VALUES (?, ?)`, title, sqlTime(now))
+		if err != nil {
+			return err
+		}
+		id, err := res.LastInsertId()
+		if err != nil {
+			return err
+		}
+		bid = uint64(id)
+
+		var vid uint64
+		for i, fragment := range fragments {
+			orig, translation := fragment[0], fragment[1]
+			if trim {
+				orig, translation = strings.TrimSpace(orig), strings.TrimSpace(translation)
+			}
+			fid := uint64(i + 1)
+			if _, err := tx.Exec(`INSERT INTO fragments (book_id, id, position, created, updated, text)
+				VALUES (?, ?, ?, ?, ?, ?)`, bid, fid, i, sqlTime(now), sqlTime(now), orig); err != nil {
+				return err
+			}
+			if translation == "" {
+				continue
+			}
+			vid++
+			if _, err := tx.Exec(`INSERT INTO versions (book_id, id, fragment_id, created, updated, text)
+				VALUES (?, ?, ?, ?, ?, ?)`, bid, vid, fid, sqlTime(now), sqlTime(now), translation); err != nil {
+				return err
+			}
+		}
+
+		if _, err := tx.Exec(`UPDATE books SET fragment_seq = ?, version_seq = ? WHERE id = ?`, len(fragments), vid, bid); err != nil {
+			return err
+		}
+		return fillSQLiteTranslatedDays(tx, bid)
+	})
+	if err != nil {
+		return 0, err
+	}
+	return bid, nil
+}
+
+// execOne executes the statement and returns ErrNotFound if no rows were
+// affected.
+func execOne(e interface {
+	Exec(string, ...interface{}) (sql.Result, error)
+}, query string, args ...interface{}) error {
+	res, err := e.Exec(query, args...)
+	if err != nil {
+		return err
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (db *SQLiteDB) UpdateBookTitle(bid uint64, title string) error {
+	return execOne(db, `UPDATE books SET title = ? WHERE id = ? AND deleted IS NULL`, title, bid)
+}
+
+func (db *SQLiteDB) UpdateBookMeta(bid uint64, m BookMeta) error {
+	return execOne(db, `UPDATE books SET source_lang = ?, target_lang = ?, author = ?, original_title = ?,
+		source_url = ?, notes = ?, tags = ? WHERE id = ? AND deleted IS NULL`,
+		m.SourceLang, m.TargetLang, m.Author, m.OriginalTitle, m.SourceURL, m.Notes, m.TagList(), bid)
+}
+
+func (db *SQLiteDB) SetBookCollection(bid uint64, name string) error {
+	return execOne(db, `UPDATE books SET collection = ? WHERE id = ? AND deleted IS NULL`, name, bid)
+}
+
+func (db *SQLiteDB) ArchiveBook(bid uint64, archived bool) error {
+	return execOne(db, `UPDATE books SET archived = ? WHERE id = ? AND deleted IS NULL`, archived, bid)
+}
+
+func (db *SQLiteDB) SetDailyGoal(bid uint64, g *DailyGoal) error {
+	if g == nil {
+		g = &DailyGoal{}
+	}
+	return execOne(db, `UPDATE books SET goal_amount = ?, goal_unit = ? WHERE id = ? AND deleted IS NULL`,
+		g.Amount, g.Unit, bid)
+}
+
+func (db *SQLiteDB) TranslatedDays(bid uint64) (map[string]dayCount, error) {
+	if _, err := db.BookByID(bid); err != nil {
+		return nil, err
+	}
+	rows, err := db.Query(`SELECT day, fragments, words FROM translated_days WHERE book_id = ?`, bid)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	days := make(map[string]dayCount)
+	for rows.Next() {
+		var d string
+		var c dayCount
+		if err := rows.Scan(&d, &c.Fragments, &c.Words); err != nil {
+			return nil, err
+		}
+		days[d] = c
+	}
+	return days, rows.Err()
+}
+
+// sqliteFirstVersion returns the time the first version of the fragment was
+// created, or the zero time if it has none.
+func sqliteFirstVersion(tx *sql.Tx, bid, fid uint64) (time.Time, error) {
+	var first time.Time
+	err := tx.QueryRow(`SELECT MIN(created) FROM versions WHERE book_id = ? AND fragment_id = ?`,
+		bid, fid).Scan(sqlTimeDest{&first})
+	return first, err
+}
+
+// countSQLiteTranslated adds n fragments with the given number of words,
+// translated for the first time at t, to the day counters of the book. n is
+// -1 to take a fragment back.
+func countSQLiteTranslated(tx *sql.Tx, bid uint64, t time.Time, words, n int) error {
+	if _, err := tx.Exec(`INSERT INTO translated_days (book_id, day, fragments, words) VALUES (?, ?, ?, ?)
+		ON CONFLICT (book_id, day) DO UPDATE SET fragments = fragments + excluded.fragments, words = words + excluded.words`,
+		bid, day(t), n, n*words); err != nil {
+		return err
+	}
+	_, err := tx.Exec(`DELETE FROM translated_days WHERE book_id = ? AND day = ? AND fragments <= 0`, bid, day(t))
+	return err
+}
+
+// fillSQLiteTranslatedDays counts the fragments of the book by the day of
+// their first version. It reads the whole book, so it is only used for the
+// books added at once.
+func fillSQLiteTranslatedDays(tx *sql.Tx, bid uint64) error {
+	rows, err := tx.Query(`SELECT f.text, MIN(v.created) FROM fragments f
+		JOIN versions v ON v.book_id = f.book_id AND v.fragment_id = f.id
+		WHERE f.book_id = ? GROUP BY f.id`, bid)
+	if err != nil {
+		return err
+	}
+	var st bookStats
+	for rows.Next() {
+		var text string
+		var first time.Time
+		if err := rows.Scan(&text, sqlTimeDest{&first}); err != nil {
+			rows.Close()
+			return err
+		}
+		st.countTranslated(first, wordCount(text), 1)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(`DELETE FROM translated_days WHERE book_id = ?`, bid); err != nil {
+		return err
+	}
+	for d, c := range st.Days {
+		if _, err := tx.Exec(`INSERT INTO translated_days (book_id, day, fragments, words) VALUES (?, ?, ?, ?)`,
+			bid, d, c.Fragments, c.Words); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// countSQLiteTranslatedDays fills the day counters of all the books.
+func countSQLiteTranslatedDays(tx *sql.Tx) error {
+	bids, err := queryIDs(tx, `SELECT id FROM books`)
+	if err != nil {
+		return err
+	}
+	for _, bid := range bids {
+		if err := fillSQLiteTranslatedDays(tx, bid); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (db *SQLiteDB) UpdateLastVisitedPage(bid uint64, page int) error {
+	return execOne(db, `UPDATE books SET last_visited_page = ? WHERE id = ? AND deleted IS NULL`, page, bid)
+}
+
+// RemoveBook moves the book to the trash.
+func (db *SQLiteDB) RemoveBook(bid uint64) error {
+	return execOne(db, `UPDATE books SET deleted = ? WHERE id = ? AND deleted IS NULL`, sqlTime(time.Now()), bid)
+}
+
+func (db *SQLiteDB) RestoreBook(bid uint64) error {
+	return execOne(db, `UPDATE books SET deleted = NULL WHERE id = ? AND deleted IS NOT NULL`, bid)
+}
+
+func sqliteRemoveBook(tx *sql.Tx, bid uint64) error {
+	for _, table := range []string{"source_revisions", "revisions", "versions", "fragments", "scratchpads", "glossary", "saved_filters", "threads", "comments", "translated_days"} {
+		if _, err := tx.Exec(`DELETE FROM `+table+` WHERE book_id = ?`, bid); err != nil {
+			return err
+		}
+	}
+	_, err := tx.Exec(`DELETE FROM books WHERE id = ?`, bid)
+	return err
+}
+
+// PurgeBook permanently removes the trashed book and all its data.
+func (db *SQLiteDB) PurgeBook(bid uint64) error {
+	return db.transaction(func(tx *sql.Tx) error {
+		var n int
+		if err := tx.QueryRow(`SELECT COUNT(*) FROM books WHERE id = ? AND deleted IS NOT NULL`, bid).Scan(&n); err != nil {
+			return err
+		} else if n == 0 {
+			return ErrNotFound
+		}
+		return sqliteRemoveBook(tx, bid)
+	})
+}
+
+// PurgeTrash permanently removes the books which were moved to the trash
+// before t. It returns the number of books removed.
+func (db *SQLiteDB) PurgeTrash(t time.Time) (int, error) {
+	var n int
+	err := db.transaction(func(tx *sql.Tx) error {
+		ids, err := queryIDs(tx, `SELECT id FROM books WHERE deleted < ?`, sqlTime(t))
+		if err != nil {
+			return err
+		}
+		for _, bid := range ids {
+			if err := sqliteRemoveBook(tx, bid); err != nil {
+				return err
+			}
+		}
+		n = len(ids)
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return n, nil
+}
+
+func (db *SQLiteDB) AddFragment(bid, fidAfter uint64, text string) (Fragment, error) {
+	now := time.Now()
+	var f Fragment
+	if err := db.transaction(func(tx *sql.Tx) error {
+		var n int
+		if err := tx.QueryRow(`SELECT COUNT(*) FROM books WHERE id = ? AND deleted IS NULL`, bid).Scan(&n); err != nil {
+			return err
+		} else if n == 0 {
+			return ErrNotFound
+		}
+
+		pos := 0
+		if fidAfter != 0 {
+			if err := tx.QueryRow(`SELECT position + 1 FROM fragments WHERE book_id = ? AND id = ?`,
+				bid, fidAfter).Scan(&pos); err == sql.ErrNoRows {
+				return ErrNotFound
+			} else if err != nil {
+				return err
+			}
+		}
+		if _, err := tx.Exec(`UPDATE fragments SET position = position + 1 WHERE book_id = ? AND position >= ?`, bid, pos); err != nil {
+			return err
+		}
+
+		fid, err := nextSeq(tx, bid, "fragment_seq")
+		if err != nil {
+			return err
+		}
+		f = Fragment{
+			ID:          fid,
+			Created:     now,
+			Updated:     now,
+			Text:        text,
+			VersionsIDs: []uint64{},
+			SeqNum:      pos + 1,
+		}
+		if _, err := tx.Exec(`INSERT INTO fragments (book_id, id, position, created, updated, text)
+			VALUES (?, ?, ?, ?, ?, ?)`, bid, fid, pos, sqlTime(now), sqlTime(now), text); err != nil {
+			return err
+		}
+
+		return sqliteTouch(tx, bid, now)
+	}); err != nil {
+		return Fragment{}, err
+	}
+	return f, nil
+}
+
+// appendSQLiteRevision is appendRevision for SQLite; table is either
+// "revisions" or "source_revisions", and column is the name of the column
+// holding id.
+func appendSQLiteRevision(tx *sql.Tx, table, column string, bid, id uint64, prev *Revision, now time.Time, text string) error {
+	var last sql.NullInt64
+	if err := tx.QueryRow(`SELECT MAX(id) FROM `+table+` WHERE book_id = ? AND `+column+` = ?`,
+		bid, id).Scan(&last); err != nil {
+		return err
+	}
+	insert := func(rid int64, created time.Time, text string) error {
+		_, err := tx.Exec(`INSERT INTO `+table+` (book_id, `+column+`, id, created, text) VALUES (?, ?, ?, ?, ?)`,
+			bid, id, rid, sqlTime(created), text)
+		return err
+	}
+
+	if !last.Valid && prev != nil {
+		if err := insert(1, prev.Created, prev.Text); err != nil {
+			return err
+		}
+		last = sql.NullInt64{Int64: 1, Valid: true}
+	}
+	if last.Valid {
+		var lastText string
+		if err := tx.QueryRow(`SELECT text FROM `+table+` WHERE book_id = ? AND `+column+` = ? AND id = ?`,
+			bid, id, last.Int64).Scan(&lastText); err != nil {
+			return err
+		}
+		if lastText == text {
+			return nil
+		}
+	}
+
+	return insert(last.Int64+1, now, text)
+}
+
+func sqliteRevisions(tx *sql.Tx, table, column string, bid, id uint64) ([]Revision, error) {
+	rows, err := tx.Query(`SELECT id, created, text FROM `+table+` WHERE book_id = ? AND `+column+` = ? ORDER BY id`, bid, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var revs []Revision
+	for rows.Next() {
+		var r Revision
+		if err := rows.Scan(&r.ID, sqlTimeDest{&r.Created}, &r.Text); err != nil {
+			return nil, err
+		}
+		revs = append(revs, r)
+	}
+	return revs, rows.Err()
+}
+
+func (db *SQLiteDB) UpdateFragment(bid, fid uint64, text string) error {
+	now := time.Now()
+	return db.transaction(func(tx *sql.Tx) error {
+		f, err := sqliteBookFragment(tx, bid, fid)
+		if err != nil {
+			return err
+		}
+		if f.Text == text {
+			return nil
+		}
+
+		prev := &Revision{Created: f.Created, Text: f.Text}
+		if !f.SourceUpdated.IsZero() {
+			prev.Created = f.SourceUpdated
+		}
+		if err := appendSQLiteRevision(tx, "source_revisions", "fragment_id", bid, fid, prev, now, text); err != nil {
+			return err
+		}
+
+		if _, err := tx.Exec(`UPDATE fragments SET text = ?, updated = ?, source_updated = ?
+			WHERE book_id = ? AND id = ?`, text, sqlTime(now), sqlTime(now), bid, fid); err != nil {
+			return err
+		}
+		first, err := sqliteFirstVersion(tx, bid, fid)
+		if err != nil {
+			return err
+		}
+		if !first.IsZero() {
+			if err := countSQLiteTranslated(tx, bid, first, wordCount(f.Text), -1); err != nil {
+				return err
+			}
+			if err := countSQLiteTranslated(tx, bid, first, wordCount(text), 1); err != nil {
+				return err
+			}
+		}
+
+		return sqliteTouch(tx, bid, now)
+	})
+}
+
+func (db *SQLiteDB) RemoveFragment(bid, fid uint64) (int, error) {
+	now := time.Now()
+	var fragmentsTranslated int
+	if err := db.transaction(func(tx *sql.Tx) error {
+		f, err := sqliteBookFragment(tx, bid, fid)
+		if err != nil {
+			return err
+		}
+		first, err := sqliteFirstVersion(tx, bid, fid)
+		if err != nil {
+			return err
+		}
+		if !first.IsZero() {
+			if err := countSQLiteTranslated(tx, bid, first, wordCount(f.Text), -1); err != nil {
+				return err
+			}
+		}
+
+		var pos int
+		if err := tx.QueryRow(`SELECT position FROM fragments WHERE book_id = ? AND id = ?`, bid, fid).Scan(&pos); err != nil {
+			return err
+		}
+		if _, err := tx.Exec(`DELETE FROM revisions WHERE book_id = ? AND version_id IN
+			(SELECT id FROM versions WHERE book_id = ? AND fragment_id = ?)`, bid, bid, fid); err != nil {
+			return err
+		}
+		for _, query := range []string{
+			`DELETE FROM versions WHERE book_id = ? AND fragment_id = ?`,
+			`DELETE FROM comments WHERE book_id = ? AND fragment_id = ?`,
+			`DELETE FROM threads WHERE book_id = ? AND fragment_id = ?`,
+			`DELETE FROM source_revisions WHERE book_id = ? AND fragment_id = ?`,
+			`DELETE FROM fragments WHERE book_id = ? AND id = ?`,
+		} {
+			if _, err := tx.Exec(query, bid, fid); err != nil {
+				return err
+			}
+		}
+		if _, err := tx.Exec(`UPDATE fragments SET position = position - 1 WHERE book_id = ? AND position > ?`, bid, pos); err != nil {
+			return err
+		}
+
+		if err := sqliteTouch(tx, bid, now); err != nil {
+			return err
+		}
+		fragmentsTranslated, err = sqliteFragmentsTranslated(tx, bid)
+		return err
+	}); err != nil {
+		return 0, err
+	}
+	return fragmentsTranslated, nil
+}
+
+func (db *SQLiteDB) StarFragment(bid, fid uint64) error {
+	return db.transaction(func(tx *sql.Tx) error {
+		if _, err := sqliteBookFragment(tx, bid, fid); err != nil {
+			return err
+		}
+		_, err := tx.Exec(`UPDATE fragments SET starred = 1 WHERE book_id = ? AND id = ?`, bid, fid)
+		return err
+	})
+}
+
+func (db *SQLiteDB) UnstarFragment(bid, fid uint64) error {
+	_, err := db.Exec(`UPDATE fragments SET starred = 0 WHERE book_id = ? AND id = ?`, bid, fid)
+	return err
+}
+
+func (db *SQLiteDB) SetFragmentStatus(bid, fid uint64, status string) error {
+	return db.transaction(func(tx *sql.Tx) error {
+		if _, err := sqliteBookFragment(tx, bid, fid); err != nil {
+			return err
+		}
+		_, err := tx.Exec(`UPDATE fragments SET status = ? WHERE book_id = ? AND id = ?`, storedStatus(status), bid, fid)
+		return err
+	})
+}
+
+// sqliteThreads returns the comment threads of the fragment.
+func sqliteThreads(tx *sql.Tx, bid, fid uint64) ([]Thread, error) {
+	rows, err := tx.Query(`SELECT t.id, t.resolved, c.id, c.author, c.created, c.text FROM threads t
+		JOIN comments c ON c.book_id = t.book_id AND c.fragment_id = t.fragment_id AND c.thread_id = t.id
+		WHERE t.book_id = ? AND t.fragment_id = ? ORDER BY t.id, c.id`, bid, fid)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var threads []Thread
+	for rows.Next() {
+		var t Thread
+		var c Comment
+		if err := rows.Scan(&t.ID, &t.Resolved, &c.ID, &c.Author, sqlTimeDest{&c.Created}, &c.Text); err != nil {
+			return nil, err
+		}
+		if n := len(threads); n == 0 || threads[n-1].ID != t.ID {
+			threads = append(threads, t)
+		}
+		last := &threads[len(threads)-1]
+		last.Comments = append(last.Comments, c)
+	}
+	return threads, rows.Err()
+}
+
+func insertSQLiteComment(tx *sql.Tx, bid, fid, tid uint64, c Comment) error {
+	_, err := tx.Exec(`INSERT INTO comments (book_id, fragment_id, thread_id, id, author, created, text)
+		VALUES (?, ?, ?, ?, ?, ?, ?)`, bid, fid, tid, c.ID, c.Author, sqlTime(c.Created), c.Text)
+	return err
+}
+
+func (db *SQLiteDB) Threads(bid, fid uint64) ([]Thread, error) {
+	var threads []Thread
+	err := db.transaction(func(tx *sql.Tx) error {
+		f, err := sqliteBookFragment(tx, bid, fid)
+		threads = f.Threads
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if threads == nil {
+		threads = []Thread{}
+	}
+	return threads, nil
+}
+
+func (db *SQLiteDB) AddThread(bid, fid uint64, c Comment) (Thread, error) {
+	var t Thread
+	err := db.transaction(func(tx *sql.Tx) error {
+		f, err := sqliteBookFragment(tx, bid, fid)
+		if err != nil {
+			return err
+		}
+		t.ID = f.nextCommentID()
+		c.ID = f.nextCommentID()
+		c.Created = time.Now()
+		t.Comments = []Comment{c}
+		if _, err := tx.Exec(`INSERT INTO threads (book_id, fragment_id, id) VALUES (?, ?, ?)`, bid, fid, t.ID); err != nil {
+			return err
+		}
+		if err := insertSQLiteComment(tx, bid, fid, t.ID, c); err != nil {
+			return err
+		}
+		return updateCommentSeq(tx, bid, f)
+	})
+	if err != nil {
+		return Thread{}, err
+	}
+	return t, nil
+}
+
+func (db *SQLiteDB) ReplyToThread(bid, fid, tid uint64, c Comment) (Comment, error) {
+	err := db.transaction(func(tx *sql.Tx) error {
+		f, err := sqliteBookFragment(tx, bid, fid)
+		if err != nil {
+			return err
+		}
+		t := f.thread(tid)
+		if t == nil {
+			return ErrNotFound
+		}
+		c.ID = f.nextCommentID()
+		c.Created = time.Now()
+		if err := insertSQLiteComment(tx, bid, fid, tid, c); err != nil {
+			return err
+		}
+		return updateCommentSeq(tx, bid, f)
+	})
+	if err != nil {
+		return Comment{}, err
+	}
+	return c, nil
+}
+
+func (db *SQLiteDB) ResolveThread(bid, fid, tid uint64, resolved bool) error {
+	return db.transaction(func(tx *sql.Tx) error {
+		if _, err := sqliteBookFragment(tx, bid, fid); err != nil {
+			return err
+		}
+		return execOne(tx, `UPDATE threads SET resolved = ? WHERE book_id = ? AND fragment_id = ? AND id = ?`,
+			resolved, bid, fid, tid)
+	})
+}
+
+// RemoveComment removes a comment from the thread. Removing the only
+// comment of the thread removes the thread.
+func (db *SQLiteDB) RemoveComment(bid, fid, tid, cid uint64) error {
+	return db.transaction(func(tx *sql.Tx) error {
+		f, err := sqliteBookFragment(tx, bid, fid)
+		if err != nil {
+			return err
+		}
+		t := f.thread(tid)
+		if t == nil {
+			return ErrNotFound
+		}
+		if err := execOne(tx, `DELETE FROM comments WHERE book_id = ? AND fragment_id = ? AND thread_id = ? AND id = ?`,
+			bid, fid, tid, cid); err != nil {
+			return err
+		}
+		if len(t.Comments) > 1 {
+			return nil
+		}
+		return execOne(tx, `DELETE FROM threads WHERE book_id = ? AND fragment_id = ? AND id = ?`, bid, fid, tid)
+	})
+}
+
+// updateCommentSeq saves the comment ID counter of the fragment.
+func updateCommentSeq(tx *sql.Tx, bid uint64, f Fragment) error {
+	return execOne(tx, `UPDATE fragments SET comment_seq = ? WHERE book_id = ? AND id = ?`, f.CommentSeq, bid, f.ID)
+}
+
+// SourceHistory returns the revisions of the fragment's original.
+func (db *SQLiteDB) SourceHistory(bid, fid uint64) ([]Revision, error) {
+	var revs []Revision
+	err := db.transaction(func(tx *sql.Tx) error {
+		f, err := sqliteBookFragment(tx, bid, fid)
+		if err != nil {
+			return err
+		}
+		revs, err = sqliteRevisions(tx, "source_revisions", "fragment_id", bid, fid)
+		if err != nil || len(revs) > 0 {
+			return err
+		}
+		revs = []Revision{{
+			ID:      1,
+			Created: f.Created,
+			Text:    f.Text,
+		}}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return revs, nil
+}
+
+func (db *SQLiteDB) Translate(bid, fid, vidOrZero uint64, text string) (TranslationVersion, int, error) {
+	var vers TranslationVersion
+	now := time.Now()
+	var fragmentsTranslated int
+	err := db.transaction(func(tx *sql.Tx) error {
+		f, err := sqliteBookFragment(tx, bid, fid)
+		if err != nil {
+			return err
+		}
+		if vidOrZero != 0 && !has(f.VersionsIDs, vidOrZero) {
+			return ErrNotFound
+		}
+
+		if err := sqliteTouch(tx, bid, now); err != nil {
+			return err
+		}
+
+		var prev *Revision
+		if vidOrZero == 0 {
+			vid, err := nextSeq(tx, bid, "version_seq")
+			if err != nil {
+				return err
+			}
+			if _, err := tx.Exec(`INSERT INTO versions (book_id, id, fragment_id, created, updated, text)
+				VALUES (?, ?, ?, ?, ?, ?)`, bid, vid, fid, sqlTime(now), sqlTime(now), text); err != nil {
+				return err
+			}
+			if len(f.VersionsIDs) == 0 {
+				if err := countSQLiteTranslated(tx, bid, now, wordCount(f.Text), 1); err != nil {
+					return err
+				}
+			}
+			vers.ID = vid
+			vers.Created = now
+		} else {
+			if err := tx.QueryRow(`SELECT id, created, updated, text FROM versions WHERE book_id = ? AND id = ?`,
+				bid, vidOrZero).Scan(&vers.ID, sqlTimeDest{&vers.Created}, sqlTimeDest{&vers.Updated}, &vers.Text); err != nil {
+				return err
+			}
+			prev = &Revision{Created: vers.Updated, Text: vers.Text}
+		}
+
+		if err := appendSQLiteRevision(tx, "revisions", "version_id", bid, vers.ID, prev, now, text); err != nil {
+			return err
+		}
+
+		vers.Updated = now
+		vers.Text = text
+		if _, err := tx.Exec(`UPDATE versions SET updated = ?, text = ? WHERE book_id = ? AND id = ?`,
+			sqlTime(now), text, bid, vers.ID); err != nil {
+			return err
+		}
+
+		fragmentsTranslated, err = sqliteFragmentsTranslated(tx, bid)
+		return err
+	})
+	if err != nil {
+		return TranslationVersion{}, 0, err
+	}
+	return vers, fragmentsTranslated, nil
+}
+
+func (db *SQLiteDB) RewriteVersions(bid uint64, rewrite func(string) string) ([]VersionChange, error) {
+	changes := []VersionChange{}
+	now := time.Now()
+	err := db.transaction(func(tx *sql.Tx) error {
+		book, err := sqliteBook(tx, bid, false)
+		if err != nil {
+			return err
+		}
+		for i, fid := range book.FragmentsIDs {
+			versions, err := sqliteVersions(tx, bid, fid)
+			if err != nil {
+				return err
+			}
+			for _, v := range versions {
+				text := rewrite(v.Text)
+				if text == v.Text {
+					continue
+				}
+				prev := &Revision{Created: v.Updated, Text: v.Text}
+				if err := appendSQLiteRevision(tx, "revisions", "version_id", bid, v.ID, prev, now, text); err != nil {
+					return err
+				}
+				if _, err := tx.Exec(`UPDATE versions SET text = ? WHERE book_id = ? AND id = ?`,
+					text, bid, v.ID); err != nil {
+					return err
+				}
+				changes = append(changes, VersionChange{fid, v.ID, i + 1, v.Text, text})
+			}
+		}
+		if len(changes) == 0 {
+			return nil
+		}
+		return sqliteTouch(tx, bid, now)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
+
+func (db *SQLiteDB) RemoveVersion(bid, fid, vid uint64) (int, error) {
+	now := time.Now()
+	var fragmentsTranslated int
+	if err := db.transaction(func(tx *sql.Tx) error {
+		f, err := sqliteBookFragment(tx, bid, fid)
+		if err != nil {
+			return err
+		}
+		if !has(f.VersionsIDs, vid) {
+			return ErrNotFound
+		}
+		first, err := sqliteFirstVersion(tx, bid, fid)
+		if err != nil {
+			return err
+		}
+
+		if _, err := tx.Exec(`DELETE FROM revisions WHERE book_id = ? AND version_id = ?`, bid, vid); err != nil {
+			return err
+		}
+		if _, err := tx.Exec(`DELETE FROM versions WHERE book_id = ? AND id = ?`, bid, vid); err != nil {
+			return err
+		}
+		// The fragment counts on the day of its first version left.
+		newFirst, err := sqliteFirstVersion(tx, bid, fid)
+		if err != nil {
+			return err
+		}
+		if !newFirst.Equal(first) {
+			words := wordCount(f.Text)
+			if err := countSQLiteTranslated(tx, bid, first, words, -1); err != nil {
+				return err
+			}
+			if !newFirst.IsZero() {
+				if err := countSQLiteTranslated(tx, bid, newFirst, words, 1); err != nil {
+					return err
+				}
+			}
+		}
+		if f.PreferredVersion == vid {
+			if _, err := tx.Exec(`UPDATE fragments SET preferred_version = 0 WHERE book_id = ? AND id = ?`, bid, fid); err != nil {
+				return err
+			}
+		}
+
+		if err := sqliteTouch(tx, bid, now); err != nil {
+			return err
+		}
+		fragmentsTranslated, err = sqliteFragmentsTranslated(tx, bid)
+		return err
+	}); err != nil {
+		return 0, err
+	}
+	return fragmentsTranslated, nil
+}
+
+func (db *SQLiteDB) SetPreferredVersion(bid, fid, vidOrZero uint64) error {
+	return db.transaction(func(tx *sql.Tx) error {
+		f, err := sqliteBookFragment(tx, bid, fid)
+		if err != nil {
+			return err
+		}
+		if vidOrZero != 0 && !has(f.VersionsIDs, vidOrZero) {
+			return ErrNotFound
+		}
+		_, err = tx.Exec(`UPDATE fragments SET preferred_version = ? WHERE book_id = ? AND id = ?`, vidOrZero, bid, fid)
+		return err
+	})
+}
+
+func (db *SQLiteDB) History(bid, fid, vid uint64) ([]Revision, error) {
+	var revs []Revision
+	err := db.transaction(func(tx *sql.Tx) error {
+		f, err := sqliteBookFragment(tx, bid, fid)
+		if err != nil {
+			return err
+		}
+		if !has(f.VersionsIDs, vid) {
+			return ErrNotFound
+		}
+
+		revs, err = sqliteRevisions(tx, "revisions", "version_id", bid, vid)
+		if err != nil || len(revs) > 0 {
+			return err
+		}
+
+		var r Revision
+		if err := tx.QueryRow(`SELECT updated, text FROM versions WHERE book_id = ? AND id = ?`,
+			bid, vid).Scan(sqlTimeDest{&r.Created}, &r.Text); err != nil {
+			return err
+		}
+		r.ID = 1
+		revs = []Revision{r}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return revs, nil
+}
+
+func sqliteScratchpad(tx *sql.Tx, bid uint64) (*Scratchpad, error) {
+	sp := Scratchpad{ID: bid}
+	err := tx.QueryRow(`SELECT created, updated, text FROM scratchpads WHERE book_id = ?`, bid).Scan(
+		sqlTimeDest{&sp.Created}, sqlTimeDest{&sp.Updated}, &sp.Text)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	return &sp, nil
+}
+
+func (db *SQLiteDB) Scratchpad(bid uint64) (Book, Scratchpad, error) {
+	var book Book
+	var sp Scratchpad
+	err := db.transaction(func(tx *sql.Tx) error {
+		var err error
+		book, err = sqliteBook(tx, bid, false)
+		if err != nil {
+			return err
+		}
+		p, err := sqliteScratchpad(tx, bid)
+		if p != nil {
+			sp = *p
+		}
+		return err
+	})
+	if err != nil {
+		return Book{}, Scratchpad{}, err
+	}
+	return book, sp, nil
+}
+
+func (db *SQLiteDB) UpdateScratchpad(bid uint64, text string) error {
+	now := sqlTime(time.Now())
+	_, err := db.Exec(`INSERT INTO scratchpads (book_id, created, updated, text) VALUES (?, ?, ?, ?)
+		ON CONFLICT (book_id) DO UPDATE SET updated = excluded.updated, text = excluded.text`,
+		bid, now, now, text)
+	return err
+}
+
+func sqliteGlossary(tx *sql.Tx, bid uint64) ([]Term, error) {
+	rows, err := tx.Query(`SELECT id, created, updated, source, target, notes, pos FROM glossary
+		WHERE book_id = ? ORDER BY id`, bid)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	terms := []Term{}
+	for rows.Next() {
+		var t Term
+		if err := rows.Scan(&t.ID, sqlTimeDest{&t.Created}, sqlTimeDest{&t.Updated},
+			&t.Source, &t.Target, &t.Notes, &t.PartOfSpeech); err != nil {
+			return nil, err
+		}
+		terms = append(terms, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	sortTerms(terms)
+	return terms, nil
+}
+
+func (db *SQLiteDB) Glossary(bid uint64) (Book, []Term, error) {
+	var book Book
+	var terms []Term
+	err := db.transaction(func(tx *sql.Tx) error {
+		var err error
+		book, err = sqliteBook(tx, bid, false)
+		if err != nil {
+			return err
+		}
+		terms, err = sqliteGlossary(tx, bid)
+		return err
+	})
+	if err != nil {
+		return Book{}, nil, err
+	}
+	return book, terms, nil
+}
+
+func (db *SQLiteDB) AddTerm(bid uint64, t Term) (Term, error) {
+	err := db.transaction(func(tx *sql.Tx) error {
+		if _, err := sqliteBook(tx, bid, false); err != nil {
+			return err
+		}
+		var err error
+		if t.ID, err = nextSeq(tx, bid, "term_seq"); err != nil {
+			return err
+		}
+		t.Created = time.Now()
+		t.Updated = t.Created
+		_, err = tx.Exec(`INSERT INTO glossary (book_id, id, created, updated, source, target, notes, pos)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?)`, bid, t.ID, sqlTime(t.Created), sqlTime(t.Updated),
+			t.Source, t.Target, t.Notes, t.PartOfSpeech)
+		return err
+	})
+	if err != nil {
+		return Term{}, err
+	}
+	return t, nil
+}
+
+func (db *SQLiteDB) UpdateTerm(bid uint64, t Term) (Term, error) {
+	err := db.transaction(func(tx *sql.Tx) error {
+		if _, err := sqliteBook(tx, bid, false); err != nil {
+			return err
+		}
+		err := tx.QueryRow(`SELECT created FROM glossary WHERE book_id = ? AND id = ?`, bid, t.ID).
+			Scan(sqlTimeDest{&t.Created})
+		if err == sql.ErrNoRows {
+			return ErrNotFound
+		} else if err != nil {
+			return err
+		}
+		t.Updated = time.Now()
+		_, err = tx.Exec(`UPDATE glossary SET updated = ?, source = ?, target = ?, notes = ?, pos = ?
+			WHERE book_id = ? AND id = ?`, sqlTime(t.Updated), t.Source, t.Target, t.Notes,
+			t.PartOfSpeech, bid, t.ID)
+		return err
+	})
+	if err != nil {
+		return Term{}, err
+	}
+	return t, nil
+}
+
+func (db *SQLiteDB) RemoveTerm(bid, tid uint64) error {
+	return db.transaction(func(tx *sql.Tx) error {
+		if _, err := sqliteBook(tx, bid, false); err != nil {
+			return err
+		}
+		return execOne(tx, `DELETE FROM glossary WHERE book_id = ? AND id = ?`, bid, tid)
+	})
+}
+
+func sqliteSavedFilters(tx *sql.Tx, bid uint64) ([]SavedFilter, error) {
+	rows, err := tx.Query(`SELECT name, query FROM saved_filters WHERE book_id = ? ORDER BY name`, bid)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	filters := []SavedFilter{}
+	for rows.Next() {
+		var f SavedFilter
+		if err := rows.Scan(&f.Name, &f.Query); err != nil {
+			return nil, err
+		}
+		filters = append(filters, f)
+	}
+	return filters, rows.Err()
+}
+
+func (db *SQLiteDB) SavedFilters(bid uint64) ([]SavedFilter, error) {
+	var filters []SavedFilter
+	err := db.transaction(func(tx *sql.Tx) error {
+		if _, err := sqliteBook(tx, bid, false); err != nil {
+			return err
+		}
+		var err error
+		filters, err = sqliteSavedFilters(tx, bid)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return filters, nil
+}
+
+func (db *SQLiteDB) SaveFilter(bid uint64, f SavedFilter) error {
+	return db.transaction(func(tx *sql.Tx) error {
+		if _, err := sqliteBook(tx, bid, false); err != nil {
+			return err
+		}
+		_, err := tx.Exec(`INSERT OR REPLACE INTO saved_filters (book_id, name, query) VALUES (?, ?, ?)`,
+			bid, f.Name, f.Query)
+		return err
+	})
+}
+
+func (db *SQLiteDB) RemoveFilter(bid uint64, name string) error {
+	return db.transaction(func(tx *sql.Tx) error {
+		if _, err := sqliteBook(tx, bid, false); err != nil {
+			return err
+		}
+		return execOne(tx, `DELETE FROM saved_filters WHERE book_id = ? AND name = ?`, bid, name)
+	})
+}
+
+func (db *SQLiteDB) Search(query string, limit int) ([]SearchResult, bool, error) {
+	books, err := db.BooksByActivity()
+	if err != nil {
+		return nil, false, err
+	}
+	c := newSearchCollector(query, limit)
+	err = db.transaction(func(tx *sql.Tx) error {
+		for _, book := range books {
+			if c.full() {
+				break
+			}
+			comments := make(map[uint64][]string)
+			rows, err := tx.Query(`SELECT fragment_id, text FROM comments WHERE book_id = ?
+				ORDER BY fragment_id, thread_id, id`, book.ID)
+			if err != nil {
+				return err
+			}
+			for rows.Next() {
+				var fid uint64
+				var text string
+				if err := rows.Scan(&fid, &text); err != nil {
+					rows.Close()
+					return err
+				}
+				comments[fid] = append(comments[fid], text)
+			}
+			rows.Close()
+			if err := rows.Err(); err != nil {
+				return err
+			}
+
+			rows, err = tx.Query(`SELECT f.id, f.text, v.id, v.text FROM fragments f
+				LEFT JOIN versions v ON v.book_id = f.book_id AND v.fragment_id = f.id
+				WHERE f.book_id = ? ORDER BY f.position, v.id`, book.ID)
+			if err != nil {
+				return err
+			}
+			type row struct {
+				fid   uint64
+				text  string
+				vid   sql.NullInt64
+				vtext sql.NullString
+			}
+			var frows []row
+			for rows.Next() {
+				var r row
+				if err := rows.Scan(&r.fid, &r.text, &r.vid, &r.vtext); err != nil {
+					rows.Close()
+					return err
+				}
+				frows = append(frows, r)
+			}
+			rows.Close()
+			if err := rows.Err(); err != nil {
+				return err
+			}
+
+			seqNum := 0
+			for i, r := range frows {
+				first := i == 0 || frows[i-1].fid != r.fid
+				if first {
+					seqNum++
+				}
+				hit := SearchHit{FragmentID: r.fid, SeqNum: seqNum}
+				if first {
+					hit.Field, hit.Text = fieldOriginal, r.text
+					c.add(book, hit)
+				}
+				if r.vid.Valid {
+					hit.VersionID, hit.Field, hit.Text = uint64(r.vid.Int64), fieldTranslation, r.vtext.String
+					c.add(book, hit)
+				}
+				if i == len(frows)-1 || frows[i+1].fid != r.fid {
+					for _, comment := range comments[r.fid] {
+						hit.VersionID, hit.Field, hit.Text = 0, fieldComment, comment
+						c.add(book, hit)
+					}
+				}
+			}
+
+			sp, err := sqliteScratchpad(tx, book.ID)
+			if err != nil {
+				return err
+			}
+			if sp != nil && sp.Text != "" {
+				c.add(book, SearchHit{Field: fieldScratchpad, Text: sp.Text})
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, false, err
+	}
+	return c.results, c.full(), nil
+}
+
+func (db *SQLiteDB) TranslationMemory(bid, fid uint64, limit int) ([]TMMatch, error) {
+	books, err := db.Books()
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[uint64]Book, len(books))
+	for _, book := range books {
+		byID[book.ID] = book
+	}
+
+	var matches []TMMatch
+	err = db.transaction(func(tx *sql.Tx) error {
+		if _, ok := byID[bid]; !ok {
+			return ErrNotFound
+		}
+		f, err := sqliteFragment(tx, bid, fid)
+		if err != nil {
+			return err
+		}
+
+		c := newTMCollector(f.Text, limit)
+		rows, err := tx.Query(`SELECT f.book_id, f.id, f.text, v.text FROM fragments f
+			JOIN versions v ON v.book_id = f.book_id AND v.fragment_id = f.id
+			WHERE NOT (f.book_id = ? AND f.id = ?)
+			ORDER BY f.book_id, f.position, v.id`, bid, fid)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+		var book Book
+		var id uint64
+		var orig string
+		var score int
+		var translations []string
+		flush := func() {
+			if score >= 0 && len(translations) > 0 {
+				c.add(book, id, orig, score, translations)
+			}
+			translations = nil
+		}
+		for rows.Next() {
+			var b, i uint64
+			var o, t string
+			if err := rows.Scan(&b, &i, &o, &t); err != nil {
+				return err
+			}
+			if b != book.ID || i != id {
+				flush()
+				var ok bool
+				book, ok = byID[b]
+				id, orig, score = i, o, -1
+				if ok {
+					score = c.score(o)
+				}
+			}
+			translations = append(translations, t)
+		}
+		if err := rows.Err(); err != nil {
+			return err
+		}
+		flush()
+		matches = c.result()
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return matches, nil
+}
+
+// ExportBook returns all the data of the book, including its edit history.
+func (db *SQLiteDB) ExportBook(bid uint64) (BookData, error) {
+	var data BookData
+	err := db.transaction(func(tx *sql.Tx) error {
+		var err error
+		data = BookData{}
+		data.Book, err = sqliteBook(tx, bid, true)
+		if err != nil {
+			return err
+		}
+		data.Fragments = make([]Fragment, 0, len(data.Book.FragmentsIDs))
+		data.Versions = make([]TranslationVersion, 0, data.Book.FragmentsTranslated)
+		data.History = make(map[uint64][]Revision)
+		data.SourceHistory = make(map[uint64][]Revision)
+		for _, fid := range data.Book.FragmentsIDs {
+			f, err := sqliteFragment(tx, bid, fid)
+			if err != nil {
+				return err
+			}
+			data.Fragments = append(data.Fragments, f)
+			revs, err := sqliteRevisions(tx, "source_revisions", "fragment_id", bid, fid)
+			if err != nil {
+				return err
+			}
+			if len(revs) > 0 {
+				data.SourceHistory[fid] = revs
+			}
+
+			versions, err := sqliteVersions(tx, bid, fid)
+			if err != nil {
+				return err
+			}
+			for _, v := range versions {
+				data.Versions = append(data.Versions, v)
+				revs, err := sqliteRevisions(tx, "revisions", "version_id", bid, v.ID)
+				if err != nil {
+					return err
+				}
+				if len(revs) > 0 {
+					data.History[v.ID] = revs
+				}
+			}
+		}
+
+		if data.Glossary, err = sqliteGlossary(tx, bid); err != nil {
+			return err
+		}
+		if data.Filters, err = sqliteSavedFilters(tx, bid); err != nil {
+			return err
+		}
+
+		data.Scratchpad, err = sqliteScratchpad(tx, bid)
+		return err
+	})
+	if err != nil {
+		return BookData{}, err
+	}
+	return data, nil
+}
+
+// ImportBook adds the book to the database. If keepIDs is true, the IDs of
+// the book, its fragments and versions are preserved; otherwise new IDs are
+// assigned. A book which was removed is put in the trash.
+func (db *SQLiteDB) ImportBook(data BookData, keepIDs bool) (uint64, error) {
+	book := data.Book
+	var goal DailyGoal
+	if book.Goal != nil {
+		goal = *book.Goal
+	}
+	err := db.transaction(func(tx *sql.Tx) error {
+		var id interface{}
+		if keepIDs {
+			var n int
+			if err := tx.QueryRow(`SELECT COUNT(*) FROM books WHERE id = ?`, book.ID).Scan(&n); err != nil {
+				return err
+			} else if n > 0 {
+				return fmt.Errorf("book %d already exists", book.ID)
+			}
+			id = book.ID
+		}
+		res, err := tx.Exec(`INSERT INTO books (id, title, created, last_activity, last_visited_page, deleted,
+			source_lang, target_lang, author, original_title, source_url, notes, tags, collection, archived,
+			goal_amount, goal_unit)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`, id, book.Title, sqlTime(book.Created),
+			sqlTime(book.LastActivity), book.LastVisitedPage, sqlTime(book.Deleted),
+			book.SourceLang, book.TargetLang, book.Author, book.OriginalTitle, book.SourceURL, book.Notes,
+			book.TagList(), book.Collection, book.Archived, goal.Amount, goal.Unit)
+		if err != nil {
+			return err
+		}
+		bid, err := res.LastInsertId()
+		if err != nil {
+			return err
+		}
+		book.ID = uint64(bid)
+
+		var fragmentSeq, versionSeq uint64
+		nextID := func(seq *uint64, id uint64) uint64 {
+			if !keepIDs {
+				*seq++
+				return *seq
+			}
+			if id > *seq {
+				*seq = id
+			}
+			return id
+		}
+
+		versions := make(map[uint64]TranslationVersion, len(data.Versions))
+		for _, v := range data.Versions {
+			versions[v.ID] = v
+		}
+
+		for i, f := range data.Fragments {
+			fid := nextID(&fragmentSeq, f.ID)
+			f.upgradeComment()
+			if _, err := tx.Exec(`INSERT INTO fragments (book_id, id, position, created, updated, source_updated, text, starred, status, comment_seq)
+				VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`, book.ID, fid, i, sqlTime(f.Created), sqlTime(f.Updated),
+				sqlTime(f.SourceUpdated), f.Text, f.Starred, f.Status, f.CommentSeq); err != nil {
+				return err
+			}
+			for _, t := range f.Threads {
+				if _, err := tx.Exec(`INSERT INTO threads (book_id, fragment_id, id, resolved) VALUES (?, ?, ?, ?)`,
+					book.ID, fid, t.ID, t.Resolved); err != nil {
+					return err
+				}
+				for _, c := range t.Comments {
+					if err := insertSQLiteComment(tx, book.ID, fid, t.ID, c); err != nil {
+						return err
+					}
+				}
+			}
+			for _, r := range data.SourceHistory[f.ID] {
+				if _, err := tx.Exec(`INSERT INTO source_revisions (book_id, fragment_id, id, created, text)
+					VALUES (?, ?, ?, ?, ?)`, book.ID, fid, r.ID, sqlTime(r.Created), r.Text); err != nil {
+					return err
+				}
+			}
+
+			for _, vid := range f.VersionsIDs {
+				v, ok := versions[vid]
+				if !ok {
+					continue
+				}
+				newVID := nextID(&versionSeq, vid)
+				if vid == f.PreferredVersion {
+					if _, err := tx.Exec(`UPDATE fragments SET preferred_version = ? WHERE book_id = ? AND id = ?`,
+						newVID, book.ID, fid); err != nil {
+						return err
+					}
+				}
+				if _, err := tx.Exec(`INSERT INTO versions (book_id, id, fragment_id, created, updated, text)
+					VALUES (?, ?, ?, ?, ?, ?)`, book.ID, newVID, fid, sqlTime(v.Created), sqlTime(v.Updated), v.Text); err != nil {
+					return err
+				}
+				for _, r := range data.History[vid] {
+					if _, err := tx.Exec(`INSERT INTO revisions (book_id, version_id, id, created, text)
+						VALUES (?, ?, ?, ?, ?)`, book.ID, newVID, r.ID, sqlTime(r.Created), r.Text); err != nil {
+						return err
+					}
+				}
+			}
+		}
+
+		if _, err := tx.Exec(`UPDATE books SET fragment_seq = ?, version_seq = ? WHERE id = ?`,
+			fragmentSeq, versionSeq, book.ID); err != nil {
+			return err
+		}
+		if err := fillSQLiteTranslatedDays(tx, book.ID); err != nil {
+			return err
+		}
+
+		if sp := data.Scratchpad; sp != nil {
+			if _, err := tx.Exec(`INSERT INTO scratchpads (book_id, created, updated, text) VALUES (?, ?, ?, ?)`,
+				book.ID, sqlTime(sp.Created), sqlTime(sp.Updated), sp.Text); err != nil {
+				return err
+			}
+		}
+
+		var termSeq uint64
+		for _, t := range data.Glossary {
+			if _, err := tx.Exec(`INSERT INTO glossary (book_id, id, created, updated, source, target, notes, pos)
+				VALUES (?, ?, ?, ?, ?, ?, ?, ?)`, book.ID, nextID(&termSeq, t.ID), sqlTime(t.Created),
+				sqlTime(t.Updated), t.Source, t.Target, t.Notes, t.PartOfSpeech); err != nil {
+				return err
+			}
+		}
+		if _, err := tx.Exec(`UPDATE books SET term_seq = ? WHERE id = ?`, termSeq, book.ID); err != nil {
+			return err
+		}
+
+		for _, f := range data.Filters {
+			if _, err := tx.Exec(`INSERT INTO saved_filters (book_id, name, query) VALUES (?, ?, ?)`,
+				book.ID, f.Name, f.Query); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return book.ID, nil
+}
+
+// Backup writes a consistent copy of the database to w. size is called
+// with the size of the copy before anything is written.
+func (db *SQLiteDB) Backup(w io.Writer, size func(int64)) error {
+	dir, err := ioutil.TempDir("", "tl-backup")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "backup.db")
+	if _, err := db.Exec(`VACUUM INTO ?`, path); err != nil {
+		return err
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	fi, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	size(fi.Size())
+	_, err = io.Copy(w, f)
+	return err
+}
@@ -716,60 +716,75 @@ tW742zXqbWpRb1fmEsYm9Zjvf/iPAAAA///cOPUCGHkAAA==
 
 	"/css/my.css": {
 		local:   "css/my.css",
-		size:    12689,
-		modtime: 1535945601,
+		size:    16487,
+		modtime: 1792275742,
 		compressed: `
-H4sIAAAAAAAC/7QaW2/btvo9v4InxYBmx9Jku87FwfK+5z4WWUGJlE2EInUoOnYW9L8f8CaRIiU73Vaj
-SSx9N3787mQuYUmx+ZmVXCAsKO468ATkHkOkfgv9BbwD83oLGGf4Efy4msEtOXrrcVESV0DWUSi5AFJs
-GZeftzURncyqPaHoxsfKJG+3YNmeQMcpQeDTXa0+4D+kabmQkMkERY9YTEtJMY/O5D6j0FH4vAoFKrmU
-vPmoTHnFmwYzCcXbx0VCOV+Mn0jwfgVACxEibLcFxePVCElvodwHyvDZZhTXMhMQkUO3BcuiPUV8HYlB
-GSEFQXb7y0hoYUtYvewEPzCUVZxysQWf4EZ9HtXLULtjlfhrVYzAuj2phxKfZAYp2bEtUAtSzyzx455I
-HKkF5RWnmVbKIvWmIQhRnHyl1DC1EIwNqxqCd1AdRKeetpwwicUH9vNIkNxvwab4JSG4BE/g11lDRA/q
-M+aXKyWF5nLbntR/pa0Gih1hPTVjSBgRSdgO5OVBSs46jT2CDPGV8rfgQT3z8SHFQmYVZxIShgV490xW
-UwAetJITCgwXV567ZDUXTf/K19KyUGryVvXFrerHVcTYPADv8XrBjyu1I+AJIPK6uLKKRuT1G0F//v76
-rFki0rUUvm1BTbFmoFG0Zi3KoGUFku0EP27BUsnHX7GoqfoKD5J7yJzTkp96fPN1INHtBWEveksA+O3X
-hNXV9+rz+Otvyb0d8QFEh9r8lOFTCxm6GTMmEzYS0elJ+NupwJ0yrQJTgSOZBoxg3Z4fMy7ILrOcbkDI
-WPFFRGqYxc/hQ4T+DrrADX/FGio0C70oZXcMvmYSlioTUuIH3xxWkrziwQKNxyxXcdTMa+UCeqmSSIrN
-9xpmR8IQP2YCd5ILrCXgLayIfNuCIl+NY0be8lYZn3YDzCQw4alHWSaS314hnAXMa2ggF1d5J6EQGA3P
-tFzORNHtAyq0BXvUTKTsKdRQLc66fIC9Xq8TqC3cEQYl4UxrGTyBroVMBV6DthPwbZxPTSCzGxjLaZNF
-MrZXD+tiVXlJqs9e1ephtdEiWeHDYPUNkU4VSOg5Zf6lZCpKHFqQt4LvhCqf3oOEVuE+fQxL9v7MTl1v
-TjaSjd5a9cAFSL8welMBhzOZdeQvbC3yCgBKGM72WOX4LVjmmzAHtyewMXBWKa4MWNsAnFpez673G8I0
-m5Ly6sXLJrossnJo0Y5WjpJTpAXhHVFr2QJYdpwezOYZl9LWpikU4xLBajTOIX9lhCF8chaW3BsdlzmU
-Q6WR0JpT2OoSLa4GLc5LuSkM1LBsgSlU8cSIa4y7JlTqHDvaEpUXR+7Qwh0W096jYS6w6chZjB+No0WL
-RQMpYS+RrS3NupxHlWUZ1RS3noIQrriARgMHppoOwnAc9hw75+cB/bg235PdnqodSi7pU13XdbmaxZPI
-D/WLWUDVXpjOYn1zKeTmZqbwxPBWqwAJ3iJ+ZFmD2WH45qwicN9NezI/Np6T2T1Zpyz33jzU+5t1Layw
-CmVHAdsLeFPixylQDFnvHJ7pDoc64gZAFyTTtgB+XH1SvYk2WgpLTMF7GEIYV9YRW3hOKs6yivLOZNZ0
-iBGGiDNKFWVuEzY8QzwyytvbWy0NaeAOZ9pNnmwxNpUHXbIfUCzVc4gmvFUUQ2GV7DEMou8mrksaKF4m
-7PANU8qPj3FL2nAEaYYIpHw3R4CwPRZETvV8RfBisgbodZt24ygKuthuY+ATgMbgNJkbb4+UHpVp9Q1F
-3v+V1fRAEHgC++ViHoDB14mqsReEqOBV6TLI+qR8ozgwWim+V/zAZNCUee1XbIk9RmR69/f3mqar4XQe
-/t+BS+MCNo8Y4kbYIFTYTZuLAPikFK52CM5tjgvMFW9aii37PiFhzEaG4VpshGt4oNI2KQ5bkQXvffY0
-lhwOYd5HuzAG0O0A88HMrEvwoxZUYIj89rIWcKdQlS21fWU7C9V3BOnIrrIOMtVly9vWhfBkVIrRSwpN
-RRUVuIlybTRcSRREVgLop3IQ5XwLZX9//w6F4Ee/ZS/8EqnwxLFmrucY0/WdSyFGyGmGo0Ld6GIQ8Nsp
-aymssNqJP3+/lry9fo4nA/08awYpsVLLuq8vVaJVP4petZ5XOQl1kG2hwMa63R7p+dosiBF2EsasJHOc
-jSNXkFafN8UvIAOroj3djOvuYnIslNSEAfE1aGa2s+rrkc5qsPB0+G9oUBULaQC9jH9Xd1o+X3V2KDCr
-O4f0AeMzFvgh1U3r5RLTM+volad1M6M7r3GzT0QfI6bUoHB81VmMed1ZpMtUVySt7qxi/q7b2oX8U8oz
-JzW6tVTSq28zxzTDiYRWYoj2HvQJbnw7hhmCL4Vtp6I6bqGAMkVRdTnR+c94Eg5SI28Euz1G4NPd3Z0d
-+Pp0Tb1tpj1+LdGnxaGU9ssJN7GPCQ6jsYCyX5Jv0uvzD2CeAvS+IBrK87xIEPEr24lzFFTXq/pxXLKt
-+qOBft020VuATT8tT/NLbU5odpuxEXBOSyhGyWBVjMDc/EE3R+8T86AYIS8l+yYw/f26Ffj1+tn33PhM
-KxxQWZ9LgoRa6cuLKeYMn2TMfHQcluSehnHs/RDm8dfDO5wR1h7MeLQfMi1H1Vs08NIUU/6hVmo2Otri
-bYlrLvAiwtHE5pGsr+lx8xZcg+uA9x7TNu5aVkXQtuwEfgtXH6KDV9KRklDtMHuCEGa+pTg3nUHSf1Ps
-jwBUS+A3P0Gg+2KMF35DUMKMoOfFFcw70hAK7RA5Dh8DsGsGvCc1rw6dR6QH6R9oCE07mnX0TWMnSfXy
-NuoKanLCKByAJioXl0FslP1S2C1oCOtHPutRI/Dw8OCzzfcYIh294gEt8MBqziUWYFj8tOl4hrMA1x4V
-vyk3hzUtYQzraDR9qnwJvjlRjVrBXnrzuz9ASYffEpMdPhNpLogGLhhP8NZHatZ7Tv0u3bqtG58yRj1d
-TzlrVXGqR8aL/plalX0402eayn4d+CuC4kXPjJMcwLurZ5YuVRgYuwUgiRI1biOdjKW1LNZWF/GAxdqB
-jQ0xFduLL6aki0FT5xiaFT/VXKAMkUrpDwqCO5ATiY3/LiYAukPZYdbhPxzgMCoPzz5+TBP4qgj4R0L9
-mPWflGlUbq+sbaUoHOgEaU41KUo6b8JlxkthKi6mF6xKB3NI06UiyOfr6ZX7qLCWdiTmMG/ADCo+waal
-5q/dpOLeGGdvjQGaGPklERsu8B+s5s7G/mspDPhDZXQRAc9HEoHyVv9LnGwOL6LLNBNa2Xlm90X3Tndz
-tuc0tDij5vMaditenN0wB5n23LFhb2bsOt8J2JQgbyflawVnX7F+osCGTK57PWV/W3BQLWgFO3t0KSUW
-+mTFVHL5fTjMrZcbuPoyPqu5nZMyDgmmGp/fGYWRCbwj+t7PSCuXoy7OAnUSi847ETRxgEhISeWvfHUH
-i8394wTclDBa0USSVyLfpmKpd05SfMF3Q/U8tUsjN5xkrrYf7FeXWZrr8719bTAih+Z8nAj8214wWHn3
-wUqE8cpf5+DYwSH0vblRB+6TR/rL9ZyVjWRxpSvgB6nW6xVVs+imLjNh68bRmm16h9WNopd7cVZiG15z
-fBrdpgSFO0tJX3qbuYKH0HzYaKAkFaTfGQ9POX7SzMckU6nw20w+i/DjfPg8jf4E9qvRDdNwGgrORlCQ
-t7zLCKupCZddqBSoPtE1EHsclnBXyo/TQZUwIonC+7hUKb2Cz9c/s8BUyfFThEA+fMnM9cOJZiwdpPac
-YUmqry2mlLBdcEa/mQv0fnazl0eCjn42S4Tr+UgC/UA1bJl0uClBm/e+lprzjGZm68GXq6o6x+FADY8n
-/47DEBfm6u+h2f9QR8zgq75k6N3umrrBFd/W8jopdxgdnfylLpk81BtUJQKfUhbih5Jie9VgxGCQMHkp
-2rV+cIdnunD9frg+mSiFJedUkjZTPZs4OxMIoe1UbXzS76Bys1TQY42OGMNJvs+mOVBJVGTSLqKvB2fN
-QWIU3bhyPP8fAAD//y/1f+GRMQAA
+H4sIAAAAAAAC/7Q7XXPiuLLv/ArdTE3VZA/2MRASArV5P8/7OHd2SrZkUEWWvLJIYKn5Xff9/rJT+rIl
+WzZkdneoCdjubkmt/m45lTCn2PxNci4QFhQ3DXgB8oAhUt9CX4ALMI+3gHGGd+DHbAI35+jc4qIoroCs
+oVByAaTYMi6/bEsiGpkUB0LRvY+VSF5vwaI+gYZTgsCnp1J9wP+QquZCQiYjFD1iQ1pqFtPoTB4SCh2F
+L8twQjmXklcfnVNa8KrCTEJx/viUUMrn/TsSXGYA1BAhwvZbkO1mPSS9hfIQMMMfNqG4lImAiBybLVhk
+9WkHRkhQGKcgyP5wGwk92RwWr3vBjwwlBadcbMEnuFaf3Qw4so67fZb4a1UDgVV9UjclPskEUrJnW6AW
+pO5Z4u8HIvGALSgtOE00U+axJxVBiOLoI8WGsYVgbIYqIbiA4igadbfmhEksPrCf7wTJwxass8+RiUvw
+An6ZFET0rD798VLFpFBcHuuT+q+4VUGxJ6ylZgQJIyIJ24M0P0rJWQMuQ8gQXzF/C57rU4gPKRYyKTiT
+kDAswMUTWU0B+NCyAhdL0D43MCVnMmnIn3gLnrPPAxxKYkxvB1rWJ5BFkLYH/qanFNnPcq0+AZLiIhQY
+zmeeMiclF5X/SOCa9u76O7vI1NZ6O/HgduLHbMAscwNc+pzXi1FSBF4AIm/zmRUORN6+EvT7r2/f9JCI
+NDWF5y0oKdYDaBQtDRalkwwFkuwFf9+ChZqf4kxJ1SU8Su4hc05zfmrxzWVHojkIwl61GAHw719inN2o
+z+6Xf0flsTcOINo9pKcEn2rI0H1/YAIut9FpSYBLCO6YaRkYM3ZR12Um1hz4e8IF2Sd2pHsQDqzGRURq
+mPnP4evHB9JILs4/SQIi9FdmIHDF37CGCiVL80WJLoNviYS5CgAo8X1OCgtJ3jC4hIZisRw6i7RUCqS5
+JYmk2FznnL8mighn5kYJk3fCEH9PBFY8wXpKvIYFkectyNJl33amNa+VQGvVwkwCY6ZblEUkCDC24Rpg
+WkIDOZ+ljYRCYNTd0/NyYo8en1GmtcKjZjxGS6GEanHWuATYq9UqglrDPWFQcUazHbyApoYMXBzaXsBz
+P64wBt3u6HCe1mlGfVzxvMqWhees3QNYLJ+Xaz0lO/nQAH5FpFGBIvoWU6lcMmV5jjVIa8H3AjcNuASO
+vcCtG+2W7P1MTo3vN3qA6qllD5yD+APDtxnwPY0W0RkAlDCcHLCKdbZgka7DWKQ+gbWBs0xx4dDKGvXY
+8trhWkUiTA+TU168el5Vh4d2Hnpq73YeOadIT4Q3RK1lC2DecHo0m2d0TEubppD1QyXL0aFf+jMhDOGT
+k7Do3mhbz6HsIq4I1xzDlrdwcdlxcXqW66w+hcsWmEJlYHazTrhLQqVx7OGWKF/bU4ca7rEY154duE2m
+B8pi9KhvLWosKkgJex3I2sKsy2lUnueD2OrRYxDCBRfQcODIVPJFGN7NxoZrIx2f/jBHOZD9gaodGomJ
+yrLMl5N4Evm2fz4JqNIsk2Gt7m+FXN9PBOAYPmoWIMFrxN9ZUmF27K6cVATqu65P5s/aUzK7J6uY5G7M
+Tb2/SVPDAitT9i5gfcPYOlDt4tusc4PX8EyW3MUm9wCCy5QsgB+zTypH00JLYY4puIQmhHElHUMJT0nB
+WVJQ3hjPGjcxwhBxQsnr9ncgwxPEB0L5+PioZ0MquMeJVpMXG+CN+UHn7DsUS/UaojFvBcVQWCZ7AwbW
+dz0MVCooXkfk8Iwp5e+7YWpecQRpggikfD9FgLADFkSO5b5Z8GA0BphMbcpBqaK17QZbeUojcJrMPbgE
+fFSi1SYpafsrKemRqKrRYTGfBmDwbSSMbCdClPEqdBhkdVKeKQ6EVorvBT8yGSSnXho6lMQWYyB6m81G
+03QxnPbDfxy5NCpg/YghbiYbmAq7aVMWAJ8Uw9UOwanNcYa54FVNsR2+dUgYs55guKwX4RIeqbSJj8NW
+ZMGl9Z5GksNi1KW3C30AnR8wH8zU/AR/1xMVGCI/ZS0F3CtUJUt1G9lOQrUpQtyyK6+DTHRZ87rGYsIq
+DdFzCk1ENQhwI+Far8gUCYjsDKDvysHA51so+/39OxSCv/tlgMwPkTJvOlbMdT1nPL5zLsRMcnzAXqBu
+eNFN8OspqSkssNqJ33+9k7y++zasNrR1vQmkyErt0G18WZ/Mn6xlradVbobayNZQYCY9CFNnnAQxkx2F
+MStJ3MhGkQtIiy/r7DNIwDKrT/f9uDsbLY9FOWFAfA6a2vUk+1qkqxzMPB7+ExxUwUIcQC/jn+Wdnp/P
+OlslmOSdQ/qA8Ok/2YdYN86XW0TPrKNlnubNBO+8xM3eEa2NGGODwvFZZzGmeWeRbmNdFpW6q4z5q2pr
+F/J3Mc90rHRqqWavribaVV1nRjMxRLsEeYIrY/dhOuNLYd0oq45rKKCMUZTIBFxBH6zfEQCx0j+CzQGr
+JtTT0242oGvibVPt8WOJ1i12obQfTrgi+pBgVxoLKHt00nV8fd7SwEuA3gZEXXieZhEifmQ70k9CZbks
+d/2Qbdm2SNp1W0dvAdZtBT4+XmxzQrFb94VAVU+h6DmDZdYDc/UHnRxdRupBQwRVmPkqMP31rhb47e4b
+uIR6Gfb2wgKV1bkoSMiVNrwYG5zhkxwO3msLRkePw7jhfRPmja+LdzghrD6a8mhbZFr0ordBwUtTjOmH
+WqnZ6MEWb3NccoHnAxxNbBrJ6pouN2/BHbgLxj5gWg+zlmUWpC17gc/h6kN08EYakhOqFeZAEMLMlxSn
+phNI+jfFfglApQR+8hMYugcjvPArghImBH2bz2DakIpQaIvIQ/PRAbtkwLtT8uLYeERakPaGhgAXt5t+
+raNNGhtJitdzLysoyQmjsAAaiVycB7FW9iGzW1AR1pZ8Vr1E4Pn52R82VS1uLByrggIt8MBKziUWoFv8
+uOh4gjMHdx4VPynXv9OaMIa1NRrvrt+CbzrLg1Swnb35bhsocfObY7LHVyzNDdbAGeORsXWbzmrPqd2l
+R7d1/c7lIKdrKSe1Ck51yXje3lOrsjcn8kwT2a8CfUVQvOqacXQEcHHxzMK5CgNjtwBEUQaJW48n/dna
+IVaWF8MCi5UDaxuGVGwuPh+b3RA01sfQQ/FTyQVKENG9OygIbkBKJDb6Ox8BaI55g1mD/+MAu1J52Pv4
+MU7gN0XAbwm1Zda/c069cHtpZStG4UhHSHOqSVHSeBUuhj2p7aLasemr0ME0aZqYBflytwO3oMJSYhFg
+3oMJVHyCqsakf+1HGXdmnJ0rAzRS8osiVlzg/7CSOxn7l6XQ4XeR0U0EPB2JGMpH/S/S2eweDA4VjXBl
+74ndg86dnqZkz3FofoXN1znsVjy/umEOMq65fcFeT8h1uhewykFaj86vFpz9hvUdBdZ5cp3rKfnbgqNK
+QQvY2NallFjozoqJ5NJNWMwtF2u4fOj3ah7r00dMgonGp3dGYSQC7wlnHqLlyu2o86tAjcSi8TqCxg4Q
+CSkp/JUvn2C23uxG4MYmoxlNJHkj8jxmS70+SfaAn7roeWyXemo4+zGx/eCwvE3SXJ7v7WuFETlW1+1E
+oN/2gMHSOxeXI4yX/jo7xQ6a0BtzshBsoi39xao+3TwXF7oCfpRqvV5QNYlu4jJjtu4drcmkt1tdz3q5
+B1dnbM1rik+9U6Ugc72U+OG/iaOICE2bjQpKUkD6nfGwy/GTYt4nGXOFXyf82QB/6A+/jaO/gMOyd9I2
+rIaCqxYUpDVvEsJKasxlEzIFqs/gGIhth0XUlfL3caNKGJFE4X18VjG+gi93P7PAWMjxU4RA2l0k5kjj
+SDIWN1IHzrAkxW81ppSwfdCjX08Zet+72cMjQUY/6SXC9XzEgX4gGraDNLjKQZ22uhar8/RqZqtOl4ui
+uDbCkZoxXvwzDp1dmIq/u2T/Qxkxg2/qv3+6a+wE1/C0lpdJuWb0oPMXO2TyXK5RETF8ilmIH3OK7VGD
+3gDdDKOHw13qB/d4IgvXz7vzlJFQWHJOJakTlbOJqzWBENpW1fqdfgeVmqWCFqvXYgwr+f4w1ZFKoiyT
+VhF95DipjhKjwYmrdkx72BWkAqsaFWdJW2SJ+KEWXZXAo+ijJS3XuPexCGvirEMl2o2UoXx8hGkcv0Qo
+PGucNhJS7E5ht8uAzw8PD8sbjgWlf8CkOODi1c919ZlmkHWl5ptphKeTNO4fMCFNcwyS6cy1cPtWZOPv
+xQY+olW+G6ijFqlzzfcC1odzosrXBL9HyUdRg9caRNUXQNsT4VLJ16fV4inblLtW51QNVJPZU940qtY5
+8sJGX6J8+MHhESe1p6SRUB6biG76wYBrFznw1HwlSMBSxize/5aLrLjrJgUhjBIwrIxTyB6xR6HMIHrA
+USKwrgV/w2iEzHrjkVkX+WZduIMzCts7wOsaiEYOXZlOy4gvqhsjrrvgSGmoJbXAJdbHcuJqlZUbXIzi
+pKfEXIBLbOLyIDD02n4D5+d4FQq7t47hQQlDMxW44fQN94h35kSZA3ehisodKkjbQ+BRgzcETMIqtK81
+fVkeweyO+Xdjj3QYehRcNfEKAb/b0F+mMYF1+PJF7K2X+Ps1XSMoXeNqsDG2b9F/bSdpX24K9PMatHOU
+A0aH2Wyn5pZQemSeRERUUfcXusPLwSESF4bqYp06fOVezHRi2YIFZ/v8OToS+oWKCksYW0Pw3Prnf+mv
+mEH4//8Ddz0c976LupjHHuw5pDGTOyJrLYFOyqL0e4/tKGPy571UMhKSqa59m4jF90LZvCZpjlWlPAOS
+vbcWnCVrDzUmxQEK6ZlGdea+FV1ztj00Q718+kq6HRmr5vSsig9qqwmlLlpppOCv2DOE5oY79LGMkErg
+iTQTUu/m1IYdORTtgsM30YBmUEIkrhpzK8EMeZzIfE6MLfb6uKnpv5fUHN/z6X/e3TinLipZxOnbblS4
+pxEvtc6LDOEIhfbAsqETQV0t8gyZWBpBQs9OuMfcjweU/n0O2SPrZD6tsH6XSr1Oiz2bhp7Xq4dyBGnE
+6hwwlBWsh8Liq94mBE7eMX4dYqi/CSLCqO9WTetYsb597g2cIHj2t9HnkbnqC5yW0eFu4RyjMuuTTil+
+wzRZxHe4eMQPm3wEZxnHecqL58dyBGcVx1munuFqbJyHOM7i+XGxfBrglEd5FDiK4h/jAj9m/x0AL28F
+HWdAAAA=
 `,
 	},
 
@@ -11190,24 +11205,52 @@ AAD//6jLA1LgAgAA
 `,
 	},
 
+	"/js/glossary.js": {
+		local:   "js/glossary.js",
+		size:    2078,
+		modtime: 1792273172,
+		compressed: `
+H4sIAAAAAAAC/6xVTW/jNhC951dMUQMkEYUp9qjEKQpsbkVbdHNb7IESRza7FBmQI8dt4f9eUJQtSpsG
+KVABUaKZx/nimxfeDa4l4x0X8PcVABsiQqRgWmJ3VwAbrn079OhIyIBK/8m5gO3DiAWwSLDpfOhhCxvO
+vicM/U36ZuJuBsSh6Q0lSHLJzjjNWZ2tC2CrXIt2BZTZmoAj8lwwBIxIU9npGQ99/uGLnBx3pV0qosCZ
+Go+yajJqRepiFPOJXJskPBJnP2nNZleuRu6NxnOKUy4sh/SOs6m1CnAeFQDK54AHdPQROzXYssIpX65R
+m6gai5pVQGHAGSXVH+rIz+EAeqS91zWw33799MSqi30Itn6tazFDUuNnTMRglDV/Ib8ATuKClNo7nC7d
++lalSDKg9UpzUcA6ZSw/7kPZ8Zu9dcrGubn0KIuBUox0g8/eRXzCIxWQ03ng0+/zZaSZt9a0X1mVWXHm
+yoYzebxBbegmUZOJBXRxOyMBKYxERkkq7JCEbK2PGIkzCmxFqMzOz071uI1+CC1+YUIelOUbCpNXZgcT
+mUrijRA54yshsuM9IZ59fOX8s4/vOew84Xy8GEHekdHNxH9fKrgGdsvgOg13co0qYfS/LtwndcBvNy7u
+/Qt/1x10vh0iL7hScCFg7w/4f7IhQbXdwRYa76nxR9l615nQLzY1RrXD+mIAYPfNw+9jMUB7hM5b61+M
+20Eq7cf72+bhvgnjD4Prcp84u9fm8MBEYQTIo3uDeUv0nnrLCz1oBiLvYr1Y3amPpRHAqgZtDSxXz6qF
+s7Uqxl9UjzWwhtyNVm6HYQE6VVev/NkqaxvVfq3TBg+W1jpiOuDfZZeAgDQEV2rHN+K4EMiPjz8/Pj2u
+Si1k8v2UXTSyGmqhlMs6IBFE9l4ry1n6z8HE3QqQUmVu8pVvnWUU2qSSFURSNMQKMIQxaxbQ9FWK5izq
+pXqm90mkZLe3cDB9DRS3HyDm98v2AyBd/TMAyfBkfB4IAAA=
+`,
+	},
+
 	"/js/index.js": {
 		local:   "js/index.js",
-		size:    2097,
-		modtime: 1523242641,
+		size:    3304,
+		modtime: 1792275430,
 		compressed: `
-H4sIAAAAAAAC/4RVW0/bTBB9z6+YT4rk3Q/IDQQSwUiVEGqR0j7QF4RQ2doTktZZR+sxqYv836u9+RYH
-XsJh58yZszO7a7bMZUTrVDIObwOAIM8QMlLriIL5YAAQpTIj2KSSVhmE8DgAAAjuhAyOLbzFnx4uhPLw
-01bVq4WHd7msYVJx8xcP73Hr4beIPPyavnp4g5GBTw1zf7cinkEIEsJrswmA9RKYhOsQphPulgAUUq4k
-yLn5vxw01oJJAEc2UnaUzw4qTz7QblL3mI2KPfwe+j6/DvW7X6ZqIwhCiGv7CRLEotCLoxekG0HI+LwK
-mTlD6Ob9aDgLjRl/qlkFCuUVbvMkeUChmiorH/yc5ipr6fvIYi1zwlYs87F7jFIZVzG3zefhmxk0i0XB
-y5Phm/GogZkS06Z4CZ614uWlx66VG/O3sZ7x8rnbtK1ComK/aZk1BSEsBK1GyyRNFWMSd2B7CCcQcxjD
-FE8be4pF0UnxOmNg5xP4H8zP7Iy7JH0GTNIV9ByCLN3gboUS1hJohbDMKVcYdA+RrRqGTQm97mtfgStd
-h9147FQOOT6fOJdesOK3azUt/8ozApnugjqzPKgx7deYOmMgXtJeHUf0UkcQVLiZ4hPMGdVH86PR8Gq/
-2qlL6fqsXWpCb0FHsQLanUU1tewO/6I9uWqivZUfMCNUsSgOFTbpuq4B75Q9bejrLu0Qf3e6ZJhjuGi1
-xvEOt0YT3muNFdAWLep6dDT7pLGY19d2yOI0yjcoiY8UirhgjNdXN0P6IgnVq0ha6zovoPUGAz5CEa0Y
-+3EMmLQY/kkYYgIhDBkmjeNvQ4R/9AM7xGSkIdsjxPrr4R8JTRNEigWxILTV9zIk7r5bVfsUuc3WvfaE
-/0JTvn1lKieOxfevS+nWymP7DJgPmeknnw9KrvcwHsPrenMJlIUzyOzvLpwB0uBfAAAA//9Ei1B1MQgA
-AA==
+H4sIAAAAAAAC/4xWbW/bNhD+7l9xAwKQXBw5SYsWsKsCQ9NiK5p1WLMPRVGsjHSO1VCkQZ38skD/fSAl
+6s1Omy/2iffcc8/dkZT4stQJZUZzAQ8TAFYWCAXZLCG2mEwAEqMLgtxoWhUQw5cJAAB7LzWb1uY7vA3m
+tbTB/G1tu9V9MN+XujNViy3vgvkJ18H8mFAw/zSbYF5h4s2vPXH/rWV6CTFoiF/7IgCyJXANr2O4OBfN
+EoBFKq0GvfDP1aS3xs4ZnNaeasT8/FHm859w96EHyF7GI/gj8EN85zqufmlsLgliSDv5CglSuXeL0R3S
+lSTkYtG6/Jwhrv+LLx5z7WwuvnaoPUobGN6VSn1Gafssq+D83ZS2GPAHz3WmS8KBrwi+T5gYnba+psxv
+Jw9+0DyVe1GdnTx4jdVZvfycO1GigoBaiWoe7KaVuf/vrRei+jZu2toi0f6waUUtCmK4lrSKlsoYy7nG
+LdQ9hDNIBczgAp/1akrlfhQSeGbAX5zDr+B/Lp8LsWj3gA96BUc2QWFy3K5QQ6aBVgjLkkqLbLyJ6qxx
+3Kdw6yH3K2hSd+5mPPVUHlP84lwsWrwjbPHDXH3J38uCQJst6yKrRzkujnNcNMJA3pmjPA0wUJ0Ca+1+
+SDXpSl25rfmz0Yi2Xqe0CRnr7FQ6wNGEDaQmcOpqq4NW4+G/HE6unejRzJ+xILSp3D+W2Ie7vN74Qdpn
+PX7XpS3i/ahLHjmDl4PWNLjHW+MAP2pNTeAk1tZYYwOrrzSeivGxvTXm/p+/P0AMCPFrH8JmbnHG4NQ/
+nnCMSNo7JNGkjhJlCiyIM7KsXUwlSc6ylAlPH96PkJsN3pg3Rin0CxxF73oorYI4qHCuU2CzpAU3tdwa
+Q7dmF62tydfEQ6MoI4VzYNdmg0DGH+0uFrhCuUHAfE1775b3CKYkMEuQei/mzcsRYCNVifMjpT5SbFtu
+T6kIZIlU6lYm93PQMsfuNmxfVX41jkGXSolmQt3pPInkd7njD641U8iRViadA/vr46cbNgWXdg4PvTKb
+NBVUnTqnz2jkXLj0yiTSISOLysiUiwFwKTPFdyvrkFKhJfcQWSzWRhd4gzvqTnNV11jV22gwZmmTVbZB
+jkFzmHK/nvl40k1UykJUV8WTKniyei/2hKcmKXPUJCKLMt039I1QzqLdWX+ikdGcJSpL7tn0YB+LRS+q
+qWMU4offa0w9RDGILPUTY6/efnh783YQnVqzTs1WnxXGEshjDGHrYbS2uEFNV7iUpSLezvSNMfcZFlGB
+xJkjYtP+QWg2und0G+FgIIvexqjFZTrF3dkyU4QWCnRdCwJXUt/hWOGPD5+7wPrHryhv84z6eb1RIP2h
+Ce1GqsFsvSTKctdllMmK83+ngGqACFfiCSqInRwlFiMX4Y6cD1XkTH4ASCGG9tvGwSSR5SyVhHX2gwiN
+25uatf6Cau7o3nXRAH6Jffrhm75V0qDE4Vs+zKSa1l8v/vvbHwmxmFTC1TCbwSbL50BFfAlF/buNLwFp
+8v8Akn63EugMAAA=
 `,
 	},
 
@@ -13346,18 +13389,32 @@ Fij7F85NA2inviqO9oIXTxxjoTN9xzp12LZwMLs7oNjdQpy/790tIG1+BwAA//8kKFw4KgUAAA==
 
 	"/js/remove-book.js": {
 		local:   "js/remove-book.js",
-		size:    973,
-		modtime: 1521679983,
+		size:    984,
+		modtime: 1792271127,
 		compressed: `
-H4sIAAAAAAAC/1xTTY+bMBC976+YlSLZqFkj7ZGE9LK5VT1U/QP+GMCNsVf2kE1V5b9XBpJALGFh5s17
-j5kxbwavyQbPC/j3AsCGhJAoWk1s9wKw4SbooUdPhYgozV/OC6gPIxbAIcFGkYcaNpwJNRAF/xaxD2dk
-xW7EbDirojQ2sELoTvoWVxQwEghJFDkzNknl0LAtvOY83aE+oWGFcOhb6mbK6406Z2pn9YnjkhHFZ8Qz
-evrARg6O+IyfDc+sk+mHxhKj7BieY7O7s3QDrnFkyeES+SkjeuKFILw86RrXQg0qBFLhInTwjY09v3kG
-6DEl2WIFbK8Ov8YaAnUITXAufFnf5tzT932pDnsVx4fBt8nC9s4y9SBV8CAGmMXWHwGcVOgqYJMY266C
-2smUfso+G1Lk30xuXVyBro/D4lVL55TUpwoipsHRsjN52Qb46xQqICIN0e8W4Y2Qf+SFr532SF0wFbCP
-44/j7+OT1SHmvyhzecpcEmXNymaxQgsT/PMM3pZxreiDkY6zzppFs+8lC1rm6yK6iA3UwEq2hjyLNdI6
-zi9d3EIiSUPaAsY4ikuHkXg+LWTuhbyuhj3v1yIPVFnC2fYVUKrfIU37V/0OSC//AwAA///YQMjizQMA
-AA==
+H4sIAAAAAAAC/2xTTY+bMBC951fMSpFsVNZIeyTL9rK5tT1U/QP+GMCNsVf2kE1V5b9XBtKFtCPFCp43
+7z1mBt6OXpMNnhfwewfAxoSQKFpN7LAD2HMT9Digp0JElOYX5wU0LxMWwCHBXpGHBvacCTUSBf8YcQhn
+ZMVhwuw5q6M0NrBC6F76DjcUMBEISRQ5MzZJ5dCwEh5yne5Rn9CwQjj0HfUL5fVGnSu1s/rEcc2I4i3i
+GT29YitHR3zBL4YX1tn0h8Yao+yUXnKLu7N0I25xZMnhGvkmI3rihSC83Oka10EDKgRS4SJ08K2NA795
+BhgwJdlhDexZvXwNZwTqEdrgXHi3vsuVJ6Aw3VKUqf/8XKmXZxWnH4NPs5vyL+E8jlTDhwbAoru9BHBS
+oauBfZ9nV26S2smUvskhe1PkH02eYtyAruXuP3+1dE5JfaohYhodrYeUw7bAH+ZUARFpjP6wSu+F/Ckv
+fOt0QOqDqYG9Hr8cfxzvrI4xv0WVe1XllihrNjaLDVqY4O/X8RbGdWIIRjrOemtWc7+FC1rmL0f0EVto
+gFVsC7kXa6V1nF/6WEIiSWMqAWOcxKXDSDw/FYd/G3nd7H0+r0XeraqCsx1qoNQ8QZrP9+YJkHZ/BgBu
+nPMA2AMAAA==
+`,
+	},
+
+	"/js/replace.js": {
+		local:   "js/replace.js",
+		size:    621,
+		modtime: 1792273883,
+		compressed: `
+H4sIAAAAAAAC/3SQUW6DMAyG3zmFpSEl0bpU6mMRu8ImrRdwEzOyhQQloaObevcJKJROGg9G+v3l92/z
+qnMqGe+4gJ8MgHWRIKZgVGJFBpBz7VXXkEtCBkJ95lxA+TyyAJYS5JUPDZSQc/YQqLWo6GmQmChuDLat
+PQ/Q0JGVcZozOYozNiHSO86UNeqTbWA9aQEwpcCZNhGPljTbQAodXT0Acokf2PP5DUBDqfZ6D+z15e3A
+NoveBbu/ppkccbwCEzdEY8KZiRQMWvNNfAEuYiGl9o74wK8DD9/R+3T0vURLIY2EVDW6d9LwCAxOFKLx
+LsJVlPPWKwsA6xUO4WQg61Fzseoum98HqtBY3tfhb57/jlihjbTyApgS93WQgWLrXaQD9eluXJHd/kO9
+CC6KbLuFk2n2kGK5gzjVr3IHlLLfAQDPGOG8bQIAAA==
 `,
 	},
 
@@ -13377,99 +13434,166 @@ QDx6DwAA//++F0tSBAIAAA==
 
 	"/js/translate.js": {
 		local:   "js/translate.js",
-		size:    23294,
-		modtime: 1536032118,
+		size:    34526,
+		modtime: 1792278862,
 		compressed: `
-H4sIAAAAAAAC/+x83W7kNpbwvZ+C7dQXSXFJZXdmBoOyy42g2zPzYbJJNjYwCdxOhyWxqhizRIWiylWJ
-DeQNFgvs5WIv52Zu9n7u8gQ7r5AnWfBPIimpqtw/2SyyGEzaJR0eHp5zeHj+qNEHYE7oFBLwnNJbjMoh
-mFJ6+wpnQzBjcL5EOS9fccohGXNWIecpg3lJIEeZfAU+GB2EsypPOaZ5GIHvDwAIqhKBkjOc8uD0AACC
-OEhhniJykWEOJiCvCGm/+JThufdyUDC0wrQq6+cHAJjZQFVkkKPPGJ0zVJahpHcIGgIVNQDgGVAvwZOJ
-vz5wf2+N8ABamEBr+ATIf0/brxukE2sGAyhWV6TcjAeTyQQcg2fgGIzBP0G+SGaEUhaeHB+DD2wCRwo+
-MngGYZAUmgPxFLIgSiDnLAxKviEoGILgDmd8MQ7AkZzvCAT/L+gcDZICsRTlPIgSjtY8LFLeAzhjUEqg
-noxjLiezCD0CwUhMapP7cGBwUYbnMVoWfBNDgpick87nBIVPmgEPjrRRhnmIjCTammEk3ShUZClXqEmQ
-WsUhmyPB+0GIEvXDfs3onXinXiQpoSUqeRhwFlhQMywEK2A1D3AWRElZTUvOwhMbXYZXXegyvLrG2VeT
-1Y2NdqXQZniVEJTP+QI8U7+6JgFjcGxNNKNsKRc1CIP3alHE4nHMlwUJomTBlySMrPmEID5WE6nVaIEn
-M5xnYcCzhAKpDkFk3kjlqH8xVBCYonD0Mh/NhyAIIk24mkMSpZElac5jatSrmTmyQdU6tX4NQTASlklq
-kjZRtWbN9N/1SoOoPed1DpdoskKsxDR/hbObWmdXkFRCZ1c4s6VVVtOlNFI2lrF6Wk+gfqp1CIk9A8El
-XKEAjEHwUZYFjrKhNYcMQQ+leWzDimdG+pplivWa53p2M1Q9Ff/x3xjh0DwMbtGmKkC6gPkcgSmpGEgJ
-Tm+DIQgjMDmvLVuHrOqZmylXkIRRv9Brg/FQK4ic39l+uVpms2215L+B6z9QtgwNRRnk8GpToDEIvimF
-NujnUzSjDF0ihiHB36FxayE2L13KT2sYYSqejK7/6z/jf/zLP/71ZoQTLral5GYEGOIVyxtojU380yw+
-/Or+ZRnFQLJgcPLTD/8GgmjXGDFiMDIDdsN/dX8dvyzD65voMHx5Gem5fvzb4Okec11Gh6EYngyfPBuf
-/vTDX6OXNzf3gxrL3/fA8jIR/5Mjfvrhrza4y1tLDwF48GQl94sRlNk+ah9muIRTgjJ5fFQoMiPLKk1R
-WY6lGrjy7XQmGtEPhJWrDaHe+50WUK5CPGnM6xAEK2FdxKxJbRoaQHdfSmwS1Fk+8H0Tz20YKvSdrsap
-tx+1IP6C+SKUJNgQ1iko37n6LXdaZDEOqM2XSAtg74YHX26IMcq6eN8rvBkkJYo8ScjDvRaF/NUvCPk6
-gUWB8kwxlaGyoHmJrlzmOoZKIU1pziHOETOYFTZfIR/0A0eDXPPRq1wd4sjwqnGRbFlkeKVdHt9ma5Oc
-0TtxvCF7ZiEylKSckT+jDXj/fYCSuwVOF2AyAScf2nJESclp8RmjBZxD5Xlb3GnZ19reoaRc4Bn/M9q4
-WlGb5c+V6yO8mlweOfqf0y7gF8qx0eO0PBq3ZjzDrORxusAkCxwMtW6+qN0cl55mETWYlvY6Fn6gi+4B
-IFKibQga8ngGzkGyjltOg7sP7L8k58Qp//77/nEi3fYgcIlvO51bd2TvnnSoUUuUKDwSniiD7W3zLuel
-Y9tbaCW/xBIlNV1S6bMdbfZ3s6A2Ms5WVKSKbWH8kiBR44OhhShqPHzhsdXTSWfJ2ZQCnwk4XNKkXteO
-reCINjbOGG/DworTPzJ6F0bJjKZVGXbEJtKpf06XwphDtmnClH3CjbQe9/muwMOFbVzwJV2h5wSWZRiU
-C2FZIt+Nb55I6xgEHRSIuZ0JEmHSxLrN3tNvfXJcCmYw5niJyjjFLCX1BpNKZgYI6x4GNTpLnAYCZlmD
-z5vXF+u2MSLgaATriU2BPE5ce0WHbaE2JrVLlDr6dWXY8O2JB72ApQtqMVATto9ItFD0iF6ZNEi3CaXD
-EGwb1silMQy21222YfdqjMa6+NyJulb8uMjdCagbCfRH1K8bwnqs3DsQlYAoa4ElU547B6WdiLDhlBvb
-wDCUZ4jpGMD2TcTLpeDbErJb4bxgHloBF85v8Wwzlh78sIknNgWdM1gsEPNffVtRjsoxCH78249//+mH
-//jph3+v47uHxqcSxl1yeJklirbwkjOcz3Wg5kKKQySbEnOOCAY0RrkB1R7sAmeoOZ4kGxOxncyzh4Yt
-LqaWy6gmn82s2T287lytvEAYnNU/ZcLiUPw8BGWBCEkXKL2dHEr/+vA86GBOYJ1kBk8D5qYL+na7z50e
-/97h0J5+7WM82x2+bUNffwhTPxuNwAtE4AaYIxxUOccE8AUCdfqlBDJBCgpGC8T4BuAS4BxzlVvIQPhH
-SucEgecLRpcoSkx0ivgVXiJa8VAHtVschiE4cXVKKYXt83Tq6uvlRd5urN2tLzqA9YJevUPbr34tYaWT
-eOrcZ82Z7jrtmnUW17zT1FWQ0HVWO93CftfP/G55v657pA5dzzvSh0jjGj0io739uJ3VgIY+x7mqia6R
-mCcduNqZ1MJJ2VigGZmDiTiZ+ZSuk5TmM2zttSUqSzhH41pJgrPp+eeSMdKOzCgh9A7nc6DzTM/ORtPz
-synT/yc0vZVn3XkAjtxE25GFc2QD1vu54pzm5djOfCnyxm70DqeIjEGgyAqGdiAmvKFP4FLYCuETZDCf
-I2aB1Duz/iOFhExhejsGDJUV4W1L/kS9aGdJB9JWhTZtS8QXNBuD4MXFxxdXFw5xFRNU7/aOxN8rnFlE
-RxaWJKM5CtsWRVpKMk+WNIMkDMQJ7Mb6dewotdx79Tbydz6hM4hJGK4XbAhKDnlVDoU9lGZaWpVQ/Oo2
-Le7GLDlk3rbEKc37Qha9sQTIm+0sT761bD/79PKqluy+UhWLCMwaD2xZtuoSgnI3BFjHYrhw/8W/nu+/
-jqvcfh10VCX2l4XLeoX5l8R8b2P9TOz3Oeyw35fN22O/CpP/QCqchbYIUuVFv2ed11ZF2IqXa4B4JpDY
-YW7qrrEFaS2xNQ0wnRRJiXgYqBFDcNx9lvfN1D2HmX/rTCe9SQ6kWysanXWr5OJd5LVhhB0Jj64T/w1L
-4k46joJzkOFV0BuAi4XEsnHgbcbfrxVy71nT9UOvvrruL8jd723H6SuvSYFsr63tWTLbVe769dWmtAj6
-6lMdEtpao/qfqEV1R+uvVQGQhkqP2Jb0f1zqXlli10C+I7NnghLP7L3zyMS4qf8XmrzN0OQdhCNCMV4n
-HAExONk3JFGqlVfLVzNMOGKyP/FoEAaJkmmsHoOyKry+I5vP9ni/nKk6/HqxOVPHtePSrnm+s/AJZtkb
-OUQ5utNFHXly5OguZvSut8XO8RbU2B5/4Y0sv8bs6o8x+eql3Ra229R2Q6+VC1YVpoHM7juwJpFDVf1w
-+xPlxoR6tHm1fXZ9Yu2cP7dbFXuewBlHrDV9hxPqiE48DF7D66z356NrPW/aJKfX4ffJCYG8UifY68mv
-OfNc/0ryFUzqCZ6Zv5z+1SDodp1wXlRcNW9KRO2+Tfn4Z2846/SKOzhnzne3CcaGUMnYM6wOwsnhDDYh
-M1Ax9OH52QifB9F+M+V8oeYJP4z2mQoyYbUYni84sBpiHjcpgY9ZXV2rBXUvQXu6dxpjGLyq6S/LQovo
-4AwacgvElpDg/PYQLBiaTQ7r3ewcVvXOdp4GLTjdTOjDHZ6/1wlZom9f5dXSBz8bwfOgftQWkBtLKwu1
-vX3QOTN03kGE2Tif1/tN9UTOenoiNQZlRIMzzgwHm5LE4fkZz0BKSVnAfHL4WyFxnon/MEfuO3ycI+Hj
-bHVvfi0BoozNrI6PJkSpJ+nMMbaPP+/w6+6uEdtKM5sycA74lGYbq+LsDt7aiP5LqA7XCdc6OKyTkl6M
-mNK85KDkOL3d/AmXnMrSv6LmeAyub5RYTpo/n5o/H8wNJjX6ecWYarC6vnHvMOVwhedCi0OcZ2g9BJza
-Xqkz/FqC3DQMcUjTb5OiKhfd42wJdQHIy0in9R0dBWJlqOvUZIHzHNmZaqsnoLzDPF1c0Ss4VUuKfIxS
-3QU3dL4vKVNGCbmiRXjcVexoITRXgLowJjlcgXNAcBAlCKaLMMTZeggQcRs2QkQit+dKOG4iQgU4W8u2
-Tpv4h/5FmN8FnKN3PaeIfWMVWAUuKj1HjVH/fvKkU0VUc+dBfYp4DEf8UkqYhQOxBs1tRPpcFgVs/jQt
-Ki7SBcwzgi6EYe4I4RqFXy9YwhDMNpcccqSuxUV1DkTFecEniN9RdqvsvDlCpNHyAC/UQSDOLhEdgvt7
-EFT5bU7vcjO4g1ZCYfYCzWQXCM1Lp1KvbMJASFuVHGz5J3Q9oyyLMywRQYZR7efbamzKAjanxfjuSo/O
-RRSkmuO8HKk5ahHXWYw/XjQ1OHH+jMH34NsKsc1YXejgDC/DqDlLDrbnKxrDdDwEvhclF9s4WW6adp8i
-j2v8hdAVhDD5vzn+jWvzLVJcYy/JkEmF4BPKwYxWeZYE21uT7UFuAmarivb1GbU153KT03yzVGozBGgN
-0z21B6YwQ0ucxqVG0aU7J9t1R82gxNmjAJYToWiTMpR/gwk42UcHDaW7tfDR2mYSWTLMs9iuWbdAUDUo
-DsLgLMMrY4rU80PHodRDhLvSHiCedoLPKOVdM6jn7hBNjlKnTrLl7H1bRUlBu/zoFSQl7W68V3Nrrb1E
-CAjQMXD118Uzo+xCnEWrdhpSLxSpAOvrMyiHxjibHA6+XyU4ezg8/1pn61atFdkEGRcQEa/t4bTjHoVl
-NeyGRcXCoeLU0KD+WczJyf8Cc3KlXe9HnkTLinAs3PYuG/L0Tc6fBvPPdwQ9/eUcQU9/4TqjvMJPGZ5f
-UUqmdP2o2w2UZJ/OZiWyOxipfBC6UJfSZ/8CTMAdzjN6p534LzqAvvSBvmwc2yaw9BxaebFBJXu5Wofh
-lN3+YNzeNvCwM3oV8bVzdcKbATwDJ2AMjg+sBIvV6atxumlnZ2lX1E4oNXwadj39EsQNwxNOC3DUYrp4
-3KR8DJqTPt99jvglIkh9jaNx3nUkKy1+RtNK3pZxYO3LJgLu/h6IfxMG8zl6Tqvcq6lZ9UwBxqluy49k
-eCt7rxecF+V4NJpW8+8wITBZUvUvZfORYP6raTVP0jl+hrPJ73/7u9//zumN1XfrZD6AJDnN0CdwKSIC
-EFxdfHH10ecXH1kX76Q7Q5LSLOiSQ8bBGbCfXeRZZPUqi8WI9/KA00lpsYQWmmELi93IX8qZJqA1zAZC
-eeaBXOT1t0CUdNhfKBNAo2sYf6evpcc3I1wZMM1uQ7F5fLfABIFQkXEOjgXDJK7mQnuSLiD7iGuYGJxE
-UaTojmMPjSD0TFlr/f2JfnQozwQilGdHR6fOFR6FoGFpqdmYZ9a3A76K7+OB/nyASU0ceDjaGk7zFIWz
-3Gn+goSYL6vosW7hTGq1grLN+sxJHqn38mMtDXMfmiqaJGMQmu0TqTDV6ZbzjU1fDavOXQ6BudCqbgD0
-jmC6fq7bFXrhmv58/VcPpHeZCcgLQzJnKIa6txl7J1MthFKRemFUn2Aw1I2TvXBoXcBclmT882sHT6QF
-rxmztXBZN5Bpbm8FhllmYHW92MrHFHCOc+mTgeSbalnEnOoEUP2RFEoJx4XVcCC1XrB0DIIp5ZwurY4D
-zvB8jtgYGBrqN8Kxad2rwpygMfj6TMpOlRxNiWLwvS5KPByen8kqGuCborlRhL9Dk8MPD/U1I0G0rMAI
-wPOzkUB4/vWw5UcJ7sirhsm0NGtrJ3IHYfBeIA4xq2lGl0UZhnGGypThKcqmmyCK2uUkSYRbRrJSsk6L
-lPJ9lcPreL9BO2tW4LxJmjl3brz03JYsup8MlRK2vBWTEd0zdWdT4dX45cpkns716jtSnInO9kWJhG/Y
-1JPctYuWah55kIgjvDORTIvQuSiqqVIpOauK7KXL3IDRumLejD/xx9dJkz0GP/UHOyGSh2BH+rbHRl/r
-gPhmV7Hi/h4031RQvyBR31fwO5CcZdo7RPXYDDt2zZKyYmHdzXvosVYdaUcAkxIvMZG2920uwZZ0exU7
-SV1Shv5/PqO6g6zLhrT3IQBJAYUuu50HXbn0lqnYVSXoIlOSZAfMgqhUbacrvV695SIHwZJWJbKLXB3l
-ql10afPQQlVfBJM9GCjnL9AMVsRqz9pSGxNukjAoKEkJRjn/wn6xsV586RqIgXF3pDGqfR8XyBSGUPlp
-ngpzIn00rzlHrbWjiGRf2iYUqtrzgVfj1mlNBueCVldj+hmyo1yokK6fgglYg7jNGwOxERAbC+LLBqKT
-qaCbpcDjlNsoK/ljq3cqWMKpOGcN9+aIh8eRDg6vaAFiQdwRCIq1e27KsQTNeN/gj9FMRALrenS9b1yW
-ozx7objuybMOI+W9Z6mt2kUVUoqsx7JVTOOxLi/X4/PO4Xn/6GZrN874W2llll9DMJ/x8VKw5rH/IZq9
-vocyUB8cURXPjs/j1GFA5KRZEwkSeg/7a9vNaWlsuFjuk9C375oXUdQ6FGrG/P5DMPoAfER4fAk+GPk1
-+P4Nt7NCbyUO3GSFm4yyAjeV9PTPIvdrZSqz6A+10ghdCLbYJLl/MlwWBG4CK0JtcPd+Os7NtbcqQru/
-XNRZfjxtgbQTwzs/HWTL98TI95/frnzlHtKMbXJthrGtTx9pSKcDqVcKOc1bTdPti/gaZVuEQ3ByfLzH
-l6NeVy0edn3pqOPDpwBuC0r6JWF6mD2TuOva6pb+KUGd6suOM0aLzPmMkOPEyab+vT23OoZSwzrivmsV
-oDKYYXp4E3j9prQIA/kJjKZjc5eTaeiPlyiv9nLFZIvhK92HVQ6B+nxq/aBDRtvCRr/LSkexdlZZchvG
-KnkcMyQiL+RNY112tNKzhKaSfLG7rDqhqY+qop4LVB+6C2Jp9QLPFwTPF9YXfHQmUm7GP5nXrT50hcjZ
-sh24vPPZ+TrK0J+j7X74h/vOsfLmY3R68BCJTTEagRVejgEvJ09Bqf57N3kKED/47wAAAP//t1CB5v5a
-AAA=
+H4sIAAAAAAAC/+x97XLjxrHofz1Fr6JrAF4SlNZJKkWJ2trybj5uHDvXq6rYtVdZQ8CQHAvEMIMhJXrN
+qrzBrfv/1HmB8+f8P//yBCevkCc51fMBzAwGJLUfjlOJq7wiMD1f3T3dPd09g9HHMCvZTVbCp4zdUlIP
+4Iax29e0GMCUZ7MFqUT9WjCRlWPBV8R5y7OqLjNBClkEH4+O4umqygVlVZzAmyOAaFUTqAWnuYjOjwBK
+IiDPqpyULwoqYALVqiy7BV9wOvMKT5acrClb1c37IwDTG6yWRSbI7zmbcVLXsRzvANoBqtEA0CmoQng0
+8ecH339v1fAAOi1Bp/oE5N/zbnHb6MTqwQDi7Ja5MPVhMpnAKTyFUxjD7zIxT6clYzw+Oz2Fj63qMFLw
+iWnnJI7SpcbA8CbjUZJmQvA4qsWmJNEAojtaiPk4gseyv8cQ/a8oWBvSJeE5qUSUpILci3iZix7AKc8k
+BZrOBBWyM2ugjyEaYaf2cLdHpi3G6WxIFkuxGWYl4bJPNpuVJH7UVtg61CYFFTExlOhyhqF0y1CJxVyx
+HoLkKpHxGUHcn8QkVQ92MWd3WKYK0rxkNalFHAkeWVBTioRFWI0DWkRJWq9uasHjM7u5gq5DzRV0/YoW
+f5ysr+1m16rZgq7TklQzMYen6inUCYzh1OpoyvhCTuokjn7SkGKIr4disSyjJJ2LRRknVn9IiM9UR2o2
+sgQgndKqiCNRpAwkO0SJKcGnuHniZFlmOYlH/7cazQYQRYkeuOpDDko3luaVGDLDXm3PiQ2q5qn5awDR
+CCWT5CQtohrOmurfzUyjpNvnqypbkMma8Jqy6jUtrhueXWflCnl2TQubWvXqZiGFlN3KWL1tOlCPah5I
+sacQvczWJIIxRM+KInKYjdyLjJPMa9K8tmHxnaG+RplCvca57t1UVW/xH7/EEIdVcXRLNqsl5POsmhG4
+KVcc8pLmt9EA4gQml41kC9Cq6bntcp2VcdJP9EZgbBsGkf07y69S02yXrab8t9n9LxlfxGZERSayq82S
+jCH6tkZu0O9vyJRx8lLSYKxnYUiiaFvQOrspSSFF0ookpma9ynNS12PZtDv5oIJqZfUJrpxmcWl+Cq4q
+/E9Ct0t2ANEaORZ7TWlhAaLAkuSeZ/WnZVbXcbTkZEo4J0WUJKahougW+r25DCOHJPuz+AP/85Smp88G
+aoxBHXjuMYpmgj9QMY/lEGwISzzLMm/KyAKJhX1QXJFK1oythraGnwwJCeeMhwjYywHTrKxJ4pFTap2G
+nvKpn5qyOM2WS1IVCqmc1EtW1eTKRa6zglSjOatERivCTcuqteTcndhWv3DY0F2gvRwaIEdB163utmlR
+0LXWxb4w0bKiYHcod4ndM5KMpLng5W/JBj76CEh6N6f5HCYTOPvEpiNJa8GWv+dsmc0yZRKeHzkk7qwu
+bPwRSes5nYrfko3LFY28+JLdGXVbSVmo/5yHgJ8rjavraXq0+nY8pbwWw3xOS2cdWbz5vNG/7njaSTRg
+mtr3QzRQ3Oa2QMqa7GqgHZ4o4BLS+2FHm7nrwP4lMYfq56OPwJPQ0p6MInfwXWto54rsXZPOaNQUZRPe
+EB5NQHQaDWrVwLK3mpX4winK0YSo0ic7uugPo2B7ZP/dOmYELgujMKNU1Y8GVkM7oMVmyWY8W843jbaV
+a5HcXTUlsaxoxA1OGA2SZtBSOThLW4KfHwUmKFdHY7chXrXIcup4yz5bCfYrzu7iJJ2yfFU3mpplxZXm
+Rcqq35EF45t4ilvEtjnXQu+bmx7gQ6yhE2kNNJbAipfjkH3YKPYFEXNWjCH6/RcvrxpDAUX1GN5Aa/6N
+4XQguXLsr5gBtNQaQ6TnErXyOS1YRWJf78hJNTLem5XFAI4ekBuflgHlOpZqRVlpRQKciBWvGgneVDYU
+bWVqHF3gBiNH+2BybHWoZwBzWgvGN8eXjlVQ0OnUmGitpvDsRwCJm9aMaN9rq6594TDPNsAdexkK3jik
+V0Q/xP5fRIOWAX714ipqqGXxgWsstCg38sTFuCZsSWtlJEQXq9LgGF8OV5XcYBcgFojZtp6ynxh/keXz
+eOH2CqrFDhENIUt6GSXOW4AUG1QLBKeZihaFHVB3M75I5S/E0RgQYQu57aZVVnZrBkZkRlUvs6qZe3ZD
+SpD/DgsyzValkIwldwmLtM4ZJ9rHMOg0FkE06O2hYc+F/OOP0H62Jbf1W+HWkcAl9U2ZhwqivoXRLA50
+IeQrzkklrpQnwaZY4s6js2y6S6czK0eeLBw5YsQ7Trw1KI8aHMkFmLOqFrAoYAKLjN+ifUeFlqwlrW7p
+dDOWuyRFm0aCEG6//tOKCVKPIfrLf/zlv/7253//25//TRITh3oEMBrpjcWnbIEbh9/krAJOcsaLGu7m
+RMwJBzEnjYMM5lkNuQIGMeckK2rIqkK1ZWpk1QbYFCsugNawqjipWbkmRdr1A1o9xyc0Z9XAtNtIFnx7
+5KwVJNVQD0NtF1WV1vlyBmNpsLbuD+mn0vuxdkRW3ZotSCyQ6x6J1JQnAZFYz9ldZ9jB0XKyYGvT6TQb
+Crog9TCnPC8JTJspWD/R0dLM1ewfZZvhqSfoxGirS1eG21p3/NKXpSeQ8U3rndNa0RRo4711toWcai58
+cOKIr6qdljF12jdSgESmxQ56nR5SVJBxoltJ75uZhyily6R7Qq7IgfElOFM+xHn0IDPoh9yd6f2zZ0aH
+bOKHemoIp1lJvyPjjstJ7cVXYs5820k57lTRdeS7TNT7lNZx9EpslmQyp0VBquso8XYGGhAltY51pDMi
+4ki9jxL0/0fR7k2DqVhbFQdO00kqOF04ToOO4+KDeK0Odnq41q4z40OcFk4FPR5pFybn/1QOmqBU8OQe
+yth9sQWE6YssdAVn6/UIiUpXI2kZ2W4nH3nQradRg1qbTReyla8dJghrLlNq29LbVpl1tFhYa1nuTk/T
+vVX8ZcVLmByyj9BDqKO2rjFMJvDqOkighkHbV72hlpO2NauFxrRThZGyp1QNTqqCcI1nmECsgAamqQHk
+SXcz6o+pZ9mc5M060LJQWfG5fpaS8VnFqs2CreqoWw8pY21XWrOCyJIB5GnOiXQdt0Cyi4rcwfNMkLiF
+SAX7jOVZSV4KTqtZcJy2a3tRpAo7cS7fh+Dvh4rhWs3u7A58XTQawZcIT6uZNFVZVW7MEgc2hUyzA6hW
+awmkXqWO7CozuWvURYatjEmJnrmzViLdMCZu2H2as2pK+cLegsl2nkIkR0Ws7p5K68x6rft46uyvOKlX
+pXDn2AgFVdjd9gacL+a/Zov9/MVnL65edPZycr+Oq60J/SoEWEstT2nhVtt297u4cfdpY48d0ZIEyqA1
+wJclzUlsHmlVkPsvpvo5GcBZch6orReVllJxACboz7W7bontDKF9bYaS948i3zWAzpu9ex+/kS7Cpxkt
+4/h+zgdQi0ys6gEQziUBpCaM8anH/WvF+1zhDyd5E2fw5NmVHFmzQDoCzLw2gWz52CPEbHEsBatGuAli
+6pJWevkEMZ6aHCaXrZXQFGuTwhHEISnccaUpZa/2ft1x3YhqqAsjpwrq1S9VQdEJALUs6mj8zg603XNa
+ho9+p2PGLpwSMmxJKi1YvJFtbbVvhtfyZ9P2LukaECpGoARGo0UM+B7dA+RMi46jIM/3Cxh/HBN45L1y
+F1MYG2+xLt3xPWw9bl2WMzkgLr9hAGEjE0EsPR5KtwiitakS2AXnLhr95bVc1fM4t/nw4UurX8QooK6c
+abDQNbPaNw46+pDRm1HSbEwP3FoGUNcVf0ZhSayph5ZcptDBm5KlBrYBPpD7tsbYDPte+v0rR67b2Kre
+tIi4+N8vv/g8Riwe2UuvuzNsDWMsO/ffN0K6xdhB2OhkPRy0HKV55LoBkx4nje+5bZNeDl/D7o5Sq353
+Q6kTyELus71JZLv3RtMG0Azc2ZO6vlJqMUKgrW7y0tKx2C3QopzBpGP5vmlieXWdzchYPwJEFzeXlrE7
+ZWXJ7tBK13G9pxejm8uLG67/L1l+K93VlxE8bnkJh/fYanNkAzZOmpUQrKrHdmKQGt7YDSFgBKSxwR31
+JCMln2cLdIahni8wpMdtbTTwXSZ5VpY3WX47DtrsO+31HWo1ZKcfGlPD32vbVg+o0O46xv+KcpYuWIFO
+pjktvCyGJp4dMnDfR2bSO+rSflePSrz6h1yYTc6Y6TqYaxaMuxtWapvYYZs9kLfk73YABvVHu6w0Ofya
+3pS0mtUukl3nUjhPDms7prPVPTxqHt6jPEcT8dcqAP8PyTsHO89souqMg+i8E8/vxun3WAZ+FN5KdbDy
+GrzoOyeoGEicNIZDzMl6ADQJRoI5WXdbx+QJ1C1u87rGnGQF4f2VhgrAr6vr9Yb/0W0WSADo+NU4WXc9
+a0H/mg3Z9bCFQ+pK4VC4hNNOPtiOCeAUuhH/C6VQQcZojtXDsUHXjahAakiVSCB/39ewXJXlkNPZHFML
+dqQB2DOOvkSaiyhQrqLxDWlkOi7ihXrI6/FwyO3turF0FQYGkl62AaGNHATFH66/xMn6wOZ6Yv1d26ig
+WclmjnqXSR1jiLRYcdS7MZ1Uj3YJq17UeYYRujamr/5Dw6PgbOmX7EmtULQMpVcE3Ye4+I+C3sTO/r7N
+2zJEk4lbtqjs0jRpramAl2uXwbLXZIFQQn5vfnWo94d62LbvWQO9ZCuek6Ae0sqixe276YxQ3t4B2mPu
+8bKjJYKWwQdTFVIb/EtL7NcSDxWLmHGILpF3E5GHC0adOAQm5+0HEJTvYbWKjD8gnKxXr50p9F7WbVg4
+H7qacRIH2vPdsPD9EKtjSBj/evHge5l32RZH7xH1q+rHhnxv//4Dod/HsIN+nzbvjH6VnaggSQ0TPbqC
+Z1OBJkAlc7UjnY09aBb5cwRosrNVsQWfLZec6ZiIrvE5IUUNXlq3gbOqyq6tes9MU6rO1j2DnG9ylJU4
+/L8H65TEIM+0Z2f3qRJ7E6nPnhh8v1I/rmV2yYeRBDgAPx9fvR6r0WwPZ9UjS4W5iYGywSF2rX46TqCW
+gS24yslndxNCzbD7gEyGdYNGhLtW6dbvUSIpV8UvyxUtYpu1VIZH9BMrc8k6/my5dxqA4RQbsVN9cheB
+HUgLaZ1uvIw4VWMAp+FTKX09hfsw/e/s6cw6y9I9JI73CLRr0T0SjmWJd+dAHMjP2p2q+lbnv53DOQwu
+oaBru0H3+DZOZChPyfce336L89IPPej8drmq6shw3yHm5Md45te7e6Lv3K8kyO5DvwduE/cdof3nO++q
+SdB35jVAoZ3nXrc/6gzqvacKpaDSNXYdAdx3kC8UXnQF5AcSeyYc6Im9Dx4TNAGifwUF32dQ8AMEApEx
+3iYQCEM4OzQYqI3e1eL1lJaCqBjYY7yARtF0qF5DvVp6l2zYeLbrdxMPd7bmdD3spPxtP3zgMiuKdzKI
+KnJnju+g5qjI3ZCzux1Jzpa1oOr22AvvJPl1yy7/GJGvCu07UPaL2jD0vTLBVkuT0GbfZWB1Iquq80S7
+3ygzJta1TdHu3rXG2tt/Zd/L0/MmmwrCO90HjFCHdHszpXqER7M+owffsPOO54z0PHz/MBLkNS3aCT6U
+fq3Oc+0riVeYNB08Nb+cy5qiKGw60Wq5EjqvDBvqXlIkX3eNw7+HVRzAnNHv7sUaNoRKHLugxgc+zVov
+Eii30vHlxYg6vvBdPVVirvqJP0kO6SrjKLVkVBGsSzYe1mmZ7ZjdkRv49PpvzrdAc9BQ9Q22PRKqfK/d
+EVoPDKV/6hgsR8XkWL+TDojJsfSN6ZmFvfcfdGdj2jVXF9mYiS4yM7Ul4YsMDyAfw5yT6eS4kSEOOhp5
+4uK3A6fvVvLhji9/EoSsyZ9eV6uFD34xyiycddnC3cErubj7IiRHU2lvB27uaTVrVrm6ImoaviLKtKBE
+d3QhuMFgm3V5fHkhCshZiSfpJ8c/Q+KLAv/hDnPvsaweo2W106j6Z9mWyh2hdTCuEx/t8dh2la6ncsMX
+t+Cy0shmHC5B3LBiYx3wdyvvvOsttL99yA53zx63d5drnXht3dfNltS88Xemxv1P89uNiduZGMDpGF5d
+K7KctT+fmJ9bc0moqv2puoNBH9WzLeAqW9MZcnEsT98MQDDbFnaqv5Ig1y1CnKHpUpWfHaxnUygEABMQ
+7Ly5BlOBBA7qR0taVcT2+88RjapmfUdFPr9iV9mNmlLityjZHbGhvYxpnXNWlldsGZ+Goo6dBuFNf4tp
+la3hEkoaJSmR2VW0uB8AKR37/SQmZeJm26G5iPtioMW9PAZnD37bPwnzvMxm5EP3iTvuoc5tcZvSfTQt
+6udHj4IsohLHvas4WoQT8VJSmMcnOAeNbVL2GUoK2Pw0KQNuo/OsKkryAgVzYOPYMvz9nKecZMUGw1ZE
+3TybNJ4XtbuMPifijvFbJecjjScptDzAF0oRoO7CPak89LCqbit2V5nKPZcBPSdTWlF8rGP7ojAlE06Q
+2jCxaYNvUnY/ZbwYFlQ2lHFKmt2FzcYmGGFjGuvvzFNZlqsZreqR6mNXNkoby/rTinC8QIXcC30JQE9E
+q6sqW8GkL6VyLlDEybZGluscPiS05Ap/JLoJFE7gp6c/dWW+NRRX2MthqBS7z5mAKVtVRbrnvgS7UuQ0
+t5NFQ5okzDkvN/JYsmKbAZD7LD+Qe7I8K8iC5sNaNxHinbPdvKN6UOTsYQDLiFBjkzSUv6E5/bubB81I
+93Phg7nNuM/k5tJCu0ZdX95SKF1JV0FzpVsB3wbBp4yJUA/qvVtFD0exU3DYsve+paKooE1+8joraxa+
+QlD1rbn2JSGAoGNw+ddtx6SMrbvOTz1RojZY31xkar9Gi8nxyRvMPd0eX36jfYTrzozsARkTkJTeMYfu
+cnGkRmSZj012FmJqYJr+QcTJ2T+AOLFupXuIJlqsSkHRbA/JkCfvon/aln84FfTkx6OCnvzIeUZZhegc
+u2KsvGH3XjBt9/XyrCy+mE5rBaBKUyZfxC7US2mzfwUTuKNVwe60Ef9VAOhrH+jr1rBtN5aeQSvvf1Eu
+ZqHmYTBlJ10Ys7cLPAjuXqPESkAJ9KCvVDs9shwsNRFXdEHYqunOdXY7U7ticVMAFp4Gobdfw7BFeCrY
+Eh53kI6vm7qNg/Ssz3afEfGSlEQ+xKR0ZUUtJX7B8hW6UFIH1r6TB+G+/x7wb8oxFvgpW1VeJM+KoiKY
+YCYlVx+xHY1gLsSyHo9GN6vZd7Qss3TB1F/GZyNE/uub1SzNZ/QpLSa/+NnPf/HzZgiqZXlLsPQHlGnF
+CoIhSlyd0dWLr66effnimXWFMNYiZVqbCb0UGRdwAfa7F5WVbKwmg+VSwWlXOE6h08yg04pzK4LsaQKd
+ajYQqQoP5EVVtCfXkTr8D4wj0OhVNvzuv/9z+Nf/99f/P7we0ZUB0+g2Izav7+a0JBCrYVzCKSJMtpUK
+Uqsb+PHqVv5MaBiM9CWJGvdw6DWDA71Q0lpZIjuaIxWeY8a5PX7snX6XYC1Ka43GqrCu5//j8Pvhib6h
+37gmjrw2uhzOqpzE08pJOcvK0lx/oOu64TrJ1QrKFutTx3mkymEiQxFeekT4gx+/IyJzct/cLCl0Dg8X
+RGT9WVJf3HxLcoEXb9ax9CUjeJvuf+tfP7JWWQgK7tXt9flRx6f4jQrVHJ+8ud0eX3+jrgB4xnm2SWkt
+/8ZrlHPr9FtGqxh9uwmMYe15HXpS2U3eKU4d9W408HIg1FAGR7vy1cO56qFEBhnsQSvCZC98Kt9E9nka
+dhtOdJDfvNiV5rDkdJF5Z5TavIbQHRw9dw/tSlFAQkUDr8Ku40XGclIkrU3UME4ecDVRyXJpMKacoAkZ
+J+Frde7nvA3ZK8eL5bF2Lfr+ZJBtz+dwfsXkRet9q2PGsrJ/YeCKlWhEMMvV3bnq4jhboHI6NpddNJVS
+VZCc91ddVTRUEV87vlKtPTN5WY6inePY87liFz9gDxbFVWuDI5f2po/JRLMIPNXjt/gBxrCqCnRTkaYB
+iysO5IcHcMK21fx6rTbe8IOWqrtQe5bp/kXqL1GkS6zQlPisuZOZ9CR09AsmgcFZSVwe4cI5U+p4aEmm
+wgYNDlinOrVDtjluj/R9ntFy447ovcnfzqI+iY3FmCjPrJP+7tvXfckiBoYMwHyNYiDFRG8NrhPVdF5g
+L1xzPM8+z94Lrc7RRwN9a8Du5Ba3aeegYm9Fc53fwNyq0wPp3fUD8totGbXDqu7dxb2dqdM00pTrhVFH
+ZvDGoGonXHOuwDo10gtM7pdZJfM2/O3mHnpKxDZE3Znd1GSZa07ZCZwVhYHVSWVW+GSZ4SE7qZnSb1eL
+5VAwHa9p781mpaBLS4hLIxXxjwucCcEW1pIWnM5mBCMLegxNCWoxd101y/abC0lolZdkMgpO3mjtsD2+
+vJCpNvo4Olq/x1DT78jk+JNjUBoLBy1zJxDw8mKEDV5+05H+EjvIslV6U5u5deOuqIZRP9mZtTp3itNs
+WJA65/SGFDcb96p4OzHIzTXpXDlkO6VPlH/KcVZF3SDXklZtjMsmM/GiaTuC3n7s0r8Z3QQwD4y09V8d
+p2Ymw2quEy4QkUx1cC5RFy22aOqJxdqZTaofue+Dia7gx33Z0v1qhx6ViqBZus2Lbrn+XevbNm39M79+
+E+M4oPITv7Lj0fQa2BNt7dEvr7T/+npfbsH330P7MSf1lJVCftip84kNe5r2ClGJuIPAqlkwvpxHScAN
+6UqrQJQQsrSmC1pm/D1PwaZ0dxZ7h7pgnPymmjLou1EhtA4B0mWGvOymJ4ZC3x1RsS+oHxqmHJLt3w58
+9kIvucRpAO8OJnZOSiC7ZN+4oiTclEERkYmapBLP1UUi7RrdkcpSEgEoUAgmspBKfGUXbKyCr10BcWJM
+NSmMzEPiApk8DlJ/UeUEJsql4mXwqrkGcj6sV9OSZSpVzL9gVHVU8GwGExcduxCyEyWm0fsnMIF7GHZx
+YyA2CLGBYRdJEEYqhFEKHqbc0zQSPzZ754gSwVDPGuzNiIhPE+3LvWJLGOLgHkO0vI+STl21bQhX/oxM
+BQzhvqnduf1UzZ1UxXOFdY+ejdeXTaeaW7V5jVRKrNcyn1y30+6e2/pVsHrVX7td2u1G4r2cd0LI5vuB
+XsTUvPa/gHfQh9hO1DfKVIJS4Lt8zRbGv0oFQfwP6PSnorXa0shwnO6j2JfvGhdJ0lEKDWJ+8QmMPoZn
+pRi+hI9Hfspc/4Lbs+QcP78bW3BjR5afVcgYpa+LGve1FQj0q1pe/1ADO2SSXD8FrZdltoksh3Lb9uiV
+dqlfj2jryU5cTEE3gWP/JxOD2ULnHZBuHHfvNwtt+p4Z+v6f90tfuYY0Yq2r9zRiffQYSPc6uz4qVKzq
+nKyyomnmRIBqskvCAZydnu7H/1uzxXbfJxYDnwKHbNempJ8S5qCTJxL33Sq5I90ZR6cObw3Rl1M4Hx9y
+vzOGzix/qHujwc161jDyczZyyzcAFcRSlwjbxdqvOj2Wn7rpHPxTkI7eazZsZg7mmJpj7VmbTrsL9zQM
+W8ZRPif5bXueZJ9123S6INXqIBtQHkV4rfO16wGoL5nbLyTAIhP5nLTl+lnxjh6L5zogjroOW9XdI1N6
+w23Hq0+ktkMno8blLo4tiVAZGt4BW3WHmSxpF43xSi45WywxESkzB1xlP5DV42ggPRWB46D4+qDDoLu8
+56qj2rstqS+YYlJQKummxX8HJh1F/nGiGn23sO+MpTwojrINuBsat9hhpFK4hd7P+mF5l17mvLLtzlb9
+wTFiWLb6GKLjp9Ix9+7neQ8g4VN8f4L7xGwR2ySCbTI46Gjw34li02yoMj7wGwmCceLRzLoXxaGz9GbK
+aKBHYx1I7gJL/34XGONqDrDqES9sE4ts+Xb6CVtqG1At+soKuaDB7TyrrSRFk5ypMgpdoKaLuf2t+Dmd
+zUs88BZ5m0a1Hn5tikPfmZiXrgESaMvbbeDmprgpDVK8PrqbKX+rsrfuVn9tcpsg1kYjWNPFGEQ9eQK1
++vdu8gSIOPqfAQBKpHKw3oYAAA==
+`,
+	},
+
+	"/js/trash.js": {
+		local:   "js/trash.js",
+		size:    1151,
+		modtime: 1792271123,
+		compressed: `
+H4sIAAAAAAAC/7RSy27bMBC8+yu2gAGSqEMDOcqxe4lvRWug+QFKXMmsKTJYrhwHgf+9oPyU2ksOJSBC
+2pl9aHZk3YWKXQxSwccEQHQJITG5isViAjCVNlZdi4GVJjT2XUoFy1XPzajQZcccwwNh4kgolK68q3YS
+bywA1K+Eewz8jLXpPEu1OCMeGaZMsISpRM2GGuRcIiZMLAWTuFKn2vw2B3mpCdAib6MtQGx+/noRs2u8
+I1+AmDOZtJ0L+JobaGvYSFHGuHtwVqgL+6iuadrGgOffyxmEbdyjVHeM2jgv5WFLM0hsuEszQKI+w3gk
+lvnrPPBRLcYavXbU/E+FMpUde8xkJl27YKXQfUgozXgY1bW+gSWUMXIZD7qKoXbUDhROyTRYXAMA4qlc
+bZBaEzCwf4eTSsBbhDp6H99caHLF3benebl6Kql/8hb6MW5bOmmSCvi4q34eYRgE8KbEvNNNL+BsgFXe
+pPTDtFiAKDk8WBMapAHpOJv847Uy3pem2hVAmDrP9+vIx9Ugv5wgBYTcUVjcwX/ZcWDJ5/X39ct6NOpn
+jDky59igw7aQN6nbaI2XYuss3jxxOfeOHmLjLp8x+UDS48D5+T6q3Gw+h71rC+C0fIR0ut+Wj4A8+TMA
+btPVKX8EAAA=
+`,
+	},
+
+	"/js/typography.js": {
+		local:   "js/typography.js",
+		size:    601,
+		modtime: 1792273797,
+		compressed: `
+H4sIAAAAAAAC/3SQT2vdMBDE7+9TDPSBJOoqkKMf7qn3FpovsLbWsYssGWmd+hHy3Yv/pHYK9UGGnZ9m
+R6PbKTTSx6ANXi+AmjIjS+obUbcLcNUuNtPAQYxNTO6utUH1dWUBz4JrG9OACletPsl9jM+Jxu7+ZZkq
+czuwPNVDLwu4SLbtg9Oq3Kbv4CbFoNU+L8DHNoDtmPiFg3zjliYver+Hd3tLIkkr12eqPTtVQNLEB2Xp
+F836FQNLF10J9eP7zydVYEq+3LdvDrSWogzezH4XsC4G1o6EzpmWr45R6jhb8pxkJWzTUXhmh89QkEQh
+e1ocM3bBqgJrlScbwMdmxWxiH8lpc1L/vgIfQrXUez136d9M/22kJZ/5ZAZssecu2cR5jCHzE8/yYd/t
+cvyX880s3T884KUfSkiuHpG383f1CJbLnwEAnxBYxFkCAAA=
 `,
 	},
 
@@ -13532,104 +13656,180 @@ FAAA
 
 	"/template/book.html": {
 		local:   "template/book.html",
-		size:    14681,
-		modtime: 1535941804,
+		size:    26706,
+		modtime: 1792278449,
 		compressed: `
-H4sIAAAAAAAC/9QbXZPbtvH9fgWCJE08LY+Np53p2KQ8qeNk0kls13dJp08eiFyJ8IEADSwlXT333zsA
-P8QPUKJOd03qB4sEsbvA7mK/sBd99t2bl9f/fvuKZJiLxUVU/RASZcBS+0BIlAMyIlkOMd1w2BZKIyWJ
-kggSY7rlKWZxChueQOBe/kS45MiZCEzCBMTf0C6iJGPaAMa0xFXwt+aT4PKG4G0BMUXYYZgYQ4kGEVOD
-twJMBoCUZBpWMbUfw6VSaFCz4jLn8tJOvy+m/PYs8JWSGLAtGJXDcC0m0bxAYnQS0/CDCQVfhh8+lqBv
-3cwPhi6isJp0HGKldH4iCCtRrbXa2p0wDexEcJNoJcS1mg1mLhOlbjjMBegL8QSgpdqdApIzfZOqrQw4
-zgVDzaQRDA/thacxbecFVj4B5oWgXf1ByAv7uVYJQiI7jySCGRNTSDlyuaYkB8xUGtO3b66u26mERFwW
-Jdb4Mp6mIGlzFEEbruR7nlKyYaKEmNp1OoAOgpRvGmJ2PR3chESNXtQo3QQimFzHVJcWWzOhgzBM+caP
-nwnQGFjDwLgEbeEn5y5RBmutyoK0T8HOkGWJqKTpr7IarJlgymXOrf1p8TgMheY507eWZjV9GkP1MsKQ
-woqVAknCZAKCLl66Xy86UzDZMlW/T1Qpsb9mQt5ovuaSiVaRuJLPSLRs4BKJgaKLF1G4XIT9YayHuyRD
-S3NCClFoVapWzUlFrfVljoZaOXVArIp1SBd9fYrCYkLdlBJLtevLkjdfV4ysWFCATLgIzMeSaQgU2QX2
-RDg9PgSGPAdDdoGGXG1gMH3Am/ZlmjWWZqA0Xz/OGb7vEQT5/3kEr9gGHv0Y3uMEOAnPU/9HUveEacBA
-wAqtpu8KJtOjuu4/Ik5dj8OK0h4Tlqbz5vfPlQ/m9MNVqeRMq9NVY1JBpkyuQTcv3OTcGL4U0D1hhxQr
-EcoAJSlD1oDXBHqi+oPb+/PORvsaN2uvztYXQco3czfsgsqYFsrwykOwpVGiRHhOrJo8I39+TjRfZ9UT
-qsL9LhWiyu1j74RPLyxReQ4Smb69l4kbgM83dQQ52v19p8qlAJIIntwQVKSx8o9pyIZ7Pt+w9Ry8tXI9
-D32mwbPPFVt6ZF6lHB+aTHUk+nRe2rGDhO5hcSVsA622c9QN9dCfdihjutdEEay4NnjQzjJtqZYF2VVu
-vSyOmr4KxCYJDZB9Hlk/TL3LUv3lnBIeHAnz2QpB03Aw/1BIcd+wwhNanHoqzwgxTj+N36ap7zQ8QsDv
-4UtX/w9qhlXYnKepcGHqxCQ88M0iEMwMpkQh6gOnr5kWhsSg5nJtrNXN2Q0QwSWCNiRjRXFbT0uUNEiW
-St285ymJyR+/+vSJXP74Hbm7+6pxiRumyUqztbWp5j0qZKKd+X0zfu2Gp6GaZDn1gO6/tfDdvUVhU5KK
-liq9rbfbUTaDPLm5pY1LTbkpBLt9RqSS8HxvaIYhT1bmywBZckMqBEHBZe0reqffbzASrhMBVUhXwy9Z
-cuNFMFprUBfRuqauFM0cyTZEsk1QcCGMe9oNnJfgi4gt3uxWSqdRyBZRKLhnwrcJSyHnyYEpP5cCuZXO
-eE4UlqLvd3vw400VbA1EuUUFKU9sWMM0B+OxFlPQrF5xYG6lkrf5KbB5s5VxzDAZu7rHi17uuzdy+4NY
-j3z6RPiKXH4vSm51NVjZh0+fCEj7ute07JuF1fBrGwKRu7sozL6pqRASSbY5KHVky7G0+zaJNWVIuvhR
-prCzsuuZqJGoWzueIN8MI4A9PmsIwtYC0MEujhGZh9WebIZJVrD0RalFbD/88u4nR++q/XSQWlczo9Ax
-tHlb6v2zc8YuOlfFIHz94dU1JcypqGfbk8GmS/WYplPqOOH2Vlwg6CDVqqhii4uTfZZ1nh3X1aAKUK3X
-AmrHGlSE6pyn+hTTCbKEfO9mDwYrHWcyJV9f/tMVg38AJHRFn5C7u8Fc63XKwmnJW7bm0tW6Lp0r+BEh
-N05r7IwxjerIDL13t77mUmZXgO0VwPoh6sV46f1Ve4gwD295ztYQuCuARADTNSfdCJ06HWNu+LLqUQBa
-7WAQf+1Z0t/p3j60Is9BlnsFqEU+IiC4Z32CLUGMx9s4tIoZV03ErlnKVVtkLqkHsOE6fBzrCy0t+5MM
-khvYm8lxqdpjM16s4pIufpH7uGHEsdos+HY0tE2PxJDkZIYk5zAkoYuXVWb7u+SGOZkb5hxuGLq4Qqb1
-75IXT0/mxdNzePGULv7FMSO4VURpkisNpC7hm9+GO03V9X0dOxl6mGHqZIapkxnWlRuqblWCkkKwBDIl
-UtAxvc6AqPoWh7Qb8C6wXr51Cp0FolscnV7N/4T/znbOFQCeLAA8TwB4RACd27P7yQB/axkc5Lc4md/i
-ZH4bEJA060hUXtCFl2ikCsfmZmlgzMRMQn4CY/xIwgrLLBLWQtGJzbqFPiH1nLu7ahfdPU8s7Wel4eSl
-RWGF3/cNMyaPiVf21djw/0BM/0InlFIe0cmh0ErJcZ7QkoxpliDoadHtp5wvwK3SqZmSoFv1E9JMOkGE
-DuIBZXj2GZ+d201VNrsJ3qzbhfaWIVNb7179hU9/ZlOni/MzGPvvHRhAL2lvJDGqA/v4268l9Ssvc1k9
-Kp13d1slXFsuU7UNNBi01mNU+n8Aqh2AQqu1BmNI8xAY1LyAdJyNeaCCJdOk+xKYMkms7W0Kma6n7pk9
-ZEWC/oLpuAD7pbc2X+fWB1NWXdVD2qu76SJt6C39+nW/xV+ATlzJ87T9WBF+6VE7z2kNLiaD5GENSoOn
-+vQOmC+Z8FcgRtcC56vWw9WBYFf1as6rA71yswkzD1uOOV67qJc5zxRPibJC8mIVF4JxWV2KvbWPxD57
-5TnX+s8nWXdM7OmSr5sM4snjLiExG7p4efXr41L5YJQUdPGPqzevyU9cgnl8cjW1r5kQAZeBkjCPk8dd
-zaBe+Q5kCrpbKPReeB/pVtkyLblcu8QxgLzA26DqNTlyLUWIy3asyqgVwW7qyQ1xPS9yfXkx4t3n7h6U
-sPaS7bLDnf6eI2RVD0Z15ehemvRK6aZsagOON5qvr6t2Jhu2ZWpb3YjXLU7jqw6LfN8w3t7oDySC2SIK
-MRuPNr2S/q/+0et9YjgPbH9jWr/1Fxzh/lax1Q/N5Bo63mhYRo5Quxx7NR1MHe1d6BWt61qWr8Y+risb
-ZJrsglLaB299uSooCwPzEboOsyMIvUX73v31kQYJ/13iVMvdRIoctDcUNvP4wlMaejIYRjsUeFnRCNwZ
-gUyQy2tnvcfgE9B2OY7RM9DXyA9hkukEol774URDiKcR8byWxIdpThwqu1ToEdsk+07tbTyusvftgTyS
-9kwPs04QnDPvpdIXtQ88nJ19bj9fwcfXZe69jvM5yIPHs9ulcvQ+q+p8cF2JZBe0dzMTN1wH6U6Ywtru
-/loXryesV9umfiyVnWVQ5pgUHJsUPGhSZhgVPKT0M8zKbMNyzLRMGpdTzMu9W/znH8lD5imccin+D/d0
-ZvuWrCk/Xt/QzXO7daMq2TVPdcZWv7kUvBHwHvFDOPyagpNKQ/tct9+Pswa9nfueXH+gpITNK2P612FD
-nA/zeE1R2IviotBFuW0E7I3432rYvIYd/r0q3TXoWo2JwgplFNZ/IvlZEJANz58RA0jMNn5K0MRPiXH/
-Az4jQbC4+G8AAAD//8usSyNZOQAA
+H4sIAAAAAAAC/8w9aXPcuLHf9Ss6XGdjV8zhs5NXlZI543K85ytfa8n7Kp9cGBIzgxUIUAComcmW/nsK
+AG+Cl0bKaj+sOCTQDTS6G30BDv/03ce3l//69D3sVEJXZ6H9AxDuMIr1A0CYYIWAoQQvvRuC9ykXyoOI
+M4WZWnp7EqvdMsY3JMK++fEcCCOKIOrLCFG8fOHVAUU7JCRWSy9TG/8fxSdK2BWoY4qXnsIHFURSeiAw
+XXpSHSmWO4yVBzuBN0tPfwzWnCupBEoXCWEL3fyukJLjSd03nCkf7bHkCW6PRUaCpAqkiJZe8JsMKFkH
+v11nWBxNy9+ktwoD22i8x4aLZGYXlCm+FXyvZ4IERjO7y0hwSi/55G5yEXF+RfDUDs1FnNFpzQ9zuiRI
+XMV8z3yipnZTAjFJkRqaC4mXXtnO1+vjqySlXp1/FE5S/TlnCYBQt4OIIimXHo6JImzrQYLVjsdL79PH
+i8uyKUBIWJqpHN6OxDFmXiGKWEjC2VcSe3CDaIaXnh6n6TAJgDqmfCtQujuWAFCa0qMLSkxuiiHrWdVG
+CBAW3FXA1Q2AIrZdeiLT0IoGNYBBTG7c8BHFQvlavSDCsPBWA22rKUztkExsuFbM3wqepVA++QcJ60wp
+zmRz/vZlTmOZrROivBocAyEVJEHC0NY274dgf3QgxHiDMqogQizC1Fu9NX9PBlfnAkUUxUvvk8Baz4Pa
+4dpn2JADlt7qsnzjxC1TxEpqi68Rz5hq0gvgoyBbwhAthYxwdg7huugXMeVzb/U6DNaroPla5a/rKAON
+s4e3wkCLm/3VL8S5LE2RXs0jJG6IXw112pSSMEh7+JBzuuaHJh+R4usGwQb5KWYRob68zpDAPoeDr7WF
+kc6hbjsiFRdHOBRPoz2iHY6u4OCnAm+wKNngi8SgdkRCPtVROIokWMLBFzjhN7jVvLUq5Y/+RdGz9bkg
+24fRrHdVaZg9qEp7MMVzgW7wgyufO8ieWeFpgvdAghYhgZVP8UZpGTukiMWjvO4WTsOuMyTUzH2qmKY0
+09KF4ngamqY4uvrMl0nLydNWq8H9YHvGiG2xKH4QmRApyZriumAO8WNEucQexEihonuOoLHC35q5v6pN
+tMmok+ZqNqfUj8nN1AkbD2HppVwSu6WhteQ0U/gVaO46h/95BYJsd/ZJ8dT8XXOleKIfvdWkgUU8STBT
+SBxnrkS9405gFMs+hVh8bSuquoatQdOvezVtnzqVKabU7D2aY6le15SiCO84jbFYehcKab4BZkwRPSC3
+4p2na3spYjYZl5otjWg7eJSpHRd1orfG/S+eCdPWYUhP0/Yt9HM1faszwFs7yzZQx2Ywe0PQz1YoHWj1
++0lIu2vUfFHfSaZKr+GYmQJSsNnZAK+MSIXAKX0ggfiMc+/MKQP9PJq7fS5uvLvkTOLcSXybs1KrK4CZ
+7tkE5pnLrwJLTm+wA6F5P4rydObMWelu6nuYPX0duMOi1ghqnlXOGKu2C6X3S81Y+s90k6IYUOExfB41
+/F1293wrZM35la+DiXfyDEyfHRfk35rVKTSh9VDXdDJc3qQsRWtMqyWgvkz8v5ngqODUN1+91TvEthna
+YhkG5k3/bmT6/31o+5E8ExH+qn2R5h5UH2iOv72hmq7PAS+2C8CsgWVklxwfl0Jii9WdxnVpuubjElkD
+S0fWepnppBV6Y+Ri2vL8Y6514KLAHzDHIupipfUe5spzgF8NwEc5Z8vy8OXzu3uYby56mSi1jXkcZ/Cd
+Uqk8D4I/ggIfuMLyjpNvWSlMg+qZr+B7ufT+1pr497FxhJ5Dmq0pkTssngNW0aLXiPnv0OQSbeU98INC
+WzlX12lTHIHEKRJI4XgyR0yLpWw5onffEwmjhGEogXTzELmOS3RQt4DOsmSNhQcJYUvvxRQavLH9a9Al
+pjgqwGeMTFEgPNWsVWQu9lxof9X8Ab4xsetCP4WBbTvQfSPQNjfuy8dutzCwA63eIIjRcdYSMbz3Bd9P
+WSAl2sHM2lBUXGfVDRFSDQa5kNBYs7SIOmVpy07r66LzZkUn/dwx71TsHBZvDmdObHYkcYU2Cgsv6Peq
+O/Hcu8Z0nZbRXK/pzvHd+Z7/mzi+F69+PMfk9NnrftAAZ2iGTUgcU+Mq9DRS3moQAEWy1SQMlBiQvqJZ
+EIBUgrCtBMUhQVcYKGEKCwk7lKaFNEecSWV8g68khiX89S+//w6Ln7+D29u/FIHFGySgVBdfFVeIli1/
+KN5fmtf9vYr8cezoWn2r9a+NTHstsATd6Z+cX73XP29vHQ21TrcN90TtYPEjR/ST4FuBpYTbWw3gO0To
+Ub+3vzGVGG5vWUap/sXiEnCdumFQ1ImEax4fV2dtdpeKRFdHrwiNxkSmFB3PgXGGX1Wqru1n7rJk7SsU
+XYEF4KeE5cG5hv5xq6yIiIhiG9HP+69RdOUE0Bmrn1e21JVtVpoUDN0AQzd+SiiV5qkV9AgpWYVo9fGw
+4SIOA7QKA0ocDd5EKMYJiQaavM+oIpo/um3CIKNNw6jPkCnoh7YYuBmUH5NI72tIECxHojz13igfsS+P
+jLNjMqdvUkyl06ff+zePFUPZuEmhZitVkL/5/XcgG1j8QDOiWdXf6IeScytO271YaW6/1C4L3N6Gwe5F
+jqWeVSpjAqA3BT/JmrZaKUelZ69B1VPfBoDeXKRn8BlUusGqHFMXmvVCnaCKwM1kWIW3V060A7Iwj3zr
+vq1CUkEnq3EM1rf68vldAX0VorwwKoeTF04xbhLLQu+O1oeyDN2PQOg0ESy0nV7/AhCiYgrW0Df/LyOI
+OfbgtULbZTGGalKosYAtrO4EX5HX06xQhrm0V2MMTP1Wp6LaJlEL1DqjVOIjLoBpZVwCu8DKWJGEHo3l
+3dROOq151qJ7S3c73SMDzyLqE8+qiS+zRNsPuQi9xwpub/XkuuLTBZPmI+laLo5G/hoJqP/wZRZFunex
+QZgCwnOzo33CIsJMj+XPbquq++qSx+hoe5tHPY/A/LQ+h/6tf31hRDUZC+BbbZJw9aqJo7WUGyJwx24G
+uFACoyuL2D5rTDHKScpw+foF3N7KLvf1D6AQqW9KlXfwFd9uKdaRXpWgVJtpFLMYiQaLj8T0is4jO3Nb
+JH+y3dpjd8L29xhfed28UAWtC6YPlF5Mim8w9TWN3+kna6cYpZ+pTGglBxvzVPGtA7rG75tV4areNZdH
+awop/eq8yzlPGtJX8JFXInTxqVvf9PKwq+nQnlm1z1+EDN0M2i4Krbs2i5vrAm/1M4vxocNZHYOlQIIi
+RTq5lQqe3nmC0pL2WnvxGJJpULV9ilS0S1H8OhPU7AR2m/JWF+Wne8O2pVxKJI4dXD/mHwYx1S25MDBL
+V/xai+rZuM8mr8nTVnbxx+8vPUDGpHMQuLfIyFTGIOHNS/DBhlCFhR8LntpowPxc3EE2nM0CVK7WclfY
+t4jyWg/7aen1oAX4wbQ+60gd2QBiMTxd/GIqmn/ECryN98yleEKZpYYfPyFtF2l6Lozz9rPCiay0Te3z
+e250x19LGQwDDeRsovDXTTJThOSt2sWM0MhBnnVn15yYAwlykJ8kaIt9U+oeUYxETmzzxusT1S7BXNlB
+x+4I0JIAh9LqKKuSKxLMsopHcq7oIKDEMb5OhNcZztwUYTiTetfFYkVUMOvbP2qE/wlJQ3gv08Q3IHBc
+3w8cFSAthfJ6s8y81RdWhQI69Mr1hms+bcX1cOSI5pAjOoUckbfKa1YeKy0yMYs3xEnMIbzV/2vTP2N5
+6UIMRTHK46SOnEMceQptpLfSdWLisfLJyzmUeHkKJV7mTKL2HLiAhAtcVG4/Ui5h6RzisPQU6rA0J4/E
+N1ggWpLmuXF4INpxidkjlSaFKJ4lUabDKVJlAFQVA9FO+2sxSMIiDI99n5JqHrHmUupCIZVJ1/gaeUyp
+vFXfOHL/10LC0u0HQzdPqc2xDyjBJrhm54Ov4emTuhUolfesbGVHVJ9YDyKAuivWNxpHJnXczgVn6vTR
+sMs1msMu1+gUwbpG3uqXN0CkzPAsrdywjSmRys+YidzEcJ2f4JFOdquY7Zc3b02zvuV1UneMxg5KX6Ne
+UtfZtxdYSfmLnHdn0nsGNw9My8V/w0zezMncNxcXR2W+5hkP6Y0yNp/D13w2W9fXXPGBgvjLWi0GlMN3
+jq3GJzWNpszgvP7RPLgOKc+DzCD/rF1InUZ+NUL+2kHLu62AegwrYPg/0fE7PIH+Yhb/i9MEQEwWgHz8
+gEDgLT6kc5ZBPCJBmL4OswRBnCYJYo4knLQUf7hEjNE9nkP2eC7Vv6Sx9gBgI3gyNsa4HKTu4/VQNN6M
+UBRA8VFUahKqR794dM7i0dki03BUIp6kPa5Ky/2grkRv8d87LGWPKTXkNbRQ6LhF3bWpL5sZ6DPI28xw
+bnScfvbQ+l0WALVDbGxxWVMTSfJvvPT+3seSbJT5HVWykyiqL9xBkcKif+mqJqcvoK3E7VlBM+pnUDSa
+sYSmxz2u4ckSPjlV1lfaCbV82eSjnPq/ix3fO+fqrvwEZxaoXTMzmu0Be0IOKydqtDob0Va9GaomJSbV
+px58iW5wM0VoriyyYmWTgQZt/1wA9I0IgORisejhq356DnmBjgKALle1ncV6uVuDeHqQsZ1Qx3OfwIOa
+TnFOqC4L1iJRA2gmMVA98V1z890xDtS/sPnRwsbSWo3XiH01ThyC2mHoyQzCrESlk5Xdi91ZaGdOc/6R
+WVJrDna8e8JivtfnVhV31B91eWc+VkexVlWoJZUgaeuwCtxDiVcaKXe1c7d62l0ClufQB1PTwpZGePUC
+H3eFdeCs2x7hqNRWqXmrefPRS/hnB/M5ONJ3yY+7FEWfXu+UoXzGyBWod1cauM/h398Z8JNKQvDB3j04
+rSTke9MakLzfsovxGoV8mNPMiL6ltEBeb5YpRYTZEy2f9KOpSHauJyX3jTK/NKbCC0+LOMazhx1CJG+8
+1duLXx8Wy2+SM+qt/u/i4wd4RxiWD48ux/YUUeoT5nOGp1Fy3GD4IyVTcU7lVMG81I3/20JpR3jaQgps
+wkkdDfsDYbEpM8sbPAgXKSwSwjjl226l4WX1zZz6IVJhFh0fZhzl/X/dYRyrqwEfALNUSMluRadCikhF
+oocRXoGN8Lax/spplmCwXx9Wa1wjZw6vmyT79hota0ZyaY+WD7XcIzx9e/HrfWmeVtHkZ8xiLBpHE1xH
+mUeuCtsjwQjbmsC5j5NUHX3zYbxi3YR59WbVOgcMRIK5cIxtF2cd+n9jjk8CKs/mLWrUac45VGhNcTFo
++6OIK3NRem47vtcVFJf2Cjod7NjxvT1Im19L5zpgEarq6uXijWitiNrpk4+77tuP5ZFn11f328sqIj6t
+W3XQMv/VHHCoqqOAbTeztIM73pQSJrew6Q9BjB55bvrNtiqspxqh5RBKhQQcTEodiR7HsDoTORGguRVw
+BKD7TICKB+bOPXcwyummpINHohvHIUyZ9JNuWvhZq7xE6Vd+b1Jdr7WR/x2FxaUxGbvdb297R2JoPAG8
+3vJkjmFhfwxBZXEP0MbNkW5jynWHZP/ST7lNEmD42Nm0eyX7oUy8YbItNIy3V2vTv1jzL6gcZ/2BcM3g
+RZadzWnqa1Rz4xPkLH9/ku/Hw7HRb/TnC3z9IUvcUUG0mifm9UPyo5X39tizuVoSDn5Zn9dTiz+It0el
+PtnA+dJ9YKvS7r/mNZUDx7rM1ckVKYcUklbhpnynOlCMKC7zChrCk83iU362NM6R6/Zp8a7aX4voTyMV
+v0eyLG00l0o07js2nymSCjKb6/SGldbosMpIWf7JG0l6TNLeI/pbdfW3GtTfEzS4GlIKE3R4DUWOYBha
+r+7u1d7Vadknm4Irf7Z2r2W8Po1WuZHXyLeWsjdYYzZ4kLFu+Guef4+lRFsbFqdkEO6AenRXmk3pOX1T
+u/Nt5NN2pfEN6f5uKZ++swx07dk/ej/c0barLjbpM2sv7c2806zQ/JAIHIqnHMhPSH4pj5JUEGvnS2qK
+08R0ouI0jr7i6R7s4xye4aP8eaaV3IJpLG2VSbB/fHsSWz+WKSMzEft56Tm+1/ID9ktRPjpxG226RdC8
+wam6aNjt13CqY11L73/b1964IHdpEgYNpysMjFNavzug66Drf+bhAz6of9r8dAGu5OgwsCDDIP+3gf7k
++3BDknOQWIHcL1+CksuXIM3/sToH31+d/WcAUVFR4lJoAAA=
+`,
+	},
+
+	"/template/glossary.html": {
+		local:   "template/glossary.html",
+		size:    3115,
+		modtime: 1792273293,
+		compressed: `
+H4sIAAAAAAAC/6RXQW/rtg+/91Pwr/PfEXobNtnD1jcMDxjWYs0OO8oWXauVJVei0wRBvvsg2Y6Tl7RN
+3npoRYs/8keKpFTxvy/3d8t/Hn6DhlpT3IjhD4BoUKq4ABAtkgQrW8zZSuNb5zwxqJwltJSzN62oyRWu
+dIVZEv4P2mrS0mShkgbzW3ZoqGqkD0g566nOfpi2jLYvQJsOc0a4Jl6FwMCjyVmgjcHQIBKDxmOds7jJ
+S+cokJfdotV2EdW/11LtLGXyDYNr8T8bazeH8FB53REEX+WMPwdudMmfX3v0m+ToObBC8EHpA8RxqFeA
+Sre+FPJkXAjSb050BZ8qQZRObUa40iuojAwhZ7EQpLbox6ABRHNbbLew+NW5l8VSk0HY7SCD30cfgje3
+xc2kbOVqAgKI3kx2rVyBlauMZBnYrJEO5VAEEHI6AVZ8tQrXgssjADf6MgPbLegaFn//9QfsdtvtwQpN
+iFHEtL7wfXBfv4y7VsFux06j/m4m5xzFU5FUNZ1UP/fe5DNBVjzutz53OWVYVqRXyK5hMJXJif/5bD/w
+LnhvJknwdPCTVPp5XTvfglax5XybRYlNlKOQaWu0RQYtUuNUzh7uH5cMYjTOfsKbgZIks8t0D4hr2/V0
+MAjYOA+D632Fx/RiQ3hnIGGy0DLojKywcUahz9kSYzweX3vtUYHsydWu6sMF3kj6J6TrvHlpg5Ex3Evi
+6Vy4yvyD9ASuhtAhVs0lHqwjvM7HnwlxYLrsiZwdbYe+bPWck5IslGSzzutW+k1ah5YVvygl+AB8z9Ig
+nFhSWMve0GgJKmkrNAzS/M+Z0qEzcvMjWGfxJ1bcpe0zrj5sq1jo2jrjnk466xM6XW9M5vVTQ6y4a7B6
+iRdz0IHQVofNKHhM9dl+G4derMsAu91MmWRpcPI/COl3PDCFNqAa5UBed3upcSv047p0XqE3GAKcaavo
+Y35nzN/88YekVkR+glNzdm+u8vdUjiv1Pa1Ua+9tnn4X/Jir4CfxCJqvzelnuwUv7ROeSfo+BcOgShMw
+jsJYEalW2LfMoraaTmmcR+kiekzrdAmR+hA1zpWEWqb1Jag4KxIkpva+fkyJvQQ4jIAI9WgVeliktF/E
+FNc0lDukpXVvXnZncgIg9vdcLaGWWYe20iYLr730mDlYZ6g0pQyPt0LiNaR6IhTfQvoS66RbDLDOPLZu
+hYPV89hzMX5bRwD7R8VxdR3XkuCpy4q5kcdnyqzRFcsG970HOgC2HW0WgnfFzTlXgiu9Gh5+gzPBh/8J
+/h0ABfh+KisMAAA=
 `,
 	},
 
 	"/template/index.html": {
 		local:   "template/index.html",
-		size:    4053,
-		modtime: 1535679937,
+		size:    10903,
+		modtime: 1792275742,
 		compressed: `
-H4sIAAAAAAAC/6xXbW/bNhD+3l9xJTagBSarWYFh6yQXXbsBBQas2IIN2zdaPFtMKJIlz04MQ/99oN4l
-S5nTLF8skvfy3N1zRyZ5/uG399d/f/oZcirU+llS/wAkOXIRPgCSAomD5gWm7CDxzhpHDDKjCTWl7E4K
-ylOBB5lhVC2+AaklSa4in3GF6RUbGspy7jxSyva0jb5vj5TUt0BHiykjvKc4856BQ5UyT0eFPkckBrnD
-bcrCYbwxhjw5bleF1Ksg/qWWtkZTxO/QmwKfbKw4DtV95qQl8C5LWXzjYyU38c3nPbpj5ejGs3US10IP
-aIxDvUBJaoH3E8Fa8nkUAQpJfKMQSJJCD1E0NiNFylqZaLMnMtpHVFjFhpkgLKzihE2oAEkt2sj4/aaQ
-gSaKe5+yDWnYkI6skwV3x+rbFx2UqBFvbQEkslXdctjyKMsxuw3xyM5fXDuc918vzvwL3PK9ojP/GdcZ
-qgf8kyzQP+B/UpEvp3PUYvovHs1oXEiPLuqKAHMagSbxAlGSuB0NycaIY6Mh5KFNWJgMXGp0PTXyq/W1
-49orTtJon8T5VcNIgETzwyDve9Xa0fwAmh8i4hs/qEyV3VaGZyQPODoFSHibWbb+GFohiflIP1Zy4DHe
-q76mFZiOUa7/HgQYyLNzZm+HfOl8ciHYRSRk63dCjKCdTiC3sIKyfDYXjcPCHJBdyPDqOGp01r9Xv1Nv
-qEXvLImFPFwe8GPaTThjhbnTEZndTmELjoxRnoHgxJuTlLWio5peB8FhTrzluuMbd0gVhS3Xw7q2/TlH
-rg5RgXo/xBcQTci2xC6u5K6i+bv64xE0G2Z6puoJX8qk3SsVObnL+/Gx4dltKM1P1e8ARF/gBzzVHd54
-a9q9HolGC9QeRbP25KTtVrk5oGu+N8YJdAr9JHPUvyH6PTfeqMTWSUz53P5gaCyJvDeFVUi4dP4r9wTV
-mJB0PBdK4jGiIDFBnVA/5wa947je4bRZF2IMm6KfjzXiKDOKnUu2ZeJawAv8DKtfHN8VqMm32UAx3DTE
-1Ut4saN5yVcvzxFOr7gWESzdtRN0o7Exyp2YjXw5yIA6VOhP6SWh+MR3CFeLgLuuqC6kvgGMuY1PJ1h9
-/ABl+dbyHaZhOTVclmwd9q+DNpTlpF+HASqPT0Nxsaf5VA6rE67h+qpu6mNRZ1ItFOhRRajnaGU7ZacT
-2Iy+u4hwUJZfz3K3CspmdLGVOVSTSd7/vQhJnTNclhDD+LBx8PL/oKk2DU/fNYNk9dH/g84sceR0gq/C
-VULSwps0XHAYHpB/kBubeVAf7ykoW4dEx8v0kuClc1eV1G2z169f/3Cmz2YNBNdRFTH2EdRQynJAlO6s
-LFnH4vWiyc7EPOo4oH36rJnO8nn9JJ7M8ySurrHhrTlp/8RC9WxPWcHdToZngn0D376y9z+Onyk5OgTu
-ELQBGjx44Yi0mn3Thdfi+i+uCcgAFwKMxrejcZHE9vxCH7wiqpdOFVAS1/+9/xsAAP//ou1ZwNUPAAA=
+H4sIAAAAAAAC/8RabW/ktvF/f59ior//jQ1Uq1xTFG2i3cC5S4IDklxwdlO077jS7IpniVTI2bUXxn73
+gtQTJVG7sn3F+YVXD5wH/ubHIYdU/MXb929u//3bD5BRka9exdUPQJwhS80FQFwgMRCswGWw53hfSkUB
+JFIQCloG9zylbJninicY2ps/AxecOMtDnbAcl68DV1GSMaWRlsGONuHfm1c5F3dAhxKXAeEDRYnWASjM
+l4GmQ446Q6QAMoWbZWBeRmspSZNi5aLgYmGaP1fTRgoK2T1qWeCLlRUHV1wnipcEWiXLIPqoo5yvo49/
+7FAdrKGPOljFUdXohES/q08QWsuHp4h81ItEyjuOcwS4SPFh0LBq+UUYAqac2DpHIE45agjDvhqeLoOm
+TbjeEUmhQyrKPHDRJizKnBHWcALEVdO6jd6tC26omDOtl8GaBKxJhKXiBVMHe62L1pWwbt7oAoh5I7ph
+sGFhkmFyZ/rDW3tRZdBvv7oZ2U9xw3Y5jewnTCSYn7BPvEB9wv4gIs8fMmHj0zmueiRm8qnttSWAT8LQ
+JJogShw16Sdey/RQS6R83wBmsg/jAlVHjez16lYxoXNGXAodR9nrmpEAsWB7B/dd3ugRbA+C7UNia+1E
+xqLbtGEJ8T323gLErEE2WL0zQyGOWE8+yvlA35QCjUwlWbC6sb8n9cTRLu+4YTvVMlN11w5QhoRbJXel
+y7vWNEvTYBaZg9V1mvZce3wEvoHF91LeaTgeX/l6prCQewxmDhf7OqxlVh/s79AkitQ11pkixXQ2ty+3
+prGjOo5Svp8P31OSQKpkmcp7EZLcbnNseklS5jqAlBGr3yyDpmmPabemoQuuLploRwFTSHZglUy4LGmy
+ho/yrUcFip3rn/FoJmVZzrd28F1XFy8gPzHSweqGGHFNPNHPV6XQrkxWv8t8VyBUt08YTi4Hpth9jhsv
+Y8ccQgDcSEWwPvRRmEELLzHmUENbWHv2Hh9BMbFFWBhv3qsU1SAL1MGqgJQKLvEPWPzKCoQLK3MFl0yk
+zuMqz3I6BFdwKSQ17a7geByk4jYRrAYGHTr8Xw2mcX5pJCorx2OwMje3ZrqB43HADx/lfImnz50he6wb
+EyEvd3keKr7Nurl5zZI7w6Lv7a/jUWe3R0upYPFG5jkmdqaDxbVKMr7H9I3cCerRdTzTlTzPNSSd+HDM
+VzZMAC77hjo780PiTpPXeX5mcHescrs3oFXrYp8+F45MS6CnO+y6/F2H0rJHoDHrBiu5jcxTVKHsLeZ6
+/WyUjVW5I3nN0i1WhG1iOx7VFZIDGnugHVG4TnKT7Omh3TZ7HpSslm6LwVPw1Y0n0GscmQ3dsIOfDEI3
+B/jG6j2nDBY/sgSpR+NuGN+yrYbFz0yYn+sdZXKYR+ONVEULj1RFyEXOBYItwMINzwlVAAVSJtNl8NMP
+twGwirNm0A3zmHXJHSuerM1FuaN6Ast4mqII6vK/GxAB7Fm+wyqzjgeFj25Nvy9cKs233TCotfz6CTYr
+oMfmNJr+9OA1pYWSOVhPQl009oltfdSVpUWx9slmOSC21XFUvfGO/jrL+Z1y1FbOmxwHF4sfbaiNEByP
+UHmOaTf46kicNOwByNaVVtkT4KwY+yI8cyZmA2ra7tgWZ6E64dsZWI3U58fVmwKeiiyzSuZiW7Weheyk
+d2ewreQ+I7pPQW+8pPdC91aKLwls4ynfBzJ1WFqALhY/GWloXvjxqdqsD3WcZtqyg2tsyT4+Y+fsWBuY
+IuazROysIWLbmSacaWdsyXl5xmDX0m/Xz6hYSHffaqrSm9iM7G8GBqubTN4PdxUb6z5DcWTIOr0HMlEi
+VOD0S9dqn632r7qpNyalSFFoTOt7TYqX7V0m96jq67U0lV6OelA1UHda0D1TI8AoW8URZb7nztbdVJM3
+sihzJJx6/zPTBE0ROW4UR32P4mjkdUzdbuM4+40hddc09q0nS5FqILe5IlTy3peZKTP0NOvSZfDXYFVH
+sS4SnHrBxDvX5qq32jUbwGGxI0yD1XvKzI6Meb1q6eEFLRrGaHJuqiHw7PY1nbTFttnWN86+ewvHY12A
+O0VUUBUyztLTC0Xa7fJWETc6PC0BBoXgj4ptCxSkGzZh6j6UxPIruNySv+VXV/6Jza1PGo9g6sRgzsxl
+kU+9PZ/upPHaMPx3rjlh+hvbIryedLjdfrDb6t1Og5R3URug70q2RVvZDhXP2CVpOliR8QVezLbkh9KN
+jjlMqA4c6viUKBKeTwaokzQuhVXZBP0SHh5Cd4ax2pfBL3KPQBKYM6mc4sGggD7DsrE3O5EaT3aiKY0b
+Rz6gJqkQNkoWQBnC6dr5fMxOOFGrhodw6MX1DKNT4euK4Z+blUez0jxVIQ32Yo2z9njZzYReMacy7wwO
+0qlVZxZCOugWqP2Eek53u+YdK27W50/WfLZsHI67nK0xB/u/Pf1ot2SIbZdt9d76wk6gdsK/egP0f5YE
+3WCXSm4Vag2aGO102NxPBNwnGq6ZAvcm1LskMTrAHp7WnzV8Y9wvE/qbM2Ncl6WS+/HMAsfj/wcT2DXD
+pBa1aj0aTSAmgZzbkXumBBfb8x15Jz6g+Z7jGR35FTHVoKz4oDOt1k/SGS428nxP3iq2oWd0w8oN/LfP
+Tjs//cIO9Vr52M3pVYn11Y9TpWa2Fq+7vu1O83f5+OhXfDxC1IelMXD1ajrn2R1JsxSWLNf17H429inj
++SHcSvO10K6wH250KbyeOX9BExEokNpsciaytzJlhy81GL1VgK+LevfX3v1TcPIvP4fTYDX1rXd5rvEw
+PcPVOCys4Q4/r1Ug02haienyDSlkd6ey/J8KnqaSvh0fPnBV+2mNtpogZYdKu8D2sVlC6hlTz2dM/d2B
+lF2nXtcV3uKd/g8qOeXV4yNcmDNt4iV8s4SUEZrva25I9dWclMcHMsKlQqLDPLnYWGnN2TSgNsnXX3/9
+j5F8MAl3WEep7UHlyvHY8Nvt3fF46kB00JvJGBpvXx7EJ5SUnhPVQfkdR3bXYfWqv3KdWkvHZTNRFExt
+uTlUL7+Bv3xVPnzb/6QiQ4XAFIKQzXI5BXK+HVo4LpQ+8+PvAp5j2zUJBaMk42JrF/HVqc7C+1FNtYcE
+bHCa6vH0k/p3QPL7Y74hWv2LCbL1UJqCFPjdKde6uLeZI46qyMdR9f3pfwcAduUE75cqAAA=
 `,
 	},
 
 	"/template/read.html": {
 		local:   "template/read.html",
-		size:    2139,
-		modtime: 1523435440,
+		size:    1956,
+		modtime: 1792274652,
 		compressed: `
-H4sIAAAAAAAC/5xW0U7jOhB95yvmWuhCdUlD364g6T4si4SEtCvEIu0TcuNpM+A6wZ4Gqm7+feUkTZNC
-y8ITtmbmzDnHh0D0z8X3r7e/fnyDlOd6fBDVPwCiFKXyB4BojizByDnGoiB8zjPLApLMMBqOxTMpTmOF
-BSUYVJcTIENMUgcukRrjkegCJam0DjkWC54G/69Lmswj8DLHWDC+cJg4J8CijoXjpUaXIrKA1OI0Fr4Y
-TrKMHVuZD+dkhr79s0jzZXfcJZZyBmeTWIQPLtQ0CR+eFmiX1aIHJ8ZRWDftmcizPEf7txMWpdrdN04y
-4xgmWfZ4Twpi+O9otYLh1QWU5dF5dyQK168WTTK1bFAUFUAqFjUnAYmWzm2uNtPeqizTTLmAyiT/0I4m
-pImXZ5CSUmjOG4caxB7K/b20Nnv2AhQV+/rIGLQtEkAk2w60c+kfz6PIFmMD2BwPtqF9EiUZtOB9DNrr
-hm86GnvHbok1QllCADcoVRSmowYOIDKy6LBa6DW6kQUYWQQsJ67Duwpa91pJaVIlxldG4UtHRs2/P7Eb
-YLUCmsLw5801lKVn3p5QO6/Ap/8xbFPgC0ZBWYqezvf3r0XKhKlAsUtPf1sV1y8Lq+MNNTGuHd2zMAoX
-evOqld3dnDS5m+pM8hlYmqV83iG0WgWVJ9fS8Z20JA07KMuDz9K9JOu4x9dvaOztoFZ9/Z7K6U7L7w+Q
-9JV3Nu7XoaXjePTvthyP+1pNl2n3t8d/H2zf/iYIUytnczTstqy30swQDsnH+gQOp3AWw/By3bwlIAcl
-WQZT/9Fp6TehNgjHZJIGatAU6wGHT3X223ozVwvpp7PxesZwrNHA8A6to8y4AZxC0OPT6T7sP83rvkYt
-GoXWE/UcWmg4VphsrxsMhrf4wjt2Vs/70TWn+yGNelXZs6qz6G3UtzGjMH8/TL1jFNZ/c6Kw/h/iTwAA
-AP//UnET2FsIAAA=
+H4sIAAAAAAAC/5xVwW7bRhC9+yumC6Np0ZCEb4VNqoemAQIYaBGoBXoyRtyhOfZqyeyOKAss/71YkqJI
+NZHTnLzrmXn73ptnOv3u3e+/rv/+4zcoZWtWV+nwAyAtCXU4AKRbEgSLW8pUw7SvKycK8soKWcnUnrWU
+maaGc4r6y1tgy8JoIp+joexGzYHyEp0nydROiujnY8mwfQY51JQpoRdJcu8VODKZ8nIw5EsiUVA6KjIV
+ismmqsSLwzreso1D+7cibQ/zcZ87rgW8yzOVPPnE8CZ5+rQjd+gfevJqlSZD04WJuqprcl874Qj1l/tW
+eWW9wKaqnh9YQwY/vWlbiD+8g657czcfSZPj1tJNpQ8jiuYGWGdq4KQgN+j96eoqE6yqKiNcK+hNCov2
+vGHDcriFkrUmezc6NCIuUB4e0LlqHwRobi71sbXkJiSAFKcOclsMywsoOGGcAMfj1Tl0SCKyJQfBx2i6
+nviWN6vg2JrFEHQdRPCRUKdJeTPCAaQWmxmrnTmiW2zAYhMJbvyMdx+0+bWXMqZKrT5YTS8zGQN/w18H
+0LbABcR/fryHrmvb2YmMDwpC+p+TKQWhYDV0nVrofP39o0jMhRtSX9KzfK2P6y87Z7ITNbUaHL3wYJrs
+zGmrvd3znIy5K0yFcguOH0u5mxFq26j35B69/IWO0YqHrrv6Vrrv2XlZ8A0vjPbOUPu+ZU/v9Kzln/9B
+MlReefGyDoNespvvz+Xc4+fUzJnO/3oA0o1b2j8GoXD4uCUr/sx6h/aR4JpDrN/CdQG3GcTvj81nAmrQ
+KBgV4aMz0R9DbQl+YJuPUD+OxWHA06ch+1N9nBuELNMZWO1ZSojXDq03KFxZuF56Hy2ITWLIanIQ/6c8
+LeTi3HURr+lFPj9t9dnv06R+fSuLY5oMH+80Gf4Z/zsAvrGnE6QHAAA=
 `,
 	},
 
@@ -13650,22 +13850,193 @@ B9XSeyUeKvGFnTyK0PXOFZEfG4EMLJbpHXLnMR4SZ9h+kbXlIa953m6j84fvVwAAAP//B632NRAFAAA=
 `,
 	},
 
+	"/template/replace.html": {
+		local:   "template/replace.html",
+		size:    3152,
+		modtime: 1792273883,
+		compressed: `
+H4sIAAAAAAAC/7xXS4/kJhC+76+okBzjRnOLVtiRsjsbrZTsjiaTSDliU24zg8EL2NOtlv97BH603Y/J
+JFH24qagHt9XFAXNvnn/+d3Dn3e3UPlaZW/Y8APAKuQiDABYjZ6D5jWmpJP43BjrCRRGe9Q+Jc9S+CoV
+2MkCkyh8D1JLL7lKXMEVpjdk6aiouHXoU9L6MvlhWlJSP4HfN5gSjztPC+cIWFQpcX6v0FWInkBlsUxJ
+WKS5Md55y5tNLfUmqP9bT6XRPuHP6EyN/9lZvV+au8LKxoOzRUroo6NK5vTxS4t2HwM9OpIxOii9YLGm
++g+McrN7rYnFRvECz1QZnQqB5UbsR2shOygUdy4loQ641GhHzgCsuskOB9j8ZMzT5kF6hdD3kMAHqQVw
+LWCMxWh1k72ZjDTvJgcArFWTf8070LxLPM8dOWrEvVmKAIxPG0Gyj1rgjlG+MqBKvs7B4QCyhM3v979A
+3x8OixEqF9iE7D7RmeTH9+OqFtD35Jz93yOZ6PLCyw7JNWYX4k5b92NrVXoES7LzfL8AgtFWTRKjcTMm
+KbfHcWlsDVKkZPSZhAkygQ9CIrWSGgnU6CsjUvLz7QOBQMvolwksSDOpm9aPB6+SQqAmYwdqrSLQcdVi
+SpZsj7aL4oyAtta0zbp0lu7DuV4zCCVtjYKolbh6Cl1KLQhErJVRAm1KYo6lBm+5dooHkm4F73MT5zZR
+se8J8Nab0hStW+ZeyO5rEJgSveZwP8zCs/TVReyTwjrN10EXFRZPudmdHFeeo8pWyGfFGd8Wd82M4YbA
+eBKPSIIC9H20RDGfuQzucdsqbgF3jUXnpNGMDiH/V8gFd/gC4F+5L6p3PDaNc8xxFYKLr4I1JAZt9xLe
+u1HlKuRJ4XWo89Z7o0c4rs1reazU3GvIvU4ElrxVPo5dTbI7i+GVwehgfPQ2Qn1Xcb1FB32/pL+KNAhn
+kRora273YyTgTaP2JJuqmyt1MebAfG6N4YBd7I0jvFtrjV2CW24dV2g9xG8iAg0brqqOKxm69GkBvw0+
+Z4er3E5X0ZWEsCb7A23w4cCb8OTSW4zuFOqlBUgdg3ywfFuj9nGunIQNo83MLwb9LpcC3qbL9n0M6nmu
+cKI6CPEb+pFA7VCMsvNWNrOUGyvQKnQOKum8sft1Zfvjw2OBxAYKV6phMLPZyVSYFDM+3PlEm2fL1631
++p0bufc9XeYrZoBk34a53/DLp7Y+v+2nyvHiIqD4WngvyzJanisxesrktChHrXWaGI3ZvVQwJ3fSsmo+
+GV9JvYXStFoM238p5lyLjA5RGR3+OPw1AKX+IQtQDAAA
+`,
+	},
+
+	"/template/report.html": {
+		local:   "template/report.html",
+		size:    4150,
+		modtime: 1792275632,
+		compressed: `
+H4sIAAAAAAAC/8xYS4/bNhC+51dMiUV6iU3kVqCUAjSPIkDRBFk3RY+UOFoxoUiXHNlrGP7vBfWytJYf
+2PbQy65EfTPzzXwzJGHxw7tPb1d/fX4PJVUmfSHafwCiRKniA4CokCRYWWHCNhq3a+eJQe4soaWEbbWi
+MlG40TkumpdXoK0mLc0i5NJg8pqNHeWl9AEpYTUVi5/6T0bb70C7NSaM8JF4HgIDjyZhgXYGQ4lIDEqP
+RcLiR545R4G8XC8rbZcR/lxPhbO0kFsMrsJ/7azajc1D7vWaIPg8Yfxb4EZn/NvfNfpdE+hbYKngLeiC
+xTTVp0aC91KJzKld50fpDeRGhpCwqJTUFn3HCmC/h62mEpa/OPcdDoduGUCUr9P9HpYrTQbhcIAFfHWm
+rlDw8nX64oizcpMObwCiNn00Kzdg5WZBMgtsjGmqOV0AELIvHks/WoWPgssnRtzo293s96ALuFv+8eU3
+OBz2+/EjmhBziuX8zmOWH991H6yCw4FNMr+NR5+0zElvkJ3PbhqTe4xD9Kb2JhlxZGlf7CuxBa/N8V3w
+kRrHPCei/i9VvLl6bbn+q/I0avdVEJkfKiIK56ueVHxeaGu0RQYVUulUwn59vxrRHMaolW/MQNt1Td3G
+UWql0LJuB629YbCRpsamW5eN7mOXHb/B1WiSG04P3tXrSbGEkRkaKJxPWOFdxdKVlzYYSaggLgjeINJT
+hlp1Jh279rnlrSQhm4SOO4l3BhrbRagmiXzwrpomI7jSm/S5mZBjKbnL1Mn1xMk9k/bKXSad1UTOdr5D
+nVWaBu8ZWcjILhQWsjbUPIeKpfel2wreWo5cySt2Xbu/iSIko5K+JJcMVF8WSR42LH17/3U0CILHTNO5
+rm63xOUXtw2TviKZGewZtS/N31guhTag6t4Deb0e3jLnFXqDIUA7lotmfSojHW8PxzU/XeiZWUenB9HI
+Uxq/CU7ljPmTWTnafPDyoUJLYc4wAu5d7XOErfPqGiZeWGRO6K8Bm9vAWdBK+gekyxE7zPWIHfBcRMGn
+1Rb8RBFBx9vCqKJe2gc87ZazGo5VvDsrYzRV6czy7BkZvUwPyn4i7p6MxF0/voPZ2fN7KISaTWG2k1ra
+7SR2/dS4nvPRI9te+DOqfCP2bbwQ34i9bwS/Cm7b4zYSLfY2Ei32IomnrTdfXcFP2u/KbiCocI5m9oD2
+CF45kmZeQT/bCHGIos3chPWAGeUvYk+0vwE9KvwN6HHpL8JPOuAG9K1UTrtgBn7aB+fmTPATaU+BgjfH
+zKVr7no4zPCRFlVNqFj6u6NS2wfYygB0vBVpC1TqAGv02qml4OvzN8RZv0PYT9bsgEqEYmiUUZzC+faj
+9oGAdIVtaOwig/QIuastoXrVtjKVqD3kpQtoB1fa2eUQclWODyXQNje1wsZrWMscw88gLciaSud/DO0h
+ATrE1JaNxp/RN+rB4TBytBzuEl0xhquQ4O2wCt7+PPDPAKAD6Yk2EAAA
+`,
+	},
+
 	"/template/scratchpad.html": {
 		local:   "template/scratchpad.html",
-		size:    1699,
-		modtime: 1522898724,
+		size:    1810,
+		modtime: 1792273155,
+		compressed: `
+H4sIAAAAAAAC/5xVwW7jNhC9+yumPPRUmfWtaEkVaBMsAiyQYOM97JES6YgxRWrJkRxD0L8vaEkObSfZ
+ZE8mMcM3b94bj9hvV7f/r7/dXUOFtckXbPwBYJUSMh4AWK1QgBW14qTTatc4jwRKZ1FZ5GSnJVZcqk6X
+Kjtc/gBtNWphslAKo/iKpEBlJXxQyEmLm+yvOWS03QLuG8UJqiekZQgEvDKcBNwbFSqlkEDl1YaTGKSF
+cxjQi2ZZa7uM6RNSKL1uEIIvOaGPgRpd0MfvrfL7Q+ZjIDmjY9IbL2rht9LtbKbxvc9C6QWWVSPkRTaj
+s5yscHI/AUjdQWlECJxENYW2yk9dRANWed/D8j/ntsu1RqNgGCCD+2MVRqtVvpjTrejmpwCsNTOyFR1Y
+0WUoikCeMw6Sp1cAJmZ9SX5jpXpiVJw8oEa/D6DvQW9g+fXLZxiGvk9OyoTYR3RvS4/t3VxNUSthGMhl
+3z9nMrcrStSdIq919kLdxLd/W2/4M1+Sp2L/ohYvlnwwLgTh9xcFP02BN8sx2pr5xujB+PlW+ON5NME6
+hOW11AjDsPiwHkpq5Kvfz1jOWhdooUCbSbURrcET0WPJhHDSzYHjC5ej/8dmkv+Hd7sEnm2cryFa7eyH
+TYVaYeUkJ3e392uSUD4sGk4udZM6NEbs/wbrrPonmdPUoYRsZJc9eNc253MYV5vwSoCWnMQV0zZk2qwx
+RE4Q4k7wzhDwbhc4Wf1JQLToNq5swykuQN9nsFyrJ4Qs8XnUfi56OlBSdx/nX7SIzh7Yj8csiE6RaXGH
+tqg1vjYe0LTGZF4/VEjO6d+LTp2xHvHf4Mxo5JkvToLp6MwaT2uc5GnOEYzRcSMzOn76fgwAKYZDFBIH
+AAA=
+`,
+	},
+
+	"/template/search.html": {
+		local:   "template/search.html",
+		size:    2392,
+		modtime: 1792272895,
+		compressed: `
+H4sIAAAAAAAC/6RWT2/jthO976eYH389RiaCXoqC0qHdbXeB7aZtculxJI4sJhSpkCN7DcPfvaBsyZbt
+uMX2kmg0/968NyNY/e/9w89Pf/3+ARpubfFO7f8BqIZQpwcA1RIjOGwpFytD684HFlB5x+Q4F2ujuck1
+rUxF2WDcgXGGDdosVmgpvxenhaoGQyTORc919sPossa9AG86ygXTV5ZVjAIC2VxE3liKDRELaALVuUhO
+WXrPkQN2i9a4RQr/1kq1d5zhmqJv6T8Xazen6bEKpmOIocqFfI7SmlI+v/YUNkOj5ygKJfdBNzLmo54n
+KTlKpUqvN4c62qygshhjLpJSaByFA6ok7n3xSBiqRsnmvng3vna4GkMAVG/HCg5X4HCVMZZRHCMGfk5N
+AIUjGaL45DR9VRJnCXKeoawZm2DFZkXirXpxwCsm3DfKKtnbaVQ5DDVaZTg+1z60kLp6d6wPLXHjdS5+
+/fB0guWUT+O6nrNl8H03J2NwnGyLOBzNqxhTU8ss6RG8FbBC21MutltY/JF2AnY7AZ3FihpvNYVcPASz
+NA5tvAMO6KLFhDbeQeXblhxHQKchVgG5ajrUUQD27Gtf9XEGLXboruDPSnbnjJc9s3eHMWJftoYn/CU7
+KNllmmrsLZ+lAqhJzRqhxmwUTcnzPZH7NnMRE8pTGbVZHXVM3F0VcrsFUx8pnPIP7/+k2FuOp57J9xR6
+VyGTnnsBVDcOkoTM2p5Ji+LB2Q1wQ1CbEDkVWXw2rWHY7aBNElAEDASx8Wu3ULIr5i3J6QsYAd2S3kA5
+BHxXGg0/5rD4yfuXT+8vkDbfX6gwHU3p/Yvcbk9yRZHMJ8OWYLc7O6PhY3JWTjGWliY2BmP4m/ZYk4uk
+D3bkYLrJKn3QFCzFeLklfPxQAVxj46O5pGLMDcWV18mhZ5I5vw7YievB0wL8EnCZDumS19uEDqLsdgO3
+sxqi+H9690ivX/r2GsPzhbCRvq3x8eZF8Tg9/0M3p99qpiTrf8fr4RSGwQ1ZPcx4I3mI/Pj02+cbgUpe
+U/UtxEpe2R8lh7W7fXBXGVdd8cVzY9wSat87Pb/b8yJze/pEKbmHpOT+B9TfAwCs2X4cWAkAAA==
+`,
+	},
+
+	"/template/stats.html": {
+		local:   "template/stats.html",
+		size:    4492,
+		modtime: 1792275532,
+		compressed: `
+H4sIAAAAAAAC/+RYX2/bNhB/z6e4ES3QApWUbH0YOkrFkmxYh6It2gzFHmnpZDGhSZU82/EMf/eBtP76
+X41uDwP2kEQk7453v9/dkQz/7vb9zd2fH36BimYqu+DbPwC8QlH4DwA+QxKgxQxTtpC4rI0lBrnRhJpS
+tpQFVWmBC5ljFAYvQGpJUqjI5UJhesWGhvJKWIeUsjmV0Y/tkpL6AWhVY8oIHynJnWNgUaXM0UqhqxCJ
+QWWxTJlfTCbGkCMr6ngmdezFv9VSaTRFYonOzPAfG5uthuout7ImcDZPWXLvEiUnyf2XOdpV2OjesYwn
+W6ETGuNQd5V40lLFJ6ZYNXYKuYBcCedS5pkSUqNtvAJYr2EpqYL42pgH2Gyaac/6VbZeQ3wnSSFsNhDB
+JxIkHcnc8aS6yi56WS0WWTcC4HPV7qjFArRYRCQmjg1lAqLjCQAuWgBZ9kYX+MgTsaOUKHm+mfUaZAlP
+4j8+voXNZr0efqJyPi4P6UPiI31z2yzoAjYbNor+PD/aoEVOcoHseHTjPRNHgtzruVXpwEWWDfH+yvY8
+mat+zJMBIX2oI27/s2SeDWJA7d9EKfDeAsEnNrvYLZJ+rxGcRYdQoaLKWPmXrzMFwcPIzWczYVejSHhB
+2Z0V2ilBWPCkoPFqMRwGB+qcIL7xDbPXayeM32yzeQqmBKow9FWRE1o3svLMZ9yvVkxnqGloZrOBBMaL
+jUko25nnIxyLYjeaDyLHQ3F4163UVAJ7Gl+WDGIv6U33XrZ+GyunUgsFAgqxArNAG+aVcAQ/XPpJd3Bv
+a+4x95HkZlYrJGn0OZjKEuJO96ZTHXLbyZ4j2JaaLEHoMTvP8MtJ+p7vb1oYjQfN70lyVwvdpqA/nKLZ
+nLBg2Vw/aLPUPPECe+E3CX+MV54UatgXqpce6KlF51vGy16wK492+YNRKyX1jqfcLaatk3UjGfkkIAb+
+MnFtHlN2CZdwdRl+GNQWHdoF/uxqzOmjIGlSpo3e7Qq8bjesjdTkQuOPfQ+FBeZkbIRliTkF5XATkXoa
+ObLmAf0J2qqPe4VbTEcTg3N07H4kHqWDIe4Xe+z4OngmfReEJ4M20kEGl8/jW0Hbs2afrRHD9VypyMpp
+RSwjU4jVvgZPCrnILk5mDq8Ppcw7Q5XUU/AR9Zm6Qop5UmcXx9Jnv39WL7O3o7rtU2YI5UTYJglGxq3Q
+U4T4VqyuhXU7jo/VGZA/pbekNxi+CiUbymvcal6Ehc/GFmFh6T9ejHvfqO3tcum3Dve9lFXoKXjVNpLf
+wnB7hRgNGuQvO4ie/sSyHYIOMjbEt1neBffqe1giPnwjup8RH86H10v7Tv3/gvl6FRAeAtx4MqhiD834
+SkBiorCrrzAIv6Pc6AK1w6IZO7Ky7kYTYwu0Cp1rLg9henx1oP491s/ZvXsSVVnDGE+oOrTcUXFMIPB3
+bPGmI/ukenu2t80kHM1fs3iWEk/GUfNkDxlO/TtoP/1PEXgUVz9ZZIMa4AkVx4WG6X5asi2W01JtrZ2W
+uutxO8/sQOHUDruQH7tG7MDOk5DGp14kR84iCK8ASavdE2j/vDloodvgrsJtFwo3s75RgbAIVBmHe5fQ
+Ztz1qOF5WJrtzbSU1hGQnGF8sfdCeV2m985olv3+6f27weOkC6NrRjzZAsaT7T9d/h4AJZM24IwRAAA=
+`,
+	},
+
+	"/template/terminology.html": {
+		local:   "template/terminology.html",
+		size:    2864,
+		modtime: 1792273293,
+		compressed: `
+H4sIAAAAAAAC/6RW34/jNBB+379iMIgnEuskkBDnBB0coBOIQ8vywKMbTxrvOnbWnnS3qvK/I+dX0266
+3O69tB575vM3842nFV+8//jzzb9//QIV1Sa/EsMXgKhQqrgAEDWSBCtrzNhO40PjPDEonCW0lLEHrajK
+FO50gUlvfAPaatLSJKGQBrM3bAlUVNIHpIy1VCbfT0dG2zugfYMZI3wkXoTAwKPJWKC9wVAhEoPKY5mx
+eMg3zlEgL5u01jaN7q9FKp2lRD5gcDV+Nli9X4aHwuuGIPgiY/w2cKM3/Pa+Rb/vL7oNLBd8cHom4jTV
+8yDBJ6nExqn9iKP0DgojQ8hYVEpqi35kFcV9kx8OkP7k3F16o8kgdB0kcIO+1tYZt90LXr3JryZ/K3dT
+LIBozQRt5Q6s3CUkN4EdPfrCLU0AIacqsfyDVfgouDwJ4EZ/GsDhALqE9J/rP6DrDofFCk2IicR63fE5
+vw/vx1OroOvY08RfzWTtIr41LgTp9z+23mRHeiz/bTz4/+um6sqC9A7ZS26no4JPCJyo+wwHwVszWYL3
+0k/Wxs/rUYZrDK2hAF13jG+W2JHBr9oqbbfRC7QtnA06ENpCYwBto4tBu8SCmEdIFzCRfIBa+jtUIAOI
+0Eg7FcrIDRroPxOFpWwNsVwhYUGoBI+uS0rSI1hH8WqqECbF3kZLe/BoFXptt6BD7+AsQhtQQe0CgSsJ
+bbqoVjPXpM/2q41W8EO2lGV57KXd4mrdhujgWl9gD/D3sDxxEdW3p/1wOCwc4WsvvX/bb17PaXTdeURU
+7l1LLnb/Sws5P6XTtgy1NCafpFwqzoce8HJbo+1TFnzwPmnB08RGkguc0+tIbgxOtAej/0wKZxXaqNZg
+B/K6ma2N8wq9wRDOnlWEPI7Ps3KNml0iM8X7fGU7HqiZKD5SYt2Dlw1bd1574H1HdR1flrHvK5Z/2cuP
+93+29dNJtqwuqU8jV7eE6iK3QRW8h/R3bRWw1pKXNhgZg6DrDJYEy83jVFa6LNGjpfX+eRHTwpmkVsl3
+/TAfnmtlIP3o9VZbaeZH1HUvhLuc9tgCN2Nm2tlwKYVxBs7UIO2JNM+gv6Iggq/12yUswVe6W/D+UeRX
+z8Wf7xwVvTDwbyr0CDrEEVvF8UMOigqLuxTeKTVM9rhHFV599o/qdv1H1XmYexBq5xFcGS+ECH42u9fS
+FFzp3fDnaiib4MMf4/8GANwwRc0wCwAA
+`,
+	},
+
+	"/template/trash.html": {
+		local:   "template/trash.html",
+		size:    2479,
+		modtime: 1792272895,
+		compressed: `
+H4sIAAAAAAAC/6RWUW/jNgx+76/ghBtwB8zRir5sg2xgu27APe3QKzDsUbaYWK0seRLjJjD83wfLcewk
+Tte7vcSk9PEj+VmUI767//Pj49+ff4eSKpPdiOEBIEqUqjcARIUkwcoKU9ZofKmdJwaFs4SWUvaiFZWp
+wkYXmETnB9BWk5YmCYU0mN6yOVFRSh+QUraldfLTuGW0fQba15gywh3xIgQGHk3KAu0NhhKRGJQe1ynr
+N3nuHAXysl5V2q56+LcyrZ2lRL5gcBX+b7JqPw8Phdc1QfBFyvhT4Ebn/OmfLfp9TPQUWCb4AHol4rTV
+rwjK3e6tIeRlKC+Ago/HQORO7Q+xSjdQGBlCyvpTILVFf+gYQJS32WNPJnh5m92Mq1Y2IwJAbM1IYGUD
+VjYJyTywCRGln7sAQo46s+yTVbgTXJ4EcKPfSBBQ+qJk2Zf4/G+esVhZkG6QXaONGrKx+1dIBd+a0RM8
+SjN6uT/abQt6DavfnHsO0HVTNMnc4FjT4MTfpHBWoQ2oDn4gr+ujV7oG/cHOnVfoDYYz0Wka+2nNny5E
+WN+lDUaSdlZwKpcgH11VGyS8tv+AlWtQLW0PvW8IVg/YXzPaWfhxLsKc5y9tDOQI9dZvrtKhVZfxffQl
+XvDTlgW/kEXQNA+zJO/8sdpf0nntZ5nbFry0G1x4u0fRQUmSSe7cc6JVytoWVp/uoetYtiCCOh4HTQZZ
+1qMfexO6TnBSizGXi7GyuiBY/eHlpkJLYXzPqOaLjqSBrvt+geF9286RU3jXAYe2XaD5cFnd19QsSFfY
+y4W9EaXy6+Lu7u5nWN2jwSE5g6hN3B6xX8ifQJbYB008Eu3n2KU6eM+ZvbGZ4xF/518/4kPfZ1W/741f
+lZpKmmg+XH3p1+bg9AjhjhKvNyUt6iHyLZGzh6/i4LAxNicLOdlE4VpuDUV7F2BAJR4DOY8sexgMwYeN
+b0/TD5E/yxKvAZZ97h/XMywJdD74y4IJfjb8gsdLNZvubTQB50Gizh5LhPiBAB0Aq5r2K8Hr7GYpj+BK
+N8P3d8gk+PC/7N8BAKMeUe2vCQAA
+`,
+	},
+
+	"/template/typography.html": {
+		local:   "template/typography.html",
+		size:    2057,
+		modtime: 1792273836,
 		compressed: `
-H4sIAAAAAAAC/5RVTW/bOBC951fM8rCnlbm+LXYpL9AmhwAFEjTuoUdKHEeMKVIlR3IMQ/+9oGTJtPPR
-5iQSnHl8781wJP64vvu8/n5/AxXVZnUlxg+AqFCquAAQNZIEK2vMWadx1zhPDEpnCS3lbKcVVbnCTpeY
-DZu/QFtNWposlNJgvmQpUFlJH5By1tIm+2c6MtpugfYN5ozwmXgZAgOPJmeB9gZDhUgMKo+bnMVDXjhH
-gbxsFrW2ixh+RAql1w1B8GXO+FPgRhf86UeLfj9EPgW2EnwMeiejln6r3M5mmn43LZReUlk1Ur2IFnyy
-UxRO7Y8ASndQGhlCzqKbUlv0RxWxAMvV4QCLT85tF2tNBqHvIYOH+RbBq+Xqagq3sptSAURrJmQrO7Cy
-y0gWgZ0iBsvTLYCQk79sdWsVPgsuzxL4ecbbAIcD6A0svn39An0fRcwrNCHqiNXb8lne7fXx1Croe/ZS
-96+ZTHJlSbpD9payV+5N6vZ/601+4stWqdnvMBC8NXPd+FCJaVf4eT26Yh3B4kZpgr6/+jBBVJry5Z8X
-PCfxBVkoyGYKN7I1dOZCvDIhnKgZOL6ymQsyi0ka1rtdAi82ztcQvXf2wy5DjVQ5lbP7u4c1SygPL3/q
-ptQ3pUNj5P5fsM7if0njpBVKyEZ22aN3bXPZGHHWSI8StMpZfPNtw46jLh6xM4T4SL0zDLzbhZwt/2Yg
-W3IbV7bhHDeal8Fijc8EWVLn0fvp0vOGUrr7OP+iJXJ2YD8usyA7ZMdJGtqi1vRWe0DTGpN5/VgRu6T/
-IDu8YD3iv8NZ8Mjz9A6Gw7R1Jo+PczWOyFPMDCb4OCIFH/9FPwMAAP//1ZnQxqMGAAA=
+H4sIAAAAAAAC/6RVwY7bNhC971dM2R4jE3srAkpFu9sCAYombdxDj5Q4XnKXIhVyZFsw9O8FZUmW1m6S
+theLQ86bmTdvSItvHt8/bP/68DNoqm1xJ84fAKFRqrQAEDWSBCdrzNne4KHxgRhU3hE6ytnBKNK5wr2p
+MBuMN2CcISNtFitpMb9ny0CVliEi5aylXfb9dGSNewHqGswZ4ZF4FSODgDZnkTqLUSMSAx1wl7N0yEvv
+KVKQzaY2bpPc/2uknXeUyQNGX+P/DlZ3S3isgmkIYqhyxp8jt6bkz59aDN2Q6DmyQvCz02cQa6r/AlT6
+49dCqGv8U5CN7q68BZ9mQZRedWMAZfZQWRljztIoSOMwjLQBhL4vTifY/OT9y2ZryCL0PWSwnbMIru+L
+u8ndyf0EBRCtnSI7uQcn9xnJMrKLxyDM0gQQclKBFe+cwqPgcgXg1nxdgNMJzA42f/7xK/T96bRYoY2J
+R2rtC5/pvXscT52CvmfXvL9cyURXVmT2yP6J2Y28C91+aIPNL/WyYtnsz1QgeGsnS/BBickqw7weu/Kg
+pXvCCH1/we98qMGonF1qydIem2glIzPOGoegTSQfOgY1kvYqZx/ef9wySMy9+yLH9Qw0SwtgG6SLVqZA
+EcjDzhzfprotumXhmxXo99YTxjegZNTpi9aaJmIE6RQ477IyoHwx7gliI6u0HzBFRgXGrSKRRkhvRISD
+NpWG8VbAQxeMtaYCi0QY4jq/KFsi78Y3JrZlbWhuXEkOSnJZE0wtQzesj5EVPzaN7QQ/Q9fKNkthU+Nn
+BQcNvyuNgrf5sr0Xf5KlxSn32Rh+s8o7hS6iGu1IwTSzVfqgMFiMcVZ3VRNdXo1FJSHpcXOgJlgoXm2l
+TTXXh0fKnD8E2bBrx1vXZuDe92m5+SXIpxodDR1gxbdp7yN++q2try/s1E1SNwsaLvyj2e0G5LWT4K+Z
+zI/FSrlXbRJ86G5xuX/j83O3mP+txoBgIjhPOg3peew3izlYJxNcmf35UT+nE/z8j//3ADMPVlUJCAAA
 `,
 	},
 
@@ -0,0 +1,359 @@
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/gorilla/mux"
+)
+
+// statsPaceDays is the number of the last days the pace of the translation
+// is averaged over.
+const statsPaceDays = 30
+
+// activity is the amount of work done in a period of time. A fragment is
+// counted on the day it got its first translation, with the words and
+// characters of its original; a version of the translation is counted on
+// the day it was last edited.
+type activity struct {
+	Fragments        int `json:"fragments"`
+	Words            int `json:"words"`
+	Chars            int `json:"chars"`
+	TranslationWords int `json:"translation_words"`
+	TranslationChars int `json:"translation_chars"`
+}
+
+func (a *activity) add(b activity) {
+	a.Fragments += b.Fragments
+	a.Words += b.Words
+	a.Chars += b.Chars
+	a.TranslationWords += b.TranslationWords
+	a.TranslationChars += b.TranslationChars
+}
+
+// dayActivity is the activity of a day, or of the week starting on the
+// day.
+type dayActivity struct {
+	Date string `json:"date"`
+	activity
+}
+
+// progressPoint is the progress of the translation by the end of a day.
+type progressPoint struct {
+	Date                string `json:"date"`
+	FragmentsTranslated int    `json:"fragments_translated"`
+	CharsTranslated     int    `json:"chars_translated"`
+}
+
+type statistics struct {
+	FragmentsTotal      int `json:"fragments_total"`
+	FragmentsTranslated int `json:"fragments_translated"`
+	CharsTotal          int `json:"chars_total"`
+	CharsTranslated     int `json:"chars_translated"`
+
+	Days     []dayActivity   `json:"days"`
+	Weeks    []dayActivity   `json:"weeks"`
+	Progress []progressPoint `json:"progress"`
+
+	// Pace is the number of characters of the original translated per
+	// day, on average over the last statsPaceDays days.
+	Pace float64 `json:"pace"`
+	// ProjectedCompletion is the day the translation is done at the
+	// current pace; empty if it is done or there is no pace.
+	ProjectedCompletion string `json:"projected_completion,omitempty"`
+
+	now time.Time
+}
+
+func day(t time.Time) string {
+	return t.In(time.Local).Format(dateLayout)
+}
+
+// weekStart returns the Monday of the week of the day.
+func weekStart(date string) string {
+	t, _ := time.ParseInLocation(dateLayout, date, time.Local)
+	return t.AddDate(0, 0, -(int(t.Weekday())+6)%7).Format(dateLayout)
+}
+
+// firstTranslated returns the time the fragment got its first version of
+// the translation, or the zero time if it has none.
+func (f Fragment) firstTranslated() time.Time {
+	var first time.Time
+	for _, v := range f.Versions {
+		if first.IsZero() || v.Created.Before(first) {
+			first = v.Created
+		}
+	}
+	return first
+}
+
+// bookActivity returns the activity on the book by day. The fragments of
+// the book must have their versions.
+func bookActivity(book Book) map[string]*activity {
+	days := make(map[string]*activity)
+	on := func(t time.Time) *activity {
+		d := day(t)
+		a, ok := days[d]
+		if !ok {
+			a = &activity{}
+			days[d] = a
+		}
+		return a
+	}
+	for _, f := range book.Fragments {
+		for _, v := range f.Versions {
+			edited := v.Updated
+			if edited.IsZero() {
+				edited = v.Created
+			}
+			a := on(edited)
+			a.TranslationWords += wordCount(v.Text)
+			a.TranslationChars += utf8.RuneCountInString(v.Text)
+		}
+		if first := f.firstTranslated(); !first.IsZero() {
+			a := on(first)
+			a.Fragments++
+			a.Words += wordCount(f.Text)
+			a.Chars += utf8.RuneCountInString(f.Text)
+		}
+	}
+	return days
+}
+
+// computeStatistics returns the statistics of the books, which must have
+// their fragments with the versions.
+func computeStatistics(books []Book, now time.Time) statistics {
+	st := statistics{now: now}
+	days := make(map[string]*activity)
+	for _, b := range books {
+		for d, a := range bookActivity(b) {
+			if days[d] == nil {
+				days[d] = &activity{}
+			}
+			days[d].add(*a)
+		}
+		for _, f := range b.Fragments {
+			n := utf8.RuneCountInString(f.Text)
+			st.FragmentsTotal++
+			st.CharsTotal += n
+			if len(f.Versions) > 0 {
+				st.FragmentsTranslated++
+				st.CharsTranslated += n
+			}
+		}
+	}
+
+	weeks := make(map[string]*activity)
+	for d, a := range days {
+		st.Days = append(st.Days, dayActivity{d, *a})
+		w := weekStart(d)
+		if weeks[w] == nil {
+			weeks[w] = &activity{}
+		}
+		weeks[w].add(*a)
+	}
+	sort.Slice(st.Days, func(i, j int) bool { return st.Days[i].Date < st.Days[j].Date })
+	for w, a := range weeks {
+		st.Weeks = append(st.Weeks, dayActivity{w, *a})
+	}
+	sort.Slice(st.Weeks, func(i, j int) bool { return st.Weeks[i].Date < st.Weeks[j].Date })
+
+	var p progressPoint
+	for _, d := range st.Days {
+		if d.Fragments == 0 {
+			continue
+		}
+		p.Date = d.Date
+		p.FragmentsTranslated += d.Fragments
+		p.CharsTranslated += d.Chars
+		st.Progress = append(st.Progress, p)
+	}
+
+	since := now.AddDate(0, 0, -statsPaceDays+1).Format(dateLayout)
+	chars := 0
+	for _, d := range st.Days {
+		if d.Date >= since {
+			chars += d.Chars
+		}
+	}
+	st.Pace = float64(chars) / statsPaceDays
+	if remaining := st.CharsTotal - st.CharsTranslated; remaining > 0 && st.Pace > 0 {
+		days := int(math.Ceil(float64(remaining) / st.Pace))
+		st.ProjectedCompletion = now.AddDate(0, 0, days).Format(dateLayout)
+	}
+	return st
+}
+
+// LastDays returns the activity of the last n days, including the days
+// without any.
+func (st statistics) LastDays(n int) []dayActivity {
+	return fillDays(st.Days, st.now.AddDate(0, 0, -n+1), n, 1)
+}
+
+// LastWeeks returns the activity of the last n weeks, including the weeks
+// without any.
+func (st statistics) LastWeeks(n int) []dayActivity {
+	monday, _ := time.ParseInLocation(dateLayout, weekStart(day(st.now)), time.Local)
+	return fillDays(st.Weeks, monday.AddDate(0, 0, -7*(n-1)), n, 7)
+}
+
+func fillDays(list []dayActivity, from time.Time, n, step int) []dayActivity {
+	byDate := make(map[string]activity, len(list))
+	for _, d := range list {
+		byDate[d.Date] = d.activity
+	}
+	filled := make([]dayActivity, n)
+	for i := range filled {
+		d := from.AddDate(0, 0, i*step).Format(dateLayout)
+		filled[i] = dayActivity{d, byDate[d]}
+	}
+	return filled
+}
+
+// chartBar is a bar of a bar chart of the activity.
+type chartBar struct {
+	dayActivity
+	Height string
+}
+
+func chartBars(list []dayActivity) []chartBar {
+	max := 0
+	for _, d := range list {
+		if d.Chars > max {
+			max = d.Chars
+		}
+	}
+	bars := make([]chartBar, len(list))
+	for i, d := range list {
+		bars[i] = chartBar{d, pct6(d.Chars, max)}
+	}
+	return bars
+}
+
+// ProgressPolyline returns the points of the progress chart, in a 100 by
+// 100 box, with the time along the x axis.
+func (st statistics) ProgressPolyline() string {
+	if len(st.Progress) == 0 || st.CharsTotal == 0 {
+		return ""
+	}
+	first, _ := time.ParseInLocation(dateLayout, st.Progress[0].Date, time.Local)
+	last, _ := time.ParseInLocation(dateLayout, day(st.now), time.Local)
+	span := last.Sub(first).Hours()
+	x := func(date string) float64 {
+		if span <= 0 {
+			return 100
+		}
+		t, _ := time.ParseInLocation(dateLayout, date, time.Local)
+		return 100 * t.Sub(first).Hours() / span
+	}
+	y := func(chars int) float64 { return 100 - 100*float64(chars)/float64(st.CharsTotal) }
+	points := []string{fmt.Sprintf("0,%g", y(0))}
+	for _, p := range st.Progress {
+		points = append(points, fmt.Sprintf("%.3g,%.3g", x(p.Date), y(p.CharsTranslated)))
+	}
+	return strings.Join(points, " ")
+}
+
+// booksWithTranslations loads the books with all their fragments and
+// versions.
+func (a *App) booksWithTranslations(books []Book) ([]Book, error) {
+	for i, b := range books {
+		book, err := a.db.BookWithTranslations(b.ID, 0, -1)
+		if err != nil {
+			return nil, err
+		}
+		books[i] = book
+	}
+	return books, nil
+}
+
+func (a *App) serveStatistics(w http.ResponseWriter, r *http.Request, book *Book, books []Book) {
+	st := computeStatistics(books, time.Now())
+
+	if r.FormValue("f") == "json" {
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(st); err != nil {
+			logError(err)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html")
+	if err := statsTmpl.Execute(w, struct {
+		Book       *Book
+		URL        string
+		Statistics statistics
+		DayBars    []chartBar
+		WeekBars   []chartBar
+	}{
+		book,
+		r.FormValue("url"),
+		st,
+		chartBars(st.LastDays(statsPaceDays)),
+		chartBars(st.LastWeeks(12)),
+	}); err != nil {
+		logError(err)
+	}
+}
+
+func (a *App) BookStatistics(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	bid, err := u64(vars["book_id"])
+	if err != nil {
+		http.Error(w, "Invalid book ID", http.StatusBadRequest)
+		return
+	}
+
+	book, err := a.db.BookWithTranslations(bid, 0, -1)
+	if err != nil {
+		if err == ErrNotFound {
+			http.NotFound(w, r)
+			return
+		}
+		internalError(w, err)
+		return
+	}
+
+	a.serveStatistics(w, r, &book, []Book{book})
+}
+
+func (a *App) Statistics(w http.ResponseWriter, r *http.Request) {
+	all, err := a.db.Books()
+	if err != nil {
+		internalError(w, err)
+		return
+	}
+	// The archived books are left out, as they are on the index page.
+	var books []Book
+	for _, b := range all {
+		if !b.Archived {
+			books = append(books, b)
+		}
+	}
+	books, err = a.booksWithTranslations(books)
+	if err != nil {
+		internalError(w, err)
+		return
+	}
+
+	a.serveStatistics(w, r, nil, books)
+}
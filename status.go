@@ -0,0 +1,169 @@
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/boltdb/bolt"
+	"github.com/gorilla/mux"
+)
+
+// The statuses of the review workflow. Only the statuses of the translated
+// fragments count; a fragment without a status is a draft.
+const (
+	statusDraft    = "draft"
+	statusReview   = "review"
+	statusApproved = "approved"
+)
+
+type fragmentStatus struct {
+	Name  string
+	Title string
+}
+
+var fragmentStatuses = []fragmentStatus{
+	{statusDraft, "Draft"},
+	{statusReview, "Needs review"},
+	{statusApproved, "Approved"},
+}
+
+func validStatus(status string) bool {
+	for _, s := range fragmentStatuses {
+		if s.Name == status {
+			return true
+		}
+	}
+	return false
+}
+
+// StatusName returns the status of the fragment, statusDraft if it has
+// none.
+func (f Fragment) StatusName() string {
+	if f.Status == "" {
+		return statusDraft
+	}
+	return f.Status
+}
+
+func (f Fragment) StatusTitle() string {
+	name := f.StatusName()
+	for _, s := range fragmentStatuses {
+		if s.Name == name {
+			return s.Title
+		}
+	}
+	return name
+}
+
+// FragmentsDraft is the number of the translated fragments which are
+// neither in review nor approved.
+func (st bookStats) FragmentsDraft() int {
+	return st.FragmentsTranslated - st.FragmentsInReview - st.FragmentsApproved
+}
+
+// countStatuses fills in the status counts of the book from the counts
+// kept by the filter index.
+func countStatuses(tx *bolt.Tx, bid uint64, st *bookStats) {
+	st.FragmentsInReview = setCount(tx, bid, setReview)
+	st.FragmentsApproved = setCount(tx, bid, setApproved)
+}
+
+// statusSet returns the filter set of the translated fragments with the
+// status.
+func statusSet(status string) string {
+	switch status {
+	case statusReview:
+		return setReview
+	case statusApproved:
+		return setApproved
+	}
+	return setDraft
+}
+
+// storedStatus is the status as it is kept in the database: drafts have
+// none.
+func storedStatus(status string) string {
+	if status == statusDraft {
+		return ""
+	}
+	return status
+}
+
+func (db *DB) SetFragmentStatus(bid, fid uint64, status string) error {
+	return db.Update(func(tx *bolt.Tx) error {
+		if _, err := indexedBook(tx, bid); err != nil {
+			return err
+		}
+		if !hasFragment(tx, bid, fid) {
+			return ErrNotFound
+		}
+
+		fb := tx.Bucket([]byte("fragments")).Bucket(encode(bid))
+		var f Fragment
+		if found, err := unmarshal(fb, fid, &f); err != nil {
+			return err
+		} else if !found {
+			return ErrNotFound
+		}
+		status = storedStatus(status)
+		if f.Status == status {
+			return nil
+		}
+
+		f.Status = status
+		if err := marshal(fb, fid, f); err != nil {
+			return err
+		}
+
+		return reindexFragment(tx, bid, fid)
+	})
+}
+
+func (a *App) FragmentStatus(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	bid, err := u64(vars["book_id"])
+	if err != nil {
+		http.Error(w, "Invalid book ID", http.StatusBadRequest)
+		return
+	}
+	fid, err := u64(vars["fragment_id"])
+	if err != nil {
+		http.Error(w, "Invalid fragment ID", http.StatusBadRequest)
+		return
+	}
+	status := r.FormValue("status")
+	if !validStatus(status) {
+		http.Error(w, "Invalid status", http.StatusBadRequest)
+		return
+	}
+
+	if err := a.db.SetFragmentStatus(bid, fid, status); err != nil {
+		if err == ErrNotFound {
+			http.Error(w, "Fragment not found", 404)
+			return
+		}
+		internalError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(struct {
+		Status string `json:"status"`
+	}{
+		status,
+	})
+}
@@ -0,0 +1,165 @@
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// Storage is the translation database. It is implemented by DB (boltdb)
+// and SQLiteDB.
+type Storage interface {
+	Books() ([]Book, error)
+	BooksByActivity() ([]Book, error)
+	BookByID(bid uint64) (Book, error)
+	BookWithTranslations(bid uint64, from, size int, filters ...bookFilter) (Book, error)
+	AddBook(title string, fragments []string, autotranslate bool) (uint64, error)
+	AddTranslatedBook(title string, fragments [][]string) (uint64, error)
+	UpdateBookTitle(bid uint64, title string) error
+	UpdateBookMeta(bid uint64, m BookMeta) error
+	SetBookCollection(bid uint64, name string) error
+	ArchiveBook(bid uint64, archived bool) error
+	SetDailyGoal(bid uint64, g *DailyGoal) error
+	TranslatedDays(bid uint64) (map[string]dayCount, error)
+	UpdateLastVisitedPage(bid uint64, page int) error
+	RemoveBook(bid uint64) error
+
+	TrashedBooks() ([]Book, error)
+	RestoreBook(bid uint64) error
+	PurgeBook(bid uint64) error
+	PurgeTrash(t time.Time) (int, error)
+
+	AddFragment(bid, fidAfter uint64, text string) (Fragment, error)
+	UpdateFragment(bid, fid uint64, text string) error
+	RemoveFragment(bid, fid uint64) (int, error)
+	StarFragment(bid, fid uint64) error
+	UnstarFragment(bid, fid uint64) error
+	SetFragmentStatus(bid, fid uint64, status string) error
+	SourceHistory(bid, fid uint64) ([]Revision, error)
+
+	Translate(bid, fid, vidOrZero uint64, text string) (TranslationVersion, int, error)
+	RewriteVersions(bid uint64, rewrite func(string) string) ([]VersionChange, error)
+	RemoveVersion(bid, fid, vid uint64) (int, error)
+	SetPreferredVersion(bid, fid, vidOrZero uint64) error
+	History(bid, fid, vid uint64) ([]Revision, error)
+
+	Scratchpad(bid uint64) (Book, Scratchpad, error)
+	UpdateScratchpad(bid uint64, text string) error
+
+	Glossary(bid uint64) (Book, []Term, error)
+	AddTerm(bid uint64, t Term) (Term, error)
+	UpdateTerm(bid uint64, t Term) (Term, error)
+	RemoveTerm(bid, tid uint64) error
+
+	SavedFilters(bid uint64) ([]SavedFilter, error)
+	SaveFilter(bid uint64, f SavedFilter) error
+	RemoveFilter(bid uint64, name string) error
+
+	Threads(bid, fid uint64) ([]Thread, error)
+	AddThread(bid, fid uint64, c Comment) (Thread, error)
+	ReplyToThread(bid, fid, tid uint64, c Comment) (Comment, error)
+	ResolveThread(bid, fid, tid uint64, resolved bool) error
+	RemoveComment(bid, fid, tid, cid uint64) error
+
+	Search(query string, limit int) (results []SearchResult, truncated bool, err error)
+	TranslationMemory(bid, fid uint64, limit int) ([]TMMatch, error)
+
+	ExportBook(bid uint64) (BookData, error)
+	ImportBook(data BookData, keepIDs bool) (uint64, error)
+	Backup(w io.Writer, size func(int64)) error
+	Close() error
+}
+
+// BookData is everything stored about a book. It is the format of the
+// JSON export.
+type BookData struct {
+	Book          Book                  `json:"book"`
+	Fragments     []Fragment            `json:"fragments"`
+	Versions      []TranslationVersion  `json:"versions"`
+	Scratchpad    *Scratchpad           `json:"scratchpad"`
+	Glossary      []Term                `json:"glossary,omitempty"`
+	Filters       []SavedFilter         `json:"filters,omitempty"`
+	History       map[uint64][]Revision `json:"history,omitempty"`
+	SourceHistory map[uint64][]Revision `json:"source_history,omitempty"`
+}
+
+// OpenStorage opens the database specified by dataSource, which is either
+// a path to a boltdb file (optionally prefixed with "bolt:") or a path to
+// an SQLite database prefixed with "sqlite:".
+func OpenStorage(dataSource string) (Storage, error) {
+	if strings.HasPrefix(dataSource, "sqlite:") {
+		return OpenSQLite(strings.TrimPrefix(dataSource, "sqlite:"))
+	}
+	db, err := OpenDatabase(strings.TrimPrefix(dataSource, "bolt:"), 0600, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &db, nil
+}
+
+func ExportBookToJSON(db Storage, bid uint64) ([]byte, error) {
+	data, err := db.ExportBook(bid)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(data)
+}
+
+func ImportBookFromJSON(db Storage, data []byte) (uint64, error) {
+	var bd BookData
+	if err := json.Unmarshal(data, &bd); err != nil {
+		return 0, err
+	}
+	bd.Book.Deleted = time.Time{}
+	return db.ImportBook(bd, false)
+}
+
+// Convert copies all the books, including the removed ones, from one
+// database to another, which must be empty.
+func Convert(from, to Storage) (int, error) {
+	books, err := to.Books()
+	if err != nil {
+		return 0, err
+	}
+	trashed, err := to.TrashedBooks()
+	if err != nil {
+		return 0, err
+	}
+	if len(books) > 0 || len(trashed) > 0 {
+		return 0, fmt.Errorf("the destination database is not empty")
+	}
+
+	books, err = from.Books()
+	if err != nil {
+		return 0, err
+	}
+	trashed, err = from.TrashedBooks()
+	if err != nil {
+		return 0, err
+	}
+	for _, book := range append(books, trashed...) {
+		data, err := from.ExportBook(book.ID)
+		if err != nil {
+			return 0, err
+		}
+		if _, err := to.ImportBook(data, true); err != nil {
+			return 0, err
+		}
+	}
+	return len(books) + len(trashed), nil
+}
@@ -32,20 +32,30 @@ var (
 		"pretty":      pretty,
 		"rfc3339":     rfc3339,
 		"datetimeStr": datetimeStr,
+		"timeAdd":     timeAdd,
 		"dec":         dec,
 		"inc":         inc,
 		"max":         max,
 		"seq":         seq,
 		"render":      render,
 		"renderhl":    renderhl,
+		"renderTerms": renderTerms,
 	}
-	indexTmpl      = mustParse("index")
-	addTmpl        = mustParse("add")
-	bookTmpl       = mustParse("book")
-	removeTmpl     = mustParse("remove")
-	readTmpl       = mustParse("read")
-	scratchpadTmpl = mustParse("scratchpad")
-	alignerTmpl    = mustParse("aligner")
+	indexTmpl       = mustParse("index")
+	addTmpl         = mustParse("add")
+	bookTmpl        = mustParse("book")
+	removeTmpl      = mustParse("remove")
+	readTmpl        = mustParse("read")
+	scratchpadTmpl  = mustParse("scratchpad")
+	alignerTmpl     = mustParse("aligner")
+	trashTmpl       = mustParse("trash")
+	searchTmpl      = mustParse("search")
+	glossaryTmpl    = mustParse("glossary")
+	terminologyTmpl = mustParse("terminology")
+	typographyTmpl  = mustParse("typography")
+	replaceTmpl     = mustParse("replace")
+	statsTmpl       = mustParse("stats")
+	reportTmpl      = mustParse("report")
 
 	rBigWords = regexp.MustCompile(`[^\s<>&;]{32,}`)
 	r16Chars  = regexp.MustCompile(`.{16}`)
@@ -106,6 +116,10 @@ func datetimeStr(t time.Time) string {
 	return t.Format("02-Jan-2006 15:04:05")
 }
 
+func timeAdd(t time.Time, d time.Duration) time.Time {
+	return t.Add(d)
+}
+
 func pretty(t time.Time) string {
 	seconds := time.Since(t).Nanoseconds() / 1e9
 	days := seconds / (60 * 60 * 24)
@@ -0,0 +1,390 @@
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/gorilla/mux"
+	"github.com/opennota/morph"
+)
+
+const (
+	// autoTermMinFragments is the number of translated fragments a name
+	// has to occur in to be checked without a glossary entry.
+	autoTermMinFragments = 3
+	// autoTermLimit is the maximum number of names checked without a
+	// glossary entry.
+	autoTermLimit = 50
+)
+
+// Kinds of terminology findings.
+const (
+	// The translation doesn't contain the rendering of the term.
+	termDifferent = "different"
+	// The translation contains the term itself instead of its rendering.
+	termUntranslated = "untranslated"
+)
+
+// TermFinding is a fragment where a term isn't translated with its
+// established rendering.
+type TermFinding struct {
+	FragmentID   uint64   `json:"fragment_id"`
+	SeqNum       int      `json:"seq_num"`
+	Kind         string   `json:"kind"`
+	Original     string   `json:"original"`
+	Translations []string `json:"translations"`
+}
+
+// TermConsistency is the result of checking one term. Auto is true if the
+// term isn't in the glossary but was found as a recurring name, in which
+// case the rendering is the one used most often.
+type TermConsistency struct {
+	Source    string        `json:"source"`
+	Rendering string        `json:"rendering"`
+	Auto      bool          `json:"auto"`
+	Fragments int           `json:"fragments"`
+	Findings  []TermFinding `json:"findings"`
+}
+
+// wordKeyer maps words to keys which are the same for the forms of a word:
+// the normal forms if morphological analysis is available and knows the
+// word, otherwise a crude stem.
+type wordKeyer map[string][]string
+
+func (k wordKeyer) keys(w string) []string {
+	w = yoReplacer.Replace(strings.ToLower(w))
+	if keys, ok := k[w]; ok {
+		return keys
+	}
+	var keys []string
+	if useMorph {
+		_, norms, _ := morph.Parse(w)
+		for _, n := range norms {
+			keys = append(keys, yoReplacer.Replace(n))
+		}
+	}
+	if len(keys) == 0 {
+		keys = []string{stem(w)}
+	}
+	k[w] = keys
+	return keys
+}
+
+// stem drops up to two last letters of a word, leaving at least three.
+func stem(w string) string {
+	r := []rune(w)
+	n := len(r) - 2
+	if n < 3 {
+		n = min(len(r), 3)
+	}
+	return string(r[:n])
+}
+
+// textKeys returns the set of the keys of the words of the texts.
+func (k wordKeyer) textKeys(texts ...string) map[string]bool {
+	set := make(map[string]bool)
+	for _, text := range texts {
+		for _, w := range rWord.FindAllString(text, -1) {
+			for _, key := range k.keys(w) {
+				set[key] = true
+			}
+		}
+	}
+	return set
+}
+
+// renders reports whether the words of the phrase all occur, in some form,
+// among the keys.
+func (k wordKeyer) renders(phrase string, keys map[string]bool) bool {
+	words := rWord.FindAllString(phrase, -1)
+	if len(words) == 0 {
+		return false
+	}
+next:
+	for _, w := range words {
+		for _, key := range k.keys(w) {
+			if keys[key] {
+				continue next
+			}
+		}
+		return false
+	}
+	return true
+}
+
+func versionTexts(f Fragment) []string {
+	texts := make([]string, len(f.Versions))
+	for i, v := range f.Versions {
+		texts[i] = v.Text
+	}
+	return texts
+}
+
+// checkRendering returns a finding if none of the translations of the
+// fragment contains the rendering of the term.
+func checkRendering(k wordKeyer, f Fragment, keys map[string]bool, source, rendering string) (TermFinding, bool) {
+	if k.renders(rendering, keys) {
+		return TermFinding{}, false
+	}
+	translations := versionTexts(f)
+	kind := termDifferent
+	m := newGlossaryMatcher([]Term{{Source: source}})
+	for _, t := range translations {
+		if len(m.find(t)) > 0 {
+			kind = termUntranslated
+			break
+		}
+	}
+	return TermFinding{
+		FragmentID:   f.ID,
+		SeqNum:       f.SeqNum,
+		Kind:         kind,
+		Original:     f.Text,
+		Translations: translations,
+	}, true
+}
+
+// atSentenceStart reports whether text[:i] ends a sentence, so that a
+// capitalized word at i needn't be a name.
+func atSentenceStart(text string, i int) bool {
+	s := strings.TrimRightFunc(text[:i], func(r rune) bool {
+		return unicode.IsSpace(r) || strings.ContainsRune(`"'«»“”„‘’()[]—–-`, r)
+	})
+	if s == "" {
+		return true
+	}
+	r, _ := utf8.DecodeLastRuneInString(s)
+	return strings.ContainsRune(".!?…:;", r)
+}
+
+// recurringNames returns the capitalized words which occur in the middle of
+// sentences in at least autoTermMinFragments of the fragments, the most
+// frequent first.
+func recurringNames(fragments []Fragment, skip map[string]bool) []string {
+	count := make(map[string]int)
+	for _, f := range fragments {
+		seen := make(map[string]bool)
+		for _, idx := range rWord.FindAllStringIndex(f.Text, -1) {
+			w := f.Text[idx[0]:idx[1]]
+			r, _ := utf8.DecodeRuneInString(w)
+			if !unicode.IsUpper(r) || utf8.RuneCountInString(w) < 2 ||
+				skip[strings.ToLower(w)] || atSentenceStart(f.Text, idx[0]) {
+				continue
+			}
+			if !seen[w] {
+				seen[w] = true
+				count[w]++
+			}
+		}
+	}
+	var names []string
+	for w, n := range count {
+		if n >= autoTermMinFragments {
+			names = append(names, w)
+		}
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if count[names[i]] != count[names[j]] {
+			return count[names[i]] > count[names[j]]
+		}
+		return names[i] < names[j]
+	})
+	if len(names) > autoTermLimit {
+		names = names[:autoTermLimit]
+	}
+	return names
+}
+
+// establishedRendering returns the word most likely to be the translation
+// of a name: the one which occurs in most of the translations of the
+// fragments with the name, and mostly in them.
+func establishedRendering(k wordKeyer, withName []int, keys []map[string]bool, df map[string]int, fragments []Fragment) (key, rendering string, ok bool) {
+	count := make(map[string]int)
+	for _, i := range withName {
+		for key := range keys[i] {
+			count[key]++
+		}
+	}
+	best := 0
+	for c, n := range count {
+		if n < 2 || 2*n < len(withName) || 2*n < df[c] || utf8.RuneCountInString(c) < 2 {
+			continue
+		}
+		if n > best || n == best && c < key {
+			key, best = c, n
+		}
+	}
+	if best == 0 {
+		return "", "", false
+	}
+
+	// Show the form of the word used most often.
+	forms := make(map[string]int)
+	for _, i := range withName {
+		for _, t := range versionTexts(fragments[i]) {
+			for _, w := range rWord.FindAllString(t, -1) {
+				for _, c := range k.keys(w) {
+					if c == key {
+						forms[w]++
+					}
+				}
+			}
+		}
+	}
+	for w, n := range forms {
+		if n > forms[rendering] || n == forms[rendering] && w < rendering {
+			rendering = w
+		}
+	}
+	return key, rendering, true
+}
+
+// checkTerminology checks that the glossary terms and the recurring names
+// are translated the same way throughout the book. The fragments are those
+// returned by BookWithTranslations, with the glossary terms attached.
+func checkTerminology(fragments []Fragment, glossary []Term) []TermConsistency {
+	k := make(wordKeyer)
+	var translated []Fragment
+	for _, f := range fragments {
+		if len(f.Versions) > 0 {
+			translated = append(translated, f)
+		}
+	}
+	keys := make([]map[string]bool, len(translated))
+	df := make(map[string]int)
+	for i, f := range translated {
+		keys[i] = k.textKeys(versionTexts(f)...)
+		for key := range keys[i] {
+			df[key]++
+		}
+	}
+
+	results := []TermConsistency{}
+	skip := make(map[string]bool)
+	for _, t := range glossary {
+		for _, w := range strings.Fields(strings.ToLower(t.Source)) {
+			skip[w] = true
+		}
+		if t.Target == "" {
+			continue
+		}
+		tc := TermConsistency{Source: t.Source, Rendering: t.Target}
+		for i, f := range translated {
+			found := false
+			for _, ft := range f.Terms {
+				if ft.ID == t.ID {
+					found = true
+					break
+				}
+			}
+			if !found {
+				continue
+			}
+			tc.Fragments++
+			if finding, ok := checkRendering(k, f, keys[i], t.Source, t.Target); ok {
+				tc.Findings = append(tc.Findings, finding)
+			}
+		}
+		results = append(results, tc)
+	}
+
+	for _, name := range recurringNames(translated, skip) {
+		m := newGlossaryMatcher([]Term{{Source: name}})
+		var withName []int
+		for i, f := range translated {
+			if len(m.find(f.Text)) > 0 {
+				withName = append(withName, i)
+			}
+		}
+		key, rendering, ok := establishedRendering(k, withName, keys, df, translated)
+		if !ok {
+			continue
+		}
+		tc := TermConsistency{Source: name, Rendering: rendering, Auto: true, Fragments: len(withName)}
+		for _, i := range withName {
+			if keys[i][key] {
+				continue
+			}
+			if finding, ok := checkRendering(k, translated[i], keys[i], name, rendering); ok {
+				tc.Findings = append(tc.Findings, finding)
+			}
+		}
+		results = append(results, tc)
+	}
+
+	return results
+}
+
+func (a *App) Terminology(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	bid, err := u64(vars["book_id"])
+	if err != nil {
+		http.Error(w, "Invalid book ID", http.StatusBadRequest)
+		return
+	}
+
+	_, glossary, err := a.db.Glossary(bid)
+	if err != nil {
+		if err == ErrNotFound {
+			http.NotFound(w, r)
+			return
+		}
+		internalError(w, err)
+		return
+	}
+	book, err := a.db.BookWithTranslations(bid, 0, -1)
+	if err != nil {
+		if err == ErrNotFound {
+			http.NotFound(w, r)
+			return
+		}
+		internalError(w, err)
+		return
+	}
+
+	results := checkTerminology(book.Fragments, glossary)
+
+	if r.FormValue("f") == "json" {
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(results); err != nil {
+			logError(err)
+		}
+		return
+	}
+
+	findings := 0
+	for _, tc := range results {
+		findings += len(tc.Findings)
+	}
+	w.Header().Set("Content-Type", "text/html")
+	if err := terminologyTmpl.Execute(w, struct {
+		Book     Book
+		URL      string
+		Results  []TermConsistency
+		Findings int
+	}{
+		book,
+		r.FormValue("url"),
+		results,
+		findings,
+	}); err != nil {
+		logError(err)
+	}
+}
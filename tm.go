@@ -0,0 +1,295 @@
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"html/template"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/boltdb/bolt"
+	"github.com/gorilla/mux"
+)
+
+const (
+	// tmMinScore is the lowest similarity, in percent, of a translation
+	// memory match.
+	tmMinScore = 50
+	// tmLimit is the number of translation memory matches returned.
+	tmLimit = 5
+)
+
+// TMMatch is a translated fragment whose original is similar to the
+// original of the fragment being translated.
+type TMMatch struct {
+	BookID      uint64 `json:"book_id"`
+	Title       string `json:"title"`
+	FragmentID  uint64 `json:"fragment_id"`
+	Score       int    `json:"score"`
+	Original    string `json:"original"`
+	Translation string `json:"translation"`
+}
+
+// tmText prepares a text for fuzzy matching: the case and the amount of
+// whitespace don't matter.
+func tmText(s string) []rune {
+	return []rune(strings.Join(strings.Fields(strings.ToLower(s)), " "))
+}
+
+// editDistance returns the Levenshtein distance between a and b, or
+// limit+1 if the distance is greater than limit.
+func editDistance(a, b []rune, limit int) int {
+	if len(a) < len(b) {
+		a, b = b, a
+	}
+	if len(a)-len(b) > limit {
+		return limit + 1
+	}
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		rowMin := i
+		for j := 1; j <= len(b); j++ {
+			d := prev[j-1]
+			if a[i-1] != b[j-1] {
+				d = 1 + min(d, min(prev[j], cur[j-1]))
+			}
+			cur[j] = d
+			rowMin = min(rowMin, d)
+		}
+		if rowMin > limit {
+			return limit + 1
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
+
+// tmCollector keeps the best translation memory matches for a text.
+type tmCollector struct {
+	text    []rune
+	limit   int
+	matches []TMMatch
+	seen    map[string]bool
+}
+
+func newTMCollector(text string, limit int) *tmCollector {
+	return &tmCollector{
+		text:  tmText(text),
+		limit: limit,
+		seen:  make(map[string]bool),
+	}
+}
+
+// score returns the similarity of the original to the text in percent, or
+// -1 if it is below tmMinScore.
+func (c *tmCollector) score(orig string) int {
+	o := tmText(orig)
+	n := max(len(o), len(c.text))
+	if n == 0 {
+		return -1
+	}
+	maxDist := n * (100 - tmMinScore) / 100
+	d := editDistance(c.text, o, maxDist)
+	if d > maxDist {
+		return -1
+	}
+	return 100 * (n - d) / n
+}
+
+// add records the translations of a fragment whose original has the given
+// score. Identical pairs of an original and a translation are only recorded
+// once.
+func (c *tmCollector) add(book Book, fid uint64, orig string, score int, translations []string) {
+	for _, t := range translations {
+		key := orig + "\x00" + t
+		if c.seen[key] {
+			continue
+		}
+		c.seen[key] = true
+		c.matches = append(c.matches, TMMatch{
+			BookID:      book.ID,
+			Title:       book.Title,
+			FragmentID:  fid,
+			Score:       score,
+			Original:    orig,
+			Translation: t,
+		})
+	}
+}
+
+// result returns the best matches, the most similar first.
+func (c *tmCollector) result() []TMMatch {
+	sort.SliceStable(c.matches, func(i, j int) bool {
+		return c.matches[i].Score > c.matches[j].Score
+	})
+	if len(c.matches) > c.limit {
+		c.matches = c.matches[:c.limit]
+	}
+	if c.matches == nil {
+		return []TMMatch{}
+	}
+	return c.matches
+}
+
+// tmCandidates returns the order keys of the translated fragments of the
+// book whose originals have at least half of the trigrams tt, in the order
+// of the book. They are found in the trigram index of the originals, and
+// only they are worth scoring.
+func tmCandidates(tx *bolt.Tx, bid uint64, tt []string) [][]byte {
+	pb := tx.Bucket([]byte("filter_index")).Bucket(encode(bid))
+	if pb == nil || len(tt) == 0 {
+		return nil
+	}
+	tb := pb.Bucket([]byte("orig_trigrams"))
+	if tb == nil {
+		return nil
+	}
+	counts := make(map[string]int)
+	for _, t := range tt {
+		prefix := trigramKey(t, nil)
+		c := tb.Cursor()
+		for k, _ := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, _ = c.Next() {
+			counts[string(k[len(prefix):])]++
+		}
+	}
+	untranslated := pb.Bucket([]byte(setUntranslated))
+	var keys [][]byte
+	for k, n := range counts {
+		if 2*n < len(tt) || untranslated != nil && untranslated.Get([]byte(k)) != nil {
+			continue
+		}
+		keys = append(keys, []byte(k))
+	}
+	sort.Slice(keys, func(i, j int) bool { return bytes.Compare(keys[i], keys[j]) < 0 })
+	return keys
+}
+
+// TranslationMemory returns the translations of the fragments of all the
+// books whose originals are the most similar to the original of the
+// fragment.
+func (db *DB) TranslationMemory(bid, fid uint64, limit int) ([]TMMatch, error) {
+	var matches []TMMatch
+	err := db.View(func(tx *bolt.Tx) error {
+		if _, err := indexedBook(tx, bid); err != nil {
+			return err
+		}
+		if !hasFragment(tx, bid, fid) {
+			return ErrNotFound
+		}
+		var f Fragment
+		if found, err := unmarshal(tx.Bucket([]byte("fragments")).Bucket(encode(bid)), fid, &f); err != nil {
+			return err
+		} else if !found {
+			return ErrNotFound
+		}
+
+		c := newTMCollector(f.Text, limit)
+		tt := trigrams(indexWords(f.Text))
+		books, err := listBooks(tx, "index")
+		if err != nil {
+			return err
+		}
+		for _, book := range books {
+			fb := tx.Bucket([]byte("fragments")).Bucket(encode(book.ID))
+			vb := tx.Bucket([]byte("versions")).Bucket(encode(book.ID))
+			ob := tx.Bucket([]byte("order")).Bucket(encode(book.ID))
+			if fb == nil || vb == nil || ob == nil {
+				continue
+			}
+			for _, key := range tmCandidates(tx, book.ID, tt) {
+				v := ob.Get(key)
+				if v == nil {
+					continue
+				}
+				id := decode(v)
+				if book.ID == bid && id == fid {
+					continue
+				}
+				var f Fragment
+				if found, err := unmarshal(fb, id, &f); err != nil {
+					return err
+				} else if !found {
+					continue
+				}
+				score := c.score(f.Text)
+				if score < 0 {
+					continue
+				}
+				var translations []string
+				for _, vid := range f.VersionsIDs {
+					var v TranslationVersion
+					if found, err := unmarshal(vb, vid, &v); err != nil {
+						return err
+					} else if found {
+						translations = append(translations, v.Text)
+					}
+				}
+				c.add(book, id, f.Text, score, translations)
+			}
+		}
+		matches = c.result()
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return matches, nil
+}
+
+func (a *App) TranslationMemory(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	bid, err := u64(vars["book_id"])
+	if err != nil {
+		http.Error(w, "Invalid book ID", http.StatusBadRequest)
+		return
+	}
+	fid, err := u64(vars["fragment_id"])
+	if err != nil {
+		http.Error(w, "Invalid fragment ID", http.StatusBadRequest)
+		return
+	}
+
+	matches, err := a.db.TranslationMemory(bid, fid, tmLimit)
+	if err != nil {
+		if err == ErrNotFound {
+			http.Error(w, "Fragment not found", 404)
+			return
+		}
+		internalError(w, err)
+		return
+	}
+
+	type match struct {
+		TMMatch
+		HTML template.HTML `json:"html"`
+	}
+	result := make([]match, 0, len(matches))
+	for _, m := range matches {
+		result = append(result, match{m, render(m.Translation)})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(result); err != nil {
+		logError(err)
+	}
+}
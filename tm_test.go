@@ -0,0 +1,65 @@
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import "testing"
+
+// TestTranslationMemoryFollowsEdits checks that the translation memory
+// offers a translated fragment only while it is translated and similar.
+func TestTranslationMemoryFollowsEdits(t *testing.T) {
+	for _, db := range openTestStorages(t) {
+		src, err := db.AddBook("Source", []string{"The quick brown fox jumps over the dog.", "Nothing alike here."}, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		v, _, err := db.Translate(src, 1, 0, "Быстрая лиса прыгает через собаку.")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, _, err := db.Translate(src, 2, 0, "Ничего похожего."); err != nil {
+			t.Fatal(err)
+		}
+		bid, err := db.AddBook("Book", []string{"The quick brown fox jumped over the dog."}, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		check := func(want int) {
+			t.Helper()
+			matches, err := db.TranslationMemory(bid, 1, tmLimit)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(matches) != want {
+				t.Fatalf("%T: got %d matches, want %d: %+v", db, len(matches), want, matches)
+			}
+			if want > 0 && (matches[0].BookID != src || matches[0].FragmentID != 1 || matches[0].Score < 90) {
+				t.Fatalf("%T: got %+v", db, matches[0])
+			}
+		}
+		check(1)
+		if _, err := db.RemoveVersion(src, 1, v.ID); err != nil {
+			t.Fatal(err)
+		}
+		check(0)
+		if _, _, err := db.Translate(src, 1, 0, "Быстрая лиса прыгает через собаку."); err != nil {
+			t.Fatal(err)
+		}
+		check(1)
+		if err := db.UpdateFragment(src, 1, "Something else entirely."); err != nil {
+			t.Fatal(err)
+		}
+		check(0)
+	}
+}
@@ -0,0 +1,182 @@
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/boltdb/bolt"
+	"github.com/gorilla/mux"
+)
+
+func (db *DB) TrashedBooks() ([]Book, error) {
+	var books []Book
+	err := db.View(func(tx *bolt.Tx) error {
+		var err error
+		books, err = listBooks(tx, "trash")
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(books, func(i, j int) bool {
+		if a, b := books[i].Deleted, books[j].Deleted; !a.Equal(b) {
+			return b.Before(a)
+		}
+		return books[i].ID > books[j].ID
+	})
+	return books, nil
+}
+
+func (db *DB) RestoreBook(bid uint64) error {
+	return db.Update(func(tx *bolt.Tx) error {
+		tb := tx.Bucket([]byte("trash"))
+		var book bookInfo
+		if found, err := unmarshal(tb, bid, &book); err != nil {
+			return err
+		} else if !found {
+			return ErrNotFound
+		}
+		book.Deleted = time.Time{}
+		if err := marshal(tx.Bucket([]byte("index")), bid, book); err != nil {
+			return err
+		}
+		return tb.Delete(encode(bid))
+	})
+}
+
+// PurgeBook permanently removes the trashed book and all its data.
+func (db *DB) PurgeBook(bid uint64) error {
+	return db.Update(func(tx *bolt.Tx) error {
+		tb := tx.Bucket([]byte("trash"))
+		key := encode(bid)
+		if tb.Get(key) == nil {
+			return ErrNotFound
+		}
+		if err := tb.Delete(key); err != nil {
+			return err
+		}
+		return removeBookData(tx, bid)
+	})
+}
+
+// PurgeTrash permanently removes the books which were moved to the trash
+// before t. It returns the number of books removed.
+func (db *DB) PurgeTrash(t time.Time) (int, error) {
+	var n int
+	err := db.Update(func(tx *bolt.Tx) error {
+		n = 0
+		tb := tx.Bucket([]byte("trash"))
+		var ids []uint64
+		c := tb.Cursor()
+		for k, v := c.First(); k != nil; k, v = c.Next() {
+			var book bookInfo
+			if err := json.Unmarshal(v, &book); err != nil {
+				return err
+			}
+			if book.Deleted.Before(t) {
+				ids = append(ids, book.ID)
+			}
+		}
+		for _, bid := range ids {
+			if err := tb.Delete(encode(bid)); err != nil {
+				return err
+			}
+			if err := removeBookData(tx, bid); err != nil {
+				return err
+			}
+		}
+		n = len(ids)
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return n, nil
+}
+
+func purgeTrashPeriodically(db Storage, retention time.Duration) {
+	for {
+		n, err := db.PurgeTrash(time.Now().Add(-retention))
+		if err != nil {
+			logError(err)
+		} else if n > 0 {
+			log.Printf("purged %d books from the trash", n)
+		}
+		time.Sleep(time.Hour)
+	}
+}
+
+func (a *App) Trash(w http.ResponseWriter, r *http.Request) {
+	books, err := a.db.TrashedBooks()
+	if err != nil {
+		internalError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html")
+	if err := trashTmpl.Execute(w, struct {
+		Books     []Book
+		Retention time.Duration
+	}{
+		books,
+		*trashRetention,
+	}); err != nil {
+		logError(err)
+	}
+}
+
+func (a *App) RestoreBook(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	bid, err := u64(vars["book_id"])
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	if err := a.db.RestoreBook(bid); err != nil {
+		if err == ErrNotFound {
+			http.Error(w, "Book not found", 404)
+			return
+		}
+		internalError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (a *App) PurgeBook(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	bid, err := u64(vars["book_id"])
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	if err := a.db.PurgeBook(bid); err != nil {
+		if err == ErrNotFound {
+			http.Error(w, "Book not found", 404)
+			return
+		}
+		internalError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
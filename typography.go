@@ -0,0 +1,226 @@
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"encoding/json"
+	"html/template"
+	"net/http"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/gorilla/mux"
+)
+
+const nbsp = "\u00a0"
+
+var (
+	rCyrillic      = regexp.MustCompile(`\p{Cyrillic}`)
+	rEllipsis      = regexp.MustCompile(`\.\.\.`)
+	rDialogueDash  = regexp.MustCompile(`(?m)^([ \t]*)[-–][ \t]+`)
+	rSentenceDash  = regexp.MustCompile(`([^\s])[ \t\x{a0}]+[-–—][ \t]+`)
+	rDanglingDash  = regexp.MustCompile(`([^\s])[ \t\x{a0}]+[-–—]$`)
+	shortWordsList = strings.Fields(`
+		а б бы в во да до же за и из к ко ли на не ни но о об обо
+		от ото по с со то у уж`)
+	shortWords = make(map[string]bool)
+)
+
+func init() {
+	for _, w := range shortWordsList {
+		shortWords[w] = true
+	}
+}
+
+// VersionChange is a change made to the text of a translation version by a
+// rewrite of a whole book.
+type VersionChange struct {
+	FragmentID uint64 `json:"fragment_id"`
+	VersionID  uint64 `json:"version_id"`
+	SeqNum     int    `json:"seq_num"`
+	Old        string `json:"old"`
+	New        string `json:"new"`
+}
+
+// versionChanges returns the changes the rewrite would make to the
+// translations of the fragments.
+func versionChanges(fragments []Fragment, rewrite func(string) string) []VersionChange {
+	changes := []VersionChange{}
+	for _, f := range fragments {
+		for _, v := range f.Versions {
+			if t := rewrite(v.Text); t != v.Text {
+				changes = append(changes, VersionChange{f.ID, v.ID, f.SeqNum, v.Text, t})
+			}
+		}
+	}
+	return changes
+}
+
+// typograph applies the rules of Russian typography to a translation:
+// straight quotes become «ёлочки», with „лапки“ inside them, hyphens
+// starting a line of dialogue or separating parts of a sentence become
+// em dashes, short prepositions and conjunctions are bound to the
+// following word with a non-breaking space, and three dots become an
+// ellipsis. Texts without Cyrillic letters are returned unchanged.
+func typograph(s string) string {
+	if !rCyrillic.MatchString(s) {
+		return s
+	}
+	s = rEllipsis.ReplaceAllString(s, "…")
+	s = rDialogueDash.ReplaceAllString(s, "${1}— ")
+	s = rSentenceDash.ReplaceAllString(s, "${1}"+nbsp+"— ")
+	s = rDanglingDash.ReplaceAllString(s, "${1}"+nbsp+"—")
+	s = typographQuotes(s)
+	return bindShortWords(s)
+}
+
+// opensQuote reports whether a straight quote following the rune r opens a
+// quotation.
+func opensQuote(r rune) bool {
+	return unicode.IsSpace(r) || strings.ContainsRune("([{«„—–-/", r)
+}
+
+// typographQuotes replaces the straight double quotes with «ёлочки», and
+// those nested in them with „лапки“.
+func typographQuotes(s string) string {
+	if !strings.Contains(s, `"`) {
+		return s
+	}
+	var b strings.Builder
+	var open []rune
+	prev := ' '
+	for _, r := range s {
+		switch r {
+		case '«', '„':
+			open = append(open, r)
+		case '»', '“':
+			if len(open) > 0 {
+				open = open[:len(open)-1]
+			}
+		case '"':
+			if opensQuote(prev) {
+				if len(open) > 0 {
+					r = '„'
+				} else {
+					r = '«'
+				}
+				open = append(open, r)
+			} else {
+				r = '»'
+				if len(open) > 0 {
+					if open[len(open)-1] == '„' {
+						r = '“'
+					}
+					open = open[:len(open)-1]
+				}
+			}
+		}
+		b.WriteRune(r)
+		prev = r
+	}
+	return b.String()
+}
+
+// bindShortWords replaces the space after a short preposition or
+// conjunction with a non-breaking one.
+func bindShortWords(s string) string {
+	var b strings.Builder
+	i := 0
+	for _, idx := range rWord.FindAllStringIndex(s, -1) {
+		from, to := idx[0], idx[1]
+		if !shortWords[strings.ToLower(s[from:to])] || !strings.HasPrefix(s[to:], " ") {
+			continue
+		}
+		if r, _ := utf8.DecodeRuneInString(s[to+1:]); to+1 == len(s) || unicode.IsSpace(r) {
+			continue
+		}
+		b.WriteString(s[i:to])
+		b.WriteString(nbsp)
+		i = to + 1
+	}
+	b.WriteString(s[i:])
+	return b.String()
+}
+
+func (a *App) Typography(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	bid, err := u64(vars["book_id"])
+	if err != nil {
+		http.Error(w, "Invalid book ID", http.StatusBadRequest)
+		return
+	}
+
+	switch r.Method {
+	case "GET":
+		book, err := a.db.BookWithTranslations(bid, 0, -1)
+		if err != nil {
+			if err == ErrNotFound {
+				http.NotFound(w, r)
+				return
+			}
+			internalError(w, err)
+			return
+		}
+		changes := versionChanges(book.Fragments, typograph)
+
+		if r.FormValue("f") == "json" {
+			w.Header().Set("Content-Type", "application/json")
+			if err := json.NewEncoder(w).Encode(changes); err != nil {
+				logError(err)
+			}
+			return
+		}
+
+		type change struct {
+			VersionChange
+			Diff template.HTML
+		}
+		rendered := make([]change, len(changes))
+		for i, c := range changes {
+			rendered[i] = change{c, renderDiff(c.Old, c.New)}
+		}
+		w.Header().Set("Content-Type", "text/html")
+		if err := typographyTmpl.Execute(w, struct {
+			Book    Book
+			URL     string
+			Changes []change
+		}{
+			book,
+			r.FormValue("url"),
+			rendered,
+		}); err != nil {
+			logError(err)
+		}
+
+	case "POST":
+		changes, err := a.db.RewriteVersions(bid, typograph)
+		if err != nil {
+			if err == ErrNotFound {
+				http.Error(w, "Book not found", 404)
+				return
+			}
+			internalError(w, err)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(struct {
+			Changed int `json:"changed"`
+		}{
+			len(changes),
+		})
+	}
+}